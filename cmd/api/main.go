@@ -2,27 +2,40 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	handlerHttp "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http"
 	redisclient "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/cache"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/config"
 	database "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/database"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/embedding"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/external_services"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/jwt"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/logger"
 	passwordservice "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/password_service"
 	randomgenerator "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/random_generator"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/repository/mongodb"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/search"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/store"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/validator"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish on shutdown.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
 	// Load environment variables from .env file
 	if err := godotenv.Load(); err != nil {
@@ -66,6 +79,17 @@ func main() {
 	blogRepo := mongodb.NewBlogRepository(mongoClient.Client.Database(dbName), userCollection)
 	likeRepo := mongodb.NewLikeRepository(mongoClient.Client.Database(dbName))
 	commentRepo := mongodb.NewCommentRepository(mongoClient.Client.Database(dbName))
+	moderationLogRepo := mongodb.NewModerationLogRepository(mongoClient.Client.Database(dbName))
+	notificationRepo := mongodb.NewNotificationRepository(mongoClient.Client.Database(dbName))
+	counterRepo := mongodb.NewCounterRepository(mongoClient.Client.Database(dbName))
+	unitOfWork := mongodb.NewUnitOfWork(context.Background(), mongoClient.Client)
+	trendingRepo := mongodb.NewTrendingRepository(mongoClient.Client.Database(dbName))
+	analyticsRepo := mongodb.NewAnalyticsRepository(mongoClient.Client.Database(dbName))
+	viewFraudRepo := mongodb.NewViewFraudRepository(mongoClient.Client.Database(dbName))
+	bookmarkRepo := mongodb.NewBookmarkRepository(mongoClient.Client.Database(dbName))
+	followRepo := mongodb.NewFollowRepository(mongoClient.Client.Database(dbName))
+	relatedPostsRepo := mongodb.NewRelatedPostsRepository(mongoClient.Client.Database(dbName))
+	embeddingRepo := mongodb.NewEmbeddingRepository(mongoClient.Client.Database(dbName))
 
 	// Dependency Injection: Services
 	hasher := passwordservice.NewHasher()
@@ -89,37 +113,162 @@ func main() {
 	emailUsecase := usecase.NewEmailVerificationUseCase(tokenRepo, userRepo, mailService, randomGenerator, uuidGenerator, baseURL)
 	userUsecase := usecase.NewUserUsecase(userRepo, tokenRepo, emailUsecase, hasher, jwtService, mailService, appLogger, appConfig, appValidator, uuidGenerator, randomGenerator)
 
-	blogUsecase := usecase.NewBlogUseCase(blogRepo, uuidGenerator, appLogger, aiUsecase)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo, userRepo)
+	// Keyword search backend: the Mongo text index, or an in-process index loaded at startup
+	var searchIndex contract.IBlogSearchIndex
+	switch appConfig.GetSearchBackend() {
+	case "mongo":
+		searchIndex = mongodb.NewBlogTextSearch(mongoClient.Client.Database(dbName))
+	case "memory":
+		invertedIndex := search.NewInvertedIndex(blogRepo)
+		if err := invertedIndex.Rebuild(context.Background()); err != nil {
+			log.Fatalf("failed to build search index: %v", err)
+		}
+		searchIndex = invertedIndex
+	default:
+		log.Fatalf("unknown SEARCH_BACKEND %q: use mongo or memory", appConfig.GetSearchBackend())
+	}
+	blogUsecase := usecase.NewBlogUseCase(blogRepo, searchIndex, uuidGenerator, appLogger, aiUsecase)
+	blogUsecase.SetNotificationUsecase(notificationUsecase)
+	blogUsecase.SetUnitOfWork(unitOfWork)
+	viewFraudUsecase := usecase.NewViewFraudUsecase(appConfig.GetViewFraudRules(), viewFraudRepo, blogRepo, appLogger)
+	blogUsecase.SetViewFraudUsecase(viewFraudUsecase)
+
+	// Viewer IPs are stored as keyed daily hashes; the key must be shared by all instances
+	if secret := appConfig.GetViewIPHashSecret(); secret != "" {
+		blogUsecase.SetIPHashSecret(secret)
+	} else {
+		log.Println("VIEW_IP_HASH_SECRET not set, hashing viewer IPs with a per-process key")
+	}
 
 	// Pass Prometheus metrics to handlers or usecases as needed (import from metrics package)
 
+	// Buffer view counts and write them in batches; in memory unless Redis is available
+	viewFlushInterval := appConfig.GetViewFlushInterval()
+	if viewFlushInterval > 0 {
+		blogUsecase.SetViewBuffer(store.NewMemoryViewBuffer())
+	}
+
+	relatedPostsUsecase := usecase.NewRelatedPostsUsecase(relatedPostsRepo, blogRepo, appLogger)
+
 	// Optional Dependency Injection: Redis cache
 	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
 		rdb := redisclient.NewRedisFromURL(context.Background(), redisURL)
 		defer redisclient.Close(rdb)
 		blogCache := store.NewBlogCacheStore(rdb)
 		blogUsecase.SetBlogCache(blogCache)
+		relatedPostsUsecase.SetBlogCache(blogCache)
+		if viewFlushInterval > 0 {
+			blogUsecase.SetViewBuffer(store.NewRedisViewBuffer(rdb))
+		}
 	}
 
+	bookmarkUsecase := usecase.NewBookmarkUsecase(bookmarkRepo, blogRepo)
+	bookmarkUsecase.SetUnitOfWork(unitOfWork)
+	followUsecase := usecase.NewFollowUsecase(followRepo, blogRepo, userRepo)
+
 	// Create like usecase
-	likeUsecase := usecase.NewLikeUsecase(likeRepo, blogRepo)
+	likeUsecase := usecase.NewLikeUsecase(likeRepo, blogRepo, commentRepo)
+	likeUsecase.SetAllowedReactions(entity.TargetTypeBlog, appConfig.GetBlogReactionTypes())
+	likeUsecase.SetAllowedReactions(entity.TargetTypeComment, appConfig.GetCommentReactionTypes())
+	likeUsecase.SetUnitOfWork(unitOfWork)
+	commentModerator := usecase.NewCommentModerator(usecase.CommentModerationConfig{
+		RejectWords:          appConfig.GetCommentRejectWords(),
+		HoldWords:            appConfig.GetCommentHoldWords(),
+		MaxLinks:             appConfig.GetCommentMaxLinks(),
+		MaxRepeatedChars:     appConfig.GetCommentMaxRepeatedChars(),
+		NewAccountHoldPeriod: appConfig.GetCommentNewAccountHoldPeriod(),
+		ReportHideThreshold:  appConfig.GetCommentReportHideThreshold(),
+	})
+	commentUsecase := usecase.NewCommentUseCase(commentRepo, blogRepo, userRepo, moderationLogRepo, commentModerator, mailService, notificationUsecase, likeUsecase, unitOfWork)
+
+	// Periodically repair counters that drifted from the reaction and comment records
+	counterReconciler := usecase.NewCounterReconciler(counterRepo, appLogger)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	counterReconciler.Start(jobsCtx, appConfig.GetCounterReconcileInterval())
+
+	// Keep time-decayed trending scores fresh
+	trendingUsecase := usecase.NewTrendingUsecase(trendingRepo, appLogger, appConfig.GetTrendingGravity())
+	trendingUsecase.Start(jobsCtx, appConfig.GetTrendingRecalcInterval())
+	viewsFlushed := blogUsecase.StartViewFlusher(jobsCtx, viewFlushInterval)
+
+	// Roll raw views, reactions and comments up into per-author analytics before they expire
+	analyticsUsecase := usecase.NewAnalyticsUsecase(analyticsRepo, blogRepo, appLogger)
+	analyticsUsecase.Start(jobsCtx, appConfig.GetAnalyticsRollupInterval())
+
+	// Precompute related posts from tags, co-readership and text similarity
+	relatedPostsUsecase.Start(jobsCtx, appConfig.GetRelatedPostsInterval())
+
+	// Correct misspelled search terms against the words of published blogs
+	searchVocabulary := usecase.NewSearchVocabulary(blogRepo, appLogger)
+	searchVocabulary.Start(jobsCtx, appConfig.GetSearchVocabularyInterval())
+	blogUsecase.SetSearchVocabulary(searchVocabulary)
+
+	// Optional semantic search: embed blogs and blend vector similarity into search
+	var embeddingProvider usecasecontract.IEmbeddingProvider
+	switch appConfig.GetEmbeddingProvider() {
+	case "local":
+		embeddingProvider = embedding.NewLocalEmbeddingProvider(appConfig.GetEmbeddingDimensions())
+	case "http":
+		if appConfig.GetEmbeddingAPIURL() == "" || appConfig.GetEmbeddingModel() == "" {
+			log.Fatal("EMBEDDING_API_URL and EMBEDDING_MODEL are required for the http embedding provider")
+		}
+		embeddingProvider = embedding.NewHTTPEmbeddingProvider(appConfig.GetEmbeddingAPIURL(), appConfig.GetEmbeddingAPIKey(), appConfig.GetEmbeddingModel())
+	case "":
+	default:
+		log.Fatalf("unknown EMBEDDING_PROVIDER %q: use local or http", appConfig.GetEmbeddingProvider())
+	}
+	if embeddingProvider != nil {
+		semanticSearch := usecase.NewSemanticSearchUsecase(blogRepo, embeddingRepo, embeddingProvider, appLogger, usecase.SemanticSearchConfig{
+			MinSimilarity: appConfig.GetSearchMinSimilarity(),
+			KeywordWeight: appConfig.GetSearchKeywordWeight(),
+		})
+		semanticSearch.Start(jobsCtx, appConfig.GetEmbeddingIndexInterval())
+		blogUsecase.SetSemanticSearch(semanticSearch)
+	}
 
 	// Setup API routes
 	appRouter := handlerHttp.NewRouter(
 		userUsecase, blogUsecase, likeUsecase, emailUsecase,
 		userRepo, tokenRepo, hasher, jwtService, mailService,
 		appLogger, appConfig, appValidator, uuidGenerator, randomGenerator,
-		commentRepo, blogRepo,
+		commentUsecase, notificationUsecase, counterReconciler, trendingUsecase, analyticsUsecase, viewFraudUsecase, bookmarkUsecase, followUsecase, relatedPostsUsecase,
 	)
 	appRouter.SetupRoutes(router)
 
-	// Start the server
+	// Start the server and shut it down gracefully on SIGINT or SIGTERM
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
-	log.Printf("Server running on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: ":" + port, Handler: router}
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server running on port %s", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+	var serveErr error
+	select {
+	case <-signalCtx.Done():
+		log.Println("Shutting down server...")
+	case serveErr = <-serverErr:
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown did not complete: %v", err)
+	}
+	// Requests have drained; stop the background jobs and wait for the buffered views to be written
+	stopJobs()
+	<-viewsFlushed
+	if serveErr != nil {
+		log.Fatalf("Failed to start server: %v", serveErr)
 	}
 }
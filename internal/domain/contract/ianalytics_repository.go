@@ -0,0 +1,54 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// AnalyticsBucketFilter selects rollup buckets for an author, optionally narrowed to one blog.
+type AnalyticsBucketFilter struct {
+	AuthorID string
+	BlogID   string
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+}
+
+// ViewGroup counts the views of a blog that came from the same viewer with the same referrer,
+// user agent family and network.
+type ViewGroup struct {
+	BlogID          string
+	UserID          string
+	IPHash          string
+	Referrer        string
+	UserAgentFamily string
+	IPPrefix        string
+	Views           int64
+}
+
+// IAnalyticsRepository reads raw blog activity and stores hourly and daily rollups of it.
+type IAnalyticsRepository interface {
+	// GroupViews returns the views recorded in [from, to), grouped per blog, viewer,
+	// referrer, user agent family and network.
+	GroupViews(ctx context.Context, from, to time.Time) ([]ViewGroup, error)
+	// CountReactions returns the blog reactions made or changed in [from, to), per blog and type.
+	CountReactions(ctx context.Context, from, to time.Time) (map[string]map[string]int64, error)
+	// CountComments returns the approved comments posted in [from, to), per blog.
+	CountComments(ctx context.Context, from, to time.Time) (map[string]int64, error)
+
+	// AddDailyViewers remembers which viewers saw each blog on a day, so daily unique viewers
+	// can be counted after the raw views have expired. viewers maps blog IDs to viewer keys.
+	AddDailyViewers(ctx context.Context, day time.Time, viewers map[string][]string) error
+	// CountDailyViewers returns the number of distinct viewers of each blog on a day.
+	CountDailyViewers(ctx context.Context, day time.Time, blogIDs []string) (map[string]int64, error)
+
+	// SaveBuckets replaces the given rollup buckets.
+	SaveBuckets(ctx context.Context, granularity entity.AnalyticsGranularity, buckets []*entity.BlogAnalyticsBucket) error
+	// ListBuckets returns the buckets matching the filter, ordered by blog and start time.
+	ListBuckets(ctx context.Context, granularity entity.AnalyticsGranularity, filter AnalyticsBucketFilter) ([]*entity.BlogAnalyticsBucket, error)
+
+	// GetRollupWatermark returns the start of the last hour rolled up, or the zero time.
+	GetRollupWatermark(ctx context.Context) (time.Time, error)
+	SetRollupWatermark(ctx context.Context, hour time.Time) error
+}
@@ -7,6 +7,22 @@ import (
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 )
 
+// FeedBlogsQuery selects the published blogs of a feed page.
+type FeedBlogsQuery struct {
+	AuthorIDs       []string // blogs by any of these authors
+	Tags            []string // or with any of these tags
+	ExcludeAuthorID string   // never include this author's blogs
+	From, To        time.Time
+	After           *FeedPosition // continue after this blog; nil starts with the newest
+	Limit           int
+}
+
+// FeedPosition is where a feed page ended, in publication order.
+type FeedPosition struct {
+	PublishedAt time.Time
+	ID          string
+}
+
 // IBlogRepository provides methods for managing blog data in the database.
 type IBlogRepository interface {
 	CreateBlog(ctx context.Context, blog *entity.Blog) error
@@ -15,14 +31,22 @@ type IBlogRepository interface {
 	GetBlogs(ctx context.Context, filterOptions *BlogFilterOptions) ([]*entity.Blog, int64, error)
 	UpdateBlog(ctx context.Context, blogID string, updates map[string]interface{}) error
 	DeleteBlog(ctx context.Context, blogID string) error
-	SearchBlogs(ctx context.Context, query string, filterOptions *BlogFilterOptions) ([]*entity.Blog, int64, error)
+	// GetBlogFacets counts the blogs matching the filters per tag, author and publication
+	// month, and per status when includeStatus is set. Text search goes through IBlogSearchIndex.
+	GetBlogFacets(ctx context.Context, filterOptions *BlogFilterOptions, includeStatus bool) (*BlogFacets, error)
+	// FilterBlogIDs returns the IDs of every blog matching the filters, in the requested
+	// order and ignoring pagination.
+	FilterBlogIDs(ctx context.Context, filterOptions *BlogFilterOptions) ([]string, error)
+	// ListSearchableBlogs returns the title and content of every blog in one of the
+	// statuses, or of every blog when none are given.
+	ListSearchableBlogs(ctx context.Context, statuses ...entity.BlogStatus) ([]*entity.Blog, error)
 	IncrementViewCount(ctx context.Context, blogID string) error
 	IncrementLikeCount(ctx context.Context, blogID string) error
 	DecrementLikeCount(ctx context.Context, blogID string) error
 	IncrementDislikeCount(ctx context.Context, blogID string) error
 	// DecrementDislikeCount(ctx context.Context, blogID string) error
-	// IncrementCommentCount(ctx context.Context, blogID string) error
-	// DecrementCommentCount(ctx context.Context, blogID string) error
+	// IncrementCommentCount atomically adds delta to the blog's comment count
+	IncrementCommentCount(ctx context.Context, blogID string, delta int) error
 	GetBlogCounts(ctx context.Context, blogID string) (viewCount, likeCount, dislikeCount, commentCount int, err error)
 	AddTagsToBlog(ctx context.Context, blogID string, tagIDs []string) error
 	RemoveTagsFromBlog(ctx context.Context, blogID string, tagIDs []string) error
@@ -30,11 +54,20 @@ type IBlogRepository interface {
 	GetBlogsByTagID(ctx context.Context, tagID string, opts *BlogFilterOptions) ([]*entity.Blog, int64, error)
 	// GetBlogsByTagIDs retrieves blogs for multiple tag IDs with pagination
 	GetBlogsByTagIDs(ctx context.Context, tagIDs []string, page int, pageSize int) ([]*entity.Blog, int64, error)
-	HasViewedRecently(ctx context.Context, blogID, userID, ipAddress string) (bool, error)
-	RecordView(ctx context.Context, blogID, userID, ipAddress, userAgent string) error
+	// GetBlogsByIDs retrieves the non-deleted blogs among the given IDs, in no particular order
+	GetBlogsByIDs(ctx context.Context, blogIDs []string) ([]*entity.Blog, error)
+	// GetFeedBlogs retrieves up to query.Limit published blogs matching the feed query, newest
+	// first and by ID among posts published at the same time
+	GetFeedBlogs(ctx context.Context, query FeedBlogsQuery) ([]*entity.Blog, error)
+	// HasViewedRecently reports whether the user, or a viewer with any of the IP hashes, has a recorded view of the blog.
+	HasViewedRecently(ctx context.Context, blogID, userID string, ipHashes []string) (bool, error)
+	RecordView(ctx context.Context, view *entity.BlogView) error
+	// ApplyViewCounts adds a batch of views per blog to view_count and to the hourly view totals.
+	// A non-empty batchID is recorded with the write, and a batch already applied is skipped.
+	ApplyViewCounts(ctx context.Context, batchID string, counts map[string]int64, at time.Time) error
 	// IncrementLikeCount(ctx context.Context, blogID string) error
 	// DecrementLikeCount(ctx context.Context, blogID string) error
-	GetRecentViewsByIP(ctx context.Context, ipAddress string, since time.Time) ([]entity.BlogView, error)
+	GetRecentViewsByIPHash(ctx context.Context, ipHash string, since time.Time) ([]entity.BlogView, error)
 	GetRecentViewsByUser(ctx context.Context, userID string, since time.Time) ([]entity.BlogView, error)
 }
 
@@ -52,4 +85,27 @@ type BlogFilterOptions struct {
 	MaxLikes  *int
 	AuthorID  *string
 	TagIDs    []string
+	IDs       []string            // restrict to these blog IDs
+	Statuses  []entity.BlogStatus // restrict to these statuses
+}
+
+// ScoredBlog is a search match with its relevance score.
+type ScoredBlog struct {
+	Blog  *entity.Blog
+	Score float64
+}
+
+// FacetCount is how many search matches share one value of a facet.
+type FacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// BlogFacets breaks the blogs matching a search down by tag, author, publication month
+// (YYYY-MM) and status, most common first; months are newest first.
+type BlogFacets struct {
+	Tags     []FacetCount `json:"tags" bson:"tags"`
+	Authors  []FacetCount `json:"authors" bson:"authors"`
+	Months   []FacetCount `json:"months" bson:"months"`
+	Statuses []FacetCount `json:"statuses,omitempty" bson:"statuses,omitempty"`
 }
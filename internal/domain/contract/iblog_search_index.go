@@ -0,0 +1,33 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// IBlogSearchIndex is the keyword search backend behind blog search. Queries use text search
+// syntax: terms, any of which may match, "quoted phrases" that must all appear and -excluded
+// terms that must not. Matches are restricted by the same filter options as blog listings.
+type IBlogSearchIndex interface {
+	// Search returns a page of matches with their scores; SortBy "relevance" orders by score.
+	// The page also carries the total and the facets of every match, counted per tag, author
+	// and publication month, and per status when includeStatus is set.
+	Search(ctx context.Context, query string, filterOptions *BlogFilterOptions, includeStatus bool) (*BlogSearchPage, error)
+	// SearchRanked returns up to limit matches ordered by score, best first.
+	SearchRanked(ctx context.Context, query string, filterOptions *BlogFilterOptions, limit int) ([]ScoredBlog, error)
+	// Index adds a created blog or refreshes an edited one.
+	Index(ctx context.Context, blog *entity.Blog) error
+	// Remove drops a deleted blog.
+	Remove(ctx context.Context, blogID string) error
+	// Rebuild indexes every blog from scratch.
+	Rebuild(ctx context.Context) error
+}
+
+// BlogSearchPage is one page of keyword matches along with the total number of matches and
+// their facets.
+type BlogSearchPage struct {
+	Blogs  []ScoredBlog
+	Total  int64
+	Facets *BlogFacets
+}
@@ -0,0 +1,34 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// IBookmarkRepository stores bookmarks and the reading lists that group them.
+type IBookmarkRepository interface {
+	CreateReadingList(ctx context.Context, list *entity.ReadingList) error
+	GetReadingList(ctx context.Context, listID string) (*entity.ReadingList, error)
+	// ListReadingLists returns a user's reading lists by name, only the public ones if publicOnly.
+	ListReadingLists(ctx context.Context, userID string, publicOnly bool) ([]*entity.ReadingList, error)
+	UpdateReadingList(ctx context.Context, listID string, updates map[string]interface{}) error
+	// DeleteReadingList removes a reading list together with the bookmarks in it.
+	DeleteReadingList(ctx context.Context, listID string) error
+	// CountBookmarksByList returns the number of bookmarks in each of the given lists.
+	CountBookmarksByList(ctx context.Context, listIDs []string) (map[string]int64, error)
+
+	// AddBookmark saves a bookmark unless the user already saved the blog to the same list,
+	// and reports whether a new one was created. Otherwise the existing bookmark's ID and
+	// creation time are copied into bookmark.
+	AddBookmark(ctx context.Context, bookmark *entity.Bookmark) (bool, error)
+	// RemoveBookmark removes a blog from one list ("" is unsorted) or, when listID is nil,
+	// from everywhere the user saved it. It returns the number of bookmarks removed.
+	RemoveBookmark(ctx context.Context, userID, blogID string, listID *string) (int64, error)
+	// ListBookmarks returns a user's bookmarks, newest first, from one list or, when listID
+	// is nil, from all of them. Bookmarks of blogs that were deleted, or that are unpublished
+	// and not written by viewerID, are left out of both the page and the total.
+	ListBookmarks(ctx context.Context, userID string, listID *string, viewerID string, pagination Pagination) ([]*entity.Bookmark, int64, error)
+	// BookmarkedBlogIDs returns which of the given blogs the user has bookmarked anywhere.
+	BookmarkedBlogIDs(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error)
+}
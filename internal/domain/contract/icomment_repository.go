@@ -2,12 +2,33 @@ package contract
 
 import (
 	"context"
+	"time"
+
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 )
 
 type Pagination struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
+	// Sort selects the ordering of comment listings (see CommentSort*); empty uses the listing's default.
+	Sort string `json:"sort,omitempty"`
+	// After switches to keyset pagination: results start right after this position and Page is ignored.
+	After *CommentCursor `json:"-"`
+}
+
+// Comment listing orders
+const (
+	CommentSortNewest = "newest"
+	CommentSortOldest = "oldest"
+	CommentSortTop    = "top" // most liked first
+)
+
+// CommentCursor is the position of the last comment a reader has seen. Ties on CreatedAt
+// (and LikeCount when sorting by top) are broken by ID so no comment is skipped or repeated.
+type CommentCursor struct {
+	CreatedAt time.Time
+	LikeCount int
+	ID        string
 }
 
 type PaginationMeta struct {
@@ -21,30 +42,88 @@ type PaginationMeta struct {
 
 var MaxCommentDepth = 5
 
+// CommentSearchOptions holds the filters applied to a full-text comment search.
+type CommentSearchOptions struct {
+	Query    string
+	BlogID   string
+	AuthorID string
+	Statuses []string // empty means any status
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// ReportedCommentSummary aggregates the pending reports filed against a single comment.
+type ReportedCommentSummary struct {
+	CommentID      string
+	ReportCount    int64
+	Reasons        []string
+	LatestReportAt time.Time
+}
+
+// ReporterStats summarizes how a user's past reports were resolved by moderators.
+type ReporterStats struct {
+	Upheld    int64 // reports resolved as reviewed
+	Dismissed int64
+}
+
+// CommentSearchHit is a comment matched by a text search together with its relevance score.
+type CommentSearchHit struct {
+	Comment *entity.Comment
+	Score   float64
+}
+
 type ICommentRepository interface {
 	// Core CRUD operations
 	Create(ctx context.Context, comment *entity.Comment) error
 	GetByID(ctx context.Context, id string) (*entity.Comment, error)
+	// GetByIDs returns the non-deleted comments among ids, in no particular order.
+	GetByIDs(ctx context.Context, ids []string) ([]*entity.Comment, error)
 	Update(ctx context.Context, comment *entity.Comment) error
 	Delete(ctx context.Context, id string) error
 
+	// Edit history
+	// RecordEdit stores the snapshot and bumps the comment's edit count and edited_at.
+	RecordEdit(ctx context.Context, edit *entity.CommentEdit) error
+	GetCommentEdits(ctx context.Context, commentID string) ([]*entity.CommentEdit, error)
+
 	// Listing operations
+	// GetTopLevelComments lists approved top-level comments that are not pinned.
 	GetTopLevelComments(ctx context.Context, blogID string, pagination Pagination) ([]*entity.Comment, int64, error)
 	GetCommentThread(ctx context.Context, parentID string) (*entity.CommentThread, error)
 	GetCommentsByUser(ctx context.Context, userID string, pagination Pagination) ([]*entity.Comment, int64, error)
+	GetReplies(ctx context.Context, commentID string, pagination Pagination) ([]*entity.Comment, int64, error)
+	// GetPinnedComments returns every pinned comment on a blog, in the order they were pinned.
+	GetPinnedComments(ctx context.Context, blogID string) ([]*entity.Comment, error)
+	// SetPinned pins the comment at the given time, or unpins it when pinnedAt is nil.
+	SetPinned(ctx context.Context, id string, pinnedAt *time.Time) error
+
+	// Search
+	SearchComments(ctx context.Context, opts CommentSearchOptions, pagination Pagination) ([]*CommentSearchHit, int64, error)
 
 	// Status and moderation
+	// UpdateStatus and BulkUpdateStatus set the status and clear any auto-hide marker.
 	UpdateStatus(ctx context.Context, id, status string) error
+	BulkUpdateStatus(ctx context.Context, ids []string, status string) (int64, error)
+	BulkDelete(ctx context.Context, ids []string) (int64, error)
+	GetCommentsByStatus(ctx context.Context, statuses []string, pagination Pagination) ([]*entity.Comment, int64, error)
+	// SetAutoHidden moves a comment from fromStatus to status and records (or, with a nil time, clears)
+	// the auto-hide marker. Clearing requires the marker to be set. It fails without writing when the
+	// comment is no longer in that state, so concurrent callers cannot both apply the change.
+	SetAutoHidden(ctx context.Context, id, fromStatus, status string, hiddenAt *time.Time) error
 	GetCommentCount(ctx context.Context, blogID string) (int64, error)
 
-	// Like system
-	LikeComment(ctx context.Context, commentID, userID string) error
-	UnlikeComment(ctx context.Context, commentID, userID string) error
-	IsCommentLikedByUser(ctx context.Context, commentID, userID string) (bool, error)
-	GetCommentLikeCount(ctx context.Context, commentID string) (int64, error)
+	// Reactions are stored through ILikeRepository; the comment only keeps the totals.
+	// SetReactionCounts stores the per-type totals and mirrors likes/dislikes into like_count and dislike_count.
+	SetReactionCounts(ctx context.Context, id string, counts map[string]int64) error
+	// IncrementReplyCount atomically adds delta to a comment's reply_count.
+	IncrementReplyCount(ctx context.Context, id string, delta int) error
 
 	// Reporting system
 	ReportComment(ctx context.Context, report *entity.CommentReport) error
 	GetCommentReports(ctx context.Context, pagination Pagination) ([]*entity.CommentReport, int64, error)
-	UpdateReportStatus(ctx context.Context, reportID string, status string, reviewerID string) error
+	GetReportByID(ctx context.Context, reportID string) (*entity.CommentReport, error)
+	GetReportsByComment(ctx context.Context, commentID string, statuses []string) ([]*entity.CommentReport, error)
+	GetReporterStats(ctx context.Context, reporterID string) (*ReporterStats, error)
+	GetReportedComments(ctx context.Context, pagination Pagination) ([]*ReportedCommentSummary, int64, error)
+	UpdateReportStatus(ctx context.Context, reportID string, status string, reviewerID string, notes string) error
 }
@@ -0,0 +1,47 @@
+package contract
+
+import (
+	"context"
+)
+
+// BlogCounters holds the denormalized counters kept on a blog.
+type BlogCounters struct {
+	BlogID         string
+	ViewCount      int
+	LikeCount      int
+	DislikeCount   int
+	CommentCount   int
+	ReactionCounts map[string]int64
+	Popularity     float64
+}
+
+// CommentCounters holds the denormalized counters kept on a comment.
+type CommentCounters struct {
+	CommentID      string
+	LikeCount      int
+	DislikeCount   int
+	ReplyCount     int
+	ReactionCounts map[string]int64
+}
+
+// ICounterRepository reads stored counters and recomputes them from the source records
+// (reactions and comments) so that drift can be detected and repaired.
+type ICounterRepository interface {
+	// ListBlogCounters returns the stored counters of up to limit blogs with an ID greater than afterID, in ID order.
+	ListBlogCounters(ctx context.Context, afterID string, limit int) ([]BlogCounters, error)
+	// ComputeBlogCounters recounts likes, dislikes, reactions and approved comments for the given blogs.
+	// Blogs without any records are missing from the result. ViewCount and Popularity are left zero.
+	ComputeBlogCounters(ctx context.Context, blogIDs []string) (map[string]BlogCounters, error)
+	// SetBlogCounters stores counters on a blog only while its counters still equal stored, so
+	// that an update made since they were listed is not overwritten. It reports whether it did.
+	SetBlogCounters(ctx context.Context, stored, counters BlogCounters) (bool, error)
+
+	// ListCommentCounters returns the stored counters of up to limit comments with an ID greater than afterID, in ID order.
+	ListCommentCounters(ctx context.Context, afterID string, limit int) ([]CommentCounters, error)
+	// ComputeCommentCounters recounts reactions and approved direct replies for the given comments.
+	// Comments without any records are missing from the result.
+	ComputeCommentCounters(ctx context.Context, commentIDs []string) (map[string]CommentCounters, error)
+	// SetCommentCounters stores counters on a comment only while its counters still equal
+	// stored. It reports whether it did.
+	SetCommentCounters(ctx context.Context, stored, counters CommentCounters) (bool, error)
+}
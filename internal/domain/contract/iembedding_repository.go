@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// IEmbeddingRepository stores the vectors used for semantic blog search.
+type IEmbeddingRepository interface {
+	// ListEmbeddings returns every stored embedding made with the model.
+	ListEmbeddings(ctx context.Context, model string) ([]*entity.BlogEmbedding, error)
+	// UpsertEmbeddings stores the embeddings, replacing any earlier one per blog.
+	UpsertEmbeddings(ctx context.Context, embeddings []*entity.BlogEmbedding) error
+	DeleteEmbeddings(ctx context.Context, blogIDs []string) error
+}
@@ -0,0 +1,24 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// IFollowRepository stores who follows which authors and tags.
+type IFollowRepository interface {
+	// Follow records the follow, reporting false when it already existed.
+	Follow(ctx context.Context, follow *entity.Follow) (bool, error)
+	// Unfollow removes the follow, reporting false when there was none.
+	Unfollow(ctx context.Context, followerID string, targetType entity.FollowTargetType, targetID string) (bool, error)
+	IsFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType, targetID string) (bool, error)
+	// ListFollowing returns what the user follows of one type, newest first.
+	ListFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType, pagination Pagination) ([]*entity.Follow, int64, error)
+	// ListFollowers returns who follows the target, newest first.
+	ListFollowers(ctx context.Context, targetType entity.FollowTargetType, targetID string, pagination Pagination) ([]*entity.Follow, int64, error)
+	CountFollowers(ctx context.Context, targetType entity.FollowTargetType, targetID string) (int64, error)
+	CountFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType) (int64, error)
+	// FollowedTargets returns the IDs of every target of one type the user follows.
+	FollowedTargets(ctx context.Context, followerID string, targetType entity.FollowTargetType) ([]string, error)
+}
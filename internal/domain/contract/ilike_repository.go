@@ -2,10 +2,22 @@ package contract
 
 import (
 	"context"
+	"time"
 
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 )
 
+// Reactor is a user who reacted to a target, with the profile fields needed to show them.
+type Reactor struct {
+	UserID    string
+	Username  string
+	FirstName *string
+	LastName  *string
+	AvatarURL *string
+	Type      entity.LikeType
+	ReactedAt time.Time
+}
+
 // ILikeRepository defines the interface for reaction data persistence.
 type ILikeRepository interface {
 	CreateReaction(ctx context.Context, like *entity.Like) error
@@ -14,4 +26,12 @@ type ILikeRepository interface {
 	GetReactionByUserIDTargetIDAndType(ctx context.Context, userID, targetID string, reactionType entity.LikeType) (*entity.Like, error) // Changed from uuid.UUID to string
 	CountLikesByTargetID(ctx context.Context, targetID string) (int64, error)                                                            // Changed from uuid.UUID to string
 	CountDislikesByTargetID(ctx context.Context, targetID string) (int64, error)                                                         // Changed from uuid.UUID to string
+	CountReactionsByTargetID(ctx context.Context, targetID string) (map[entity.LikeType]int64, error)                                    // Active reactions per type
+	// ListReactors pages through the users who reacted to a target, most recent first, leaving out
+	// users who hide their reactions. An empty reactionType lists every type.
+	ListReactors(ctx context.Context, targetID string, reactionType entity.LikeType, pagination Pagination) ([]*Reactor, int64, error)
+	// ListReactionsByUser pages through a user's active reactions, most recent first.
+	ListReactionsByUser(ctx context.Context, userID string, pagination Pagination) ([]*entity.Like, int64, error)
+	// GetReactionsByUserIDAndTargetIDs returns a user's active reactions on any of the targets.
+	GetReactionsByUserIDAndTargetIDs(ctx context.Context, userID string, targetIDs []string) ([]*entity.Like, error)
 }
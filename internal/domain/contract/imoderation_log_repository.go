@@ -0,0 +1,20 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// ModerationLogFilter narrows down audit trail queries. Empty fields are ignored.
+type ModerationLogFilter struct {
+	ModeratorID string
+	TargetID    string
+	Action      entity.ModerationActionType
+}
+
+// IModerationLogRepository persists the audit trail of moderator actions.
+type IModerationLogRepository interface {
+	Record(ctx context.Context, actions ...*entity.ModerationAction) error
+	List(ctx context.Context, filter ModerationLogFilter, pagination Pagination) ([]*entity.ModerationAction, int64, error)
+}
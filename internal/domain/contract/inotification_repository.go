@@ -0,0 +1,17 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// INotificationRepository persists in-app notifications for users.
+type INotificationRepository interface {
+	Create(ctx context.Context, notifications ...*entity.Notification) error
+	// ListByRecipient returns a user's notifications, newest first, and the total matching count.
+	ListByRecipient(ctx context.Context, userID string, unreadOnly bool, pagination Pagination) ([]*entity.Notification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkRead marks the given notifications as read, or all of them when ids is empty.
+	MarkRead(ctx context.Context, userID string, ids []string) (int64, error)
+}
@@ -0,0 +1,30 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// IRelatedPostsRepository provides the inputs of the related posts job and stores its results.
+type IRelatedPostsRepository interface {
+	// ListCorpus returns up to limit of the most recently published blogs with the fields
+	// needed for matching.
+	ListCorpus(ctx context.Context, limit int) ([]*entity.Blog, error)
+	// EachReaderPosts calls fn, for every reader with between two and maxPosts posts viewed in
+	// [from, to), with the time the reader first viewed each post in that window. A reader is
+	// a user or, for anonymous views, a daily IP hash.
+	EachReaderPosts(ctx context.Context, from, to time.Time, maxPosts int, fn func(posts map[string]time.Time) error) error
+	// GetCoReadWatermark returns the time up to which views were counted into co-reads, or the zero time.
+	GetCoReadWatermark(ctx context.Context) (time.Time, error)
+	SetCoReadWatermark(ctx context.Context, at time.Time) error
+	// AddCoReads adds the counts to the stored co-readership of each pair.
+	AddCoReads(ctx context.Context, pairs []entity.CoReadPair) error
+	// ListCoReads returns every stored pair read together by at least minCount readers.
+	ListCoReads(ctx context.Context, minCount int64) ([]entity.CoReadPair, error)
+	// SaveRelatedPosts replaces the related posts of each blog given.
+	SaveRelatedPosts(ctx context.Context, related []*entity.RelatedPosts) error
+	// GetRelatedPosts returns a blog's related posts, or nil when none were computed.
+	GetRelatedPosts(ctx context.Context, blogID string) (*entity.RelatedPosts, error)
+}
@@ -0,0 +1,29 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// TrendingSignals is the activity a published blog received inside a trending window.
+type TrendingSignals struct {
+	BlogID      string
+	PublishedAt time.Time
+	Views       int64
+	Likes       int64
+	Dislikes    int64
+	Reactions   int64 // reactions other than likes and dislikes
+	Comments    int64
+}
+
+// ITrendingRepository gathers recent blog activity and stores the resulting trending scores.
+type ITrendingRepository interface {
+	// CollectSignals returns the activity since the given time for every published blog that had any.
+	CollectSignals(ctx context.Context, since time.Time) ([]TrendingSignals, error)
+	// SetTrendingScores replaces the scores of one window: listed blogs get their score, all others lose theirs.
+	SetTrendingScores(ctx context.Context, window entity.TrendingWindow, scores map[string]float64) error
+	// GetTrendingBlogs returns published blogs with a positive score in the window, highest first.
+	GetTrendingBlogs(ctx context.Context, window entity.TrendingWindow, pagination Pagination) ([]*entity.Blog, int64, error)
+}
@@ -0,0 +1,11 @@
+package contract
+
+import "context"
+
+// IUnitOfWork groups several repository writes so they commit or fail together.
+type IUnitOfWork interface {
+	// Do runs fn as one unit of work. Repository calls must use the context passed to fn to
+	// take part in it. If fn returns an error, its writes are rolled back where the backing
+	// store supports it. fn may be retried on transient failures, so it must be safe to re-run.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -9,8 +9,12 @@ import (
 type IUserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
+	// GetUsersByIDs retrieves the users among the given IDs, in no particular order.
+	GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User, error)
 	// GetUserByUsername retrieves a user by username.
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+	// SearchUsersByUsernamePrefix returns up to limit users whose username starts with prefix, case-insensitively.
+	SearchUsersByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]*entity.User, error)
 	// GetUserByEmail retrieves a user by email.
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 	// UpdateUser updates an existing user and returns the updated user.
@@ -0,0 +1,25 @@
+package contract
+
+import "context"
+
+// ViewBatch is a claimed batch of buffered views.
+type ViewBatch struct {
+	// ID stays the same each time the batch is claimed again, so a write that already
+	// landed can be recognised and skipped.
+	ID string
+	// Token identifies this claim. Release only discards the batch while the claim holds.
+	Token  string
+	Counts map[string]int64
+}
+
+// IViewCountBuffer accumulates blog view increments so they can be written in batches.
+type IViewCountBuffer interface {
+	// Add buffers one view of a blog.
+	Add(ctx context.Context, blogID string) error
+	// Claim takes the buffered views for flushing. Views added afterwards go into the next
+	// batch. Until Release is called, Claim returns the same batch again so that a failed
+	// flush is retried rather than lost. It returns nil when there is nothing to flush.
+	Claim(ctx context.Context) (*ViewBatch, error)
+	// Release discards the claimed batch once it has been written.
+	Release(ctx context.Context, batch *ViewBatch) error
+}
@@ -0,0 +1,36 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// FlaggedViewFilter narrows a flagged view listing; empty fields match everything.
+type FlaggedViewFilter struct {
+	Action entity.ViewFraudAction
+	Rule   string
+	BlogID string
+	Since  time.Time
+}
+
+// ViewOffender is an IP hash, user or header fingerprint with the number of flagged views it caused.
+type ViewOffender struct {
+	Key      string    `json:"key" bson:"_id"`
+	Count    int64     `json:"count" bson:"count"`
+	Blocked  int64     `json:"blocked" bson:"blocked"`
+	Dropped  int64     `json:"dropped" bson:"dropped"`
+	Rules    []string  `json:"rules" bson:"rules"`
+	LastSeen time.Time `json:"last_seen" bson:"last_seen"`
+}
+
+// IViewFraudRepository stores views that matched fraud rules for admin review.
+type IViewFraudRepository interface {
+	RecordFlaggedView(ctx context.Context, view *entity.FlaggedView) error
+	// ListFlaggedViews returns matching flagged views, newest first, and their total.
+	ListFlaggedViews(ctx context.Context, filter FlaggedViewFilter, pagination Pagination) ([]*entity.FlaggedView, int64, error)
+	// TopOffenders groups flagged views since a time by field ("ip_hash", "user_id" or
+	// "fingerprint") and returns the keys with the most views.
+	TopOffenders(ctx context.Context, field string, since time.Time, limit int) ([]ViewOffender, error)
+}
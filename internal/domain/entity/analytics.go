@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// AnalyticsGranularity is the bucket size of a blog analytics rollup
+type AnalyticsGranularity string
+
+const (
+	AnalyticsGranularityHour AnalyticsGranularity = "hour"
+	AnalyticsGranularityDay  AnalyticsGranularity = "day"
+)
+
+// CountEntry is one row of a breakdown, such as views from a single referrer
+type CountEntry struct {
+	Key   string `json:"key" bson:"key"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// BlogAnalyticsBucket holds a blog's activity over one hour or one day. Raw views expire
+// after a day, so these rollups are the long-term record.
+type BlogAnalyticsBucket struct {
+	ID            string           `json:"id" bson:"_id"` // blog ID and bucket start
+	BlogID        string           `json:"blog_id" bson:"blog_id"`
+	AuthorID      string           `json:"author_id" bson:"author_id"`
+	Start         time.Time        `json:"start" bson:"start"`
+	Views         int64            `json:"views" bson:"views"`
+	UniqueViewers int64            `json:"unique_viewers" bson:"unique_viewers"`
+	Reactions     map[string]int64 `json:"reactions,omitempty" bson:"reactions,omitempty"` // new or changed reactions per type
+	Comments      int64            `json:"comments" bson:"comments"`
+	Referrers     []CountEntry     `json:"referrers,omitempty" bson:"referrers,omitempty"`     // views per referring host
+	UserAgents    []CountEntry     `json:"user_agents,omitempty" bson:"user_agents,omitempty"` // views per browser family
+	Networks      []CountEntry     `json:"networks,omitempty" bson:"networks,omitempty"`       // views per truncated IP network
+}
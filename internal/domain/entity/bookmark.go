@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// ReadingListVisibility controls who can see a reading list
+type ReadingListVisibility string
+
+const (
+	ReadingListPrivate ReadingListVisibility = "private"
+	ReadingListPublic  ReadingListVisibility = "public"
+)
+
+// ReadingList is a named collection of a user's bookmarks
+type ReadingList struct {
+	ID          string                `json:"id" bson:"_id"`
+	UserID      string                `json:"user_id" bson:"user_id"`
+	Name        string                `json:"name" bson:"name"`
+	Description string                `json:"description,omitempty" bson:"description,omitempty"`
+	Visibility  ReadingListVisibility `json:"visibility" bson:"visibility"`
+	CreatedAt   time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+// Bookmark saves a blog for a user, either unsorted (empty ListID) or in one of their
+// reading lists. The same blog may be saved to several lists.
+type Bookmark struct {
+	ID        string    `json:"id" bson:"_id"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	BlogID    string    `json:"blog_id" bson:"blog_id"`
+	ListID    string    `json:"list_id" bson:"list_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
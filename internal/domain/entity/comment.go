@@ -6,23 +6,49 @@ import (
 
 // Comment represents a comment on a blog post with advanced reply-to-reply support
 type Comment struct {
-	ID             string    `json:"id" bson:"_id,omitempty"`
-	BlogID         string    `json:"blog_id" bson:"blog_id"`
-	Type           string    `json:"type" bson:"type"` // "comment" or "reply"
-	ParentID       *string   `json:"parent_id" bson:"parent_id"`
-	TargetID       *string   `json:"target_id" bson:"target_id"`
-	AuthorID       string    `json:"author_id" bson:"author_id"`
-	AuthorName     string    `json:"author_name" bson:"author_name"`
-	TargetUserName string    `json:"target_user_name" bson:"target_user_name"`
-	Content        string    `json:"content" bson:"content"`
-	Status         string    `json:"status" bson:"status"`
-	LikeCount      int       `json:"like_count" bson:"like_count"`
-	ReplyCount     int       `json:"reply_count" bson:"reply_count"`
-	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at" bson:"updated_at"`
-	IsDeleted      bool      `json:"is_deleted" bson:"is_deleted"`
+	ID             string           `json:"id" bson:"_id,omitempty"`
+	BlogID         string           `json:"blog_id" bson:"blog_id"`
+	Type           string           `json:"type" bson:"type"` // "comment" or "reply"
+	ParentID       *string          `json:"parent_id" bson:"parent_id"`
+	RootID         string           `json:"root_id" bson:"root_id"` // top-level comment of the thread (own ID for top-level comments)
+	Path           []string         `json:"path" bson:"path"`       // ancestor IDs from the root down to the direct parent
+	Depth          int              `json:"depth" bson:"depth"`     // len(Path); 0 for top-level comments
+	TargetID       *string          `json:"target_id" bson:"target_id"`
+	AuthorID       string           `json:"author_id" bson:"author_id"`
+	AuthorName     string           `json:"author_name" bson:"author_name"`
+	TargetUserName string           `json:"target_user_name" bson:"target_user_name"`
+	Content        string           `json:"content" bson:"content"`
+	Status         string           `json:"status" bson:"status"`
+	LikeCount      int              `json:"like_count" bson:"like_count"`
+	DislikeCount   int              `json:"dislike_count" bson:"dislike_count"`
+	ReactionCounts map[string]int64 `json:"reaction_counts,omitempty" bson:"reaction_counts,omitempty"` // active reactions per type
+	ReplyCount     int              `json:"reply_count" bson:"reply_count"`
+	CreatedAt      time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" bson:"updated_at"`
+	IsDeleted      bool             `json:"is_deleted" bson:"is_deleted"`
+	AutoHiddenAt   *time.Time       `json:"auto_hidden_at,omitempty" bson:"auto_hidden_at,omitempty"` // set when hidden after crossing the report threshold
+	EditCount      int              `json:"edit_count" bson:"edit_count"`
+	EditedAt       *time.Time       `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+	Mentions       []Mention        `json:"mentions,omitempty" bson:"mentions,omitempty"`   // users mentioned with @username in the content
+	PinnedAt       *time.Time       `json:"pinned_at,omitempty" bson:"pinned_at,omitempty"` // set while the blog author has the comment pinned
 }
 
+// Comment statuses
+const (
+	CommentStatusApproved = "approved"
+	CommentStatusPending  = "pending"
+	CommentStatusHidden   = "hidden"
+	CommentStatusFlagged  = "flagged"
+	CommentStatusRejected = "rejected"
+)
+
+// Comment report statuses
+const (
+	ReportStatusPending   = "pending"
+	ReportStatusReviewed  = "reviewed"
+	ReportStatusDismissed = "dismissed"
+)
+
 // CommentThread represents a comment with its nested replies
 type CommentThread struct {
 	Comment *Comment         `json:"comment"`
@@ -30,12 +56,13 @@ type CommentThread struct {
 	Depth   int              `json:"depth"`
 }
 
-// CommentLike represents a user's like on a comment
-type CommentLike struct {
-	ID        string    `json:"id" bson:"_id,omitempty"`
-	CommentID string    `json:"comment_id" bson:"comment_id"`
-	UserID    string    `json:"user_id" bson:"user_id"`
-	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+// CommentEdit is a snapshot of a comment's content taken just before it was edited
+type CommentEdit struct {
+	ID              string    `json:"id" bson:"_id,omitempty"`
+	CommentID       string    `json:"comment_id" bson:"comment_id"`
+	EditorID        string    `json:"editor_id" bson:"editor_id"`
+	PreviousContent string    `json:"previous_content" bson:"previous_content"`
+	EditedAt        time.Time `json:"edited_at" bson:"edited_at"`
 }
 
 // CommentReport represents a report against a comment
@@ -49,4 +76,5 @@ type CommentReport struct {
 	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
 	ReviewedAt *time.Time `json:"reviewed_at" bson:"reviewed_at"`
 	ReviewedBy *string    `json:"reviewed_by" bson:"reviewed_by"`
+	Notes      string     `json:"notes" bson:"notes"` // reviewer notes recorded on resolution
 }
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// BlogEmbedding is the vector of a blog's text under one embedding model
+type BlogEmbedding struct {
+	BlogID      string    `json:"blog_id" bson:"_id"`
+	Model       string    `json:"model" bson:"model"`
+	Vector      []float32 `json:"vector" bson:"vector"`
+	ContentHash string    `json:"content_hash" bson:"content_hash"` // hash of the embedded text, to detect edits
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// FollowTargetType is the kind of thing a user follows
+type FollowTargetType string
+
+const (
+	FollowTargetAuthor FollowTargetType = "author"
+	FollowTargetTag    FollowTargetType = "tag"
+)
+
+// Follow records that a user follows an author (TargetID is the author's user ID) or a
+// tag (TargetID is the tag as it appears on blogs)
+type Follow struct {
+	ID         string           `json:"id" bson:"_id"`
+	FollowerID string           `json:"follower_id" bson:"follower_id"`
+	TargetType FollowTargetType `json:"target_type" bson:"target_type"`
+	TargetID   string           `json:"target_id" bson:"target_id"`
+	CreatedAt  time.Time        `json:"created_at" bson:"created_at"`
+}
@@ -4,14 +4,23 @@ import (
 	"time"
 )
 
-// LikeType represents the type of reaction (like or dislike)
+// LikeType represents the type of reaction (like, dislike or an emoji reaction)
 type LikeType string
 
 const (
-	LIKE_TYPE_LIKE    LikeType = "like"
-	LIKE_TYPE_DISLIKE LikeType = "dislike"
+	LIKE_TYPE_LIKE       LikeType = "like"
+	LIKE_TYPE_DISLIKE    LikeType = "dislike"
+	LIKE_TYPE_CLAP       LikeType = "clap"
+	LIKE_TYPE_HEART      LikeType = "heart"
+	LIKE_TYPE_INSIGHTFUL LikeType = "insightful"
+	LIKE_TYPE_LAUGH      LikeType = "laugh"
 )
 
+// DefaultReactionTypes is the reaction set offered when none is configured for a target type
+var DefaultReactionTypes = []LikeType{
+	LIKE_TYPE_LIKE, LIKE_TYPE_DISLIKE, LIKE_TYPE_CLAP, LIKE_TYPE_HEART, LIKE_TYPE_INSIGHTFUL, LIKE_TYPE_LAUGH,
+}
+
 // Like represents a like on a blog post or comment
 type Like struct {
 	ID         string     `json:"id" bson:"_id"`
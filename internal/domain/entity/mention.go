@@ -0,0 +1,7 @@
+package entity
+
+// Mention is a reference to a user mentioned with @username in a comment or blog post
+type Mention struct {
+	UserID   string `json:"user_id" bson:"user_id"`
+	Username string `json:"username" bson:"username"`
+}
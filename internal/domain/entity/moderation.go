@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+)
+
+// ModerationAction records a single moderator decision for the audit trail
+type ModerationAction struct {
+	ID          string               `json:"id" bson:"_id"`
+	ModeratorID string               `json:"moderator_id" bson:"moderator_id"`
+	Action      ModerationActionType `json:"action" bson:"action"`
+	TargetType  string               `json:"target_type" bson:"target_type"` // "comment" or "report"
+	TargetID    string               `json:"target_id" bson:"target_id"`
+	Notes       string               `json:"notes" bson:"notes"`
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at"`
+}
+
+// ModerationActionType represents the kind of decision a moderator made
+type ModerationActionType string
+
+const (
+	ModerationActionApprove       ModerationActionType = "approve"
+	ModerationActionReject        ModerationActionType = "reject"
+	ModerationActionDelete        ModerationActionType = "delete"
+	ModerationActionStatusChange  ModerationActionType = "status_change"
+	ModerationActionResolveReport ModerationActionType = "resolve_report"
+	ModerationActionAutoModerate  ModerationActionType = "auto_moderate"
+	ModerationActionAutoHide      ModerationActionType = "auto_hide"
+	ModerationActionRestore       ModerationActionType = "restore"
+)
+
+// SystemModeratorID is recorded as the moderator for decisions made by auto-moderation
+const SystemModeratorID = "system"
@@ -26,4 +26,6 @@ const (
 	NotificationTypeEmailVerification NotificationType = "EMAIL_VERIFICATION"
 	NotificationTypeCommentLiked      NotificationType = "COMMENT_LIKED"
 	NotificationTypePackageExpired    NotificationType = "PACKAGE_EXPIRED"
+	NotificationTypeCommentMention    NotificationType = "COMMENT_MENTION"
+	NotificationTypeBlogMention       NotificationType = "BLOG_MENTION"
 )
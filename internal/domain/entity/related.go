@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// RelatedPost is a blog recommended alongside another, with the signals that matched
+type RelatedPost struct {
+	BlogID         string   `json:"blog_id" bson:"blog_id"`
+	Score          float64  `json:"score" bson:"score"`
+	SharedTags     []string `json:"shared_tags,omitempty" bson:"shared_tags,omitempty"`
+	CoReaders      int64    `json:"co_readers,omitempty" bson:"co_readers,omitempty"`
+	TextSimilarity float64  `json:"text_similarity,omitempty" bson:"text_similarity,omitempty"`
+}
+
+// RelatedPosts is the precomputed list of posts related to one blog, best first
+type RelatedPosts struct {
+	BlogID     string        `json:"blog_id" bson:"_id"`
+	Related    []RelatedPost `json:"related" bson:"related"`
+	ComputedAt time.Time     `json:"computed_at" bson:"computed_at"`
+}
+
+// CoReadPair counts the readers who viewed both blogs; BlogA always sorts before BlogB
+type CoReadPair struct {
+	BlogA string `json:"blog_a" bson:"blog_a"`
+	BlogB string `json:"blog_b" bson:"blog_b"`
+	Count int64  `json:"count" bson:"count"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// TrendingWindow is the period of recent activity a trending score is computed over
+type TrendingWindow string
+
+const (
+	TrendingWindowDay   TrendingWindow = "24h"
+	TrendingWindowWeek  TrendingWindow = "7d"
+	TrendingWindowMonth TrendingWindow = "30d"
+)
+
+// TrendingWindows lists every window a trending score is kept for
+var TrendingWindows = []TrendingWindow{TrendingWindowDay, TrendingWindowWeek, TrendingWindowMonth}
+
+// Duration returns the length of the window, or zero for an unknown window.
+func (w TrendingWindow) Duration() time.Duration {
+	switch w {
+	case TrendingWindowDay:
+		return 24 * time.Hour
+	case TrendingWindowWeek:
+		return 7 * 24 * time.Hour
+	case TrendingWindowMonth:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
@@ -19,6 +19,7 @@ type User struct {
 	FirstName         *string   `bson:"firstname,omitempty" json:"firstname,omitempty"`
 	LastName          *string   `bson:"lastname,omitempty" json:"lastname,omitempty"`
 	AvatarURL         *string   `bson:"avatar_url,omitempty" json:"avatar_url,omitempty"`
+	HideReactions     bool      `bson:"hide_reactions" json:"hide_reactions"` // keep this user out of public "who reacted" listings
 }
 
 // UserRole represents the role of a user in the system
@@ -3,10 +3,15 @@ package entity
 import "time"
 
 // BlogView represents a record of a user viewing a blog, used for tracking and analysis.
+// The viewer's IP address is never stored: IPHash is a keyed hash that rotates daily, and
+// IPPrefix keeps only the network. Views from readers who opted out of tracking carry
+// nothing but the hash.
 type BlogView struct {
-	BlogID    string    `bson:"blog_id"`
-	UserID    string    `bson:"user_id,omitempty"`
-	IPAddress string    `bson:"ip_address"`
-	UserAgent string    `bson:"user_agent"`
-	ViewedAt  time.Time `bson:"viewed_at"`
+	BlogID          string    `bson:"blog_id"`
+	UserID          string    `bson:"user_id,omitempty"`
+	IPHash          string    `bson:"ip_hash"`
+	IPPrefix        string    `bson:"ip_prefix,omitempty"`
+	UserAgentFamily string    `bson:"user_agent_family,omitempty"`
+	Referrer        string    `bson:"referrer,omitempty"`
+	ViewedAt        time.Time `bson:"viewed_at"`
 }
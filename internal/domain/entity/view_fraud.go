@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// ViewFraudAction is what happens to a view that matches a fraud rule.
+type ViewFraudAction string
+
+const (
+	// ViewFraudActionFlag counts the view but records it for review
+	ViewFraudActionFlag ViewFraudAction = "flag"
+	// ViewFraudActionDrop silently leaves the view uncounted
+	ViewFraudActionDrop ViewFraudAction = "drop"
+	// ViewFraudActionBlock rejects the view with an error
+	ViewFraudActionBlock ViewFraudAction = "block"
+	// ViewFraudActionOff disables a rule
+	ViewFraudActionOff ViewFraudAction = "off"
+)
+
+// Severity ranks actions so the strictest matching rule decides a view's fate.
+func (a ViewFraudAction) Severity() int {
+	switch a {
+	case ViewFraudActionBlock:
+		return 3
+	case ViewFraudActionDrop:
+		return 2
+	case ViewFraudActionFlag:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Names of the built-in view fraud rules
+const (
+	ViewFraudRuleBotUserAgent      = "bot_user_agent"     // user agent contains a bot signature (Values)
+	ViewFraudRuleMissingUserAgent  = "missing_user_agent" // no user agent at all
+	ViewFraudRuleUnknownUserAgent  = "unknown_user_agent" // user agent of no known browser family
+	ViewFraudRuleHeaderFingerprint = "header_fingerprint" // a header browsers always send (Values) is missing
+	ViewFraudRuleDatacenterIP      = "datacenter_ip"      // IP inside a listed datacenter range (Values, CIDR)
+	ViewFraudRuleIPVelocity        = "ip_velocity"        // more than Limit blogs viewed from one IP within Window
+	ViewFraudRuleUserIPRotation    = "user_ip_rotation"   // more than Limit IPs used by one user within Window
+)
+
+// ViewFraudRule configures one fraud check. Limit and Window apply to the velocity rules,
+// Values to the list-based ones.
+type ViewFraudRule struct {
+	Name   string          `json:"name"`
+	Action ViewFraudAction `json:"action"`
+	Limit  int             `json:"limit,omitempty"`
+	Window time.Duration   `json:"window,omitempty"`
+	Values []string        `json:"values,omitempty"`
+}
+
+// FlaggedView records a view that matched at least one fraud rule, whatever the outcome.
+type FlaggedView struct {
+	ID       string `json:"id" bson:"_id"`
+	BlogID   string `json:"blog_id" bson:"blog_id"`
+	UserID   string `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	IPHash   string `json:"ip_hash" bson:"ip_hash"`
+	IPPrefix string `json:"ip_prefix,omitempty" bson:"ip_prefix,omitempty"`
+	// UserAgentFamily is the browser family; the raw User-Agent is never stored.
+	UserAgentFamily string          `json:"user_agent_family,omitempty" bson:"user_agent_family,omitempty"`
+	Fingerprint     string          `json:"fingerprint" bson:"fingerprint"`
+	Action          ViewFraudAction `json:"action" bson:"action"`
+	Rules           []string        `json:"rules" bson:"rules"`
+	Reasons         []string        `json:"reasons" bson:"reasons"`
+	CreatedAt       time.Time       `json:"created_at" bson:"created_at"`
+}
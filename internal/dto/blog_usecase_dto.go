@@ -15,10 +15,73 @@ type CreateCommentRequest struct {
 
 type UpdateCommentRequest struct {
 	Content string `json:"content" validate:"required,min=1,max=1000"`
+	// EditWindow is how long after posting the author may still edit; zero means no limit.
+	// It is set by the handler from configuration, never from the request body.
+	EditWindow time.Duration `json:"-"`
+}
+
+// ListCommentsRequest selects a page of comments either by page number or, when Cursor is
+// set, by continuing after the next_cursor of a previous response.
+type ListCommentsRequest struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Sort     string // newest, oldest or top
 }
 
 type UpdateCommentStatusRequest struct {
-	Status string `json:"status" validate:"required,oneof=approved pending hidden flagged"`
+	Status string `json:"status" validate:"required,oneof=approved pending hidden flagged rejected"`
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type BulkModerationRequest struct {
+	Action     string   `json:"action" validate:"required,oneof=approve reject delete"`
+	CommentIDs []string `json:"comment_ids" validate:"required,min=1,max=100"`
+	Reason     string   `json:"reason" validate:"max=500"`
+}
+
+type ResolveReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=reviewed dismissed"`
+	Notes  string `json:"notes" validate:"max=1000"`
+}
+
+type UpdateCommentBlocklistRequest struct {
+	Keywords []string `json:"keywords" validate:"max=100,dive,max=50"`
+}
+
+type UpdateCommentLockRequest struct {
+	Locked *bool `json:"locked" binding:"required"`
+}
+
+type SearchCommentsRequest struct {
+	Query    string
+	BlogID   string
+	AuthorID string
+	Status   string
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Page     int
+	PageSize int
+}
+
+// SearchBlogsRequest holds a blog search. Query supports "quoted phrases" and -excluded
+// terms; Status only applies when an author searches their own posts.
+type SearchBlogsRequest struct {
+	Query     string
+	Mode      string // keyword, semantic or hybrid
+	SortBy    string // relevance, created_at, view_count, like_count or popularity
+	SortOrder string
+	Tags      []string
+	DateFrom  *time.Time
+	DateTo    *time.Time
+	MinViews  *int
+	MaxViews  *int
+	MinLikes  *int
+	MaxLikes  *int
+	AuthorID  *string
+	Status    string
+	Page      int
+	PageSize  int
 }
 
 type ReportCommentRequest struct {
@@ -28,21 +91,50 @@ type ReportCommentRequest struct {
 
 // Response DTOs
 type CommentResponse struct {
-	ID             string    `json:"id"`
-	BlogID         string    `json:"blog_id"`
-	Type           string    `json:"type"`
-	ParentID       *string   `json:"parent_id"`
-	TargetID       *string   `json:"target_id"`
-	AuthorID       string    `json:"author_id"`
-	AuthorName     string    `json:"author_name"`
-	TargetUserName string    `json:"target_user_name"`
-	Content        string    `json:"content"`
-	Status         string    `json:"status"`
-	LikeCount      int       `json:"like_count"`
-	IsLiked        bool      `json:"is_liked"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	ReplyCount     int       `json:"reply_count"`
+	ID              string             `json:"id"`
+	BlogID          string             `json:"blog_id"`
+	Type            string             `json:"type"`
+	ParentID        *string            `json:"parent_id"`
+	TargetID        *string            `json:"target_id"`
+	AuthorID        string             `json:"author_id"`
+	AuthorName      string             `json:"author_name"`
+	TargetUserName  string             `json:"target_user_name"`
+	Content         string             `json:"content"`
+	Status          string             `json:"status"`
+	LikeCount       int                `json:"like_count"`
+	IsLiked         bool               `json:"is_liked"`
+	DislikeCount    int                `json:"dislike_count"`
+	ReactionCounts  map[string]int64   `json:"reaction_counts,omitempty"`
+	UserReaction    string             `json:"user_reaction,omitempty"` // "like" or "dislike" when the current user reacted
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	ReplyCount      int                `json:"reply_count"`
+	AutoHiddenAt    *time.Time         `json:"auto_hidden_at,omitempty"`
+	IsEdited        bool               `json:"is_edited"`
+	EditCount       int                `json:"edit_count"`
+	EditedAt        *time.Time         `json:"edited_at,omitempty"`
+	Mentions        []*MentionResponse `json:"mentions,omitempty"`
+	RenderedContent string             `json:"rendered_content"` // HTML-escaped content with mentions turned into profile links
+	IsAuthor        bool               `json:"is_author"`        // written by the author of the blog
+	IsPinned        bool               `json:"is_pinned"`
+	PinnedAt        *time.Time         `json:"pinned_at,omitempty"`
+}
+
+type MentionResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+type CommentEditResponse struct {
+	ID              string    `json:"id"`
+	EditorID        string    `json:"editor_id"`
+	PreviousContent string    `json:"previous_content"`
+	EditedAt        time.Time `json:"edited_at"`
+}
+
+type CommentEditHistoryResponse struct {
+	Comment *CommentResponse       `json:"comment"`
+	Edits   []*CommentEditResponse `json:"edits"`
 }
 
 type CommentThreadResponse struct {
@@ -54,6 +146,19 @@ type CommentThreadResponse struct {
 type CommentsResponse struct {
 	Comments   []*CommentResponse `json:"comments"`
 	Pagination PaginationMeta     `json:"pagination"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+type CommentSearchHit struct {
+	Comment   *CommentResponse `json:"comment"`
+	Score     float64          `json:"score"`
+	Highlight string           `json:"highlight"`
+}
+
+type CommentSearchResponse struct {
+	Query      string              `json:"query"`
+	Results    []*CommentSearchHit `json:"results"`
+	Pagination PaginationMeta      `json:"pagination"`
 }
 
 type PaginationMeta struct {
@@ -75,9 +180,295 @@ type CommentReportResponse struct {
 	CreatedAt  time.Time  `json:"created_at"`
 	ReviewedAt *time.Time `json:"reviewed_at"`
 	ReviewedBy *string    `json:"reviewed_by"`
+	Notes      string     `json:"notes"`
+}
+
+type CommentBlocklistResponse struct {
+	BlogID   string   `json:"blog_id"`
+	Keywords []string `json:"keywords"`
 }
 
 type ReportsResponse struct {
 	Reports    []*CommentReportResponse `json:"reports"`
 	Pagination PaginationMeta           `json:"pagination"`
 }
+
+type ReportedCommentResponse struct {
+	Comment        *CommentResponse `json:"comment"`
+	ReportCount    int64            `json:"report_count"`
+	Reasons        []string         `json:"reasons"`
+	LatestReportAt time.Time        `json:"latest_report_at"`
+}
+
+type ReportedCommentsResponse struct {
+	Items      []*ReportedCommentResponse `json:"items"`
+	Pagination PaginationMeta             `json:"pagination"`
+}
+
+type BulkModerationResponse struct {
+	Action         string `json:"action"`
+	TotalRequested int    `json:"total_requested"`
+	AffectedCount  int64  `json:"affected_count"`
+}
+
+type ModerationActionResponse struct {
+	ID          string    `json:"id"`
+	ModeratorID string    `json:"moderator_id"`
+	Action      string    `json:"action"`
+	TargetType  string    `json:"target_type"`
+	TargetID    string    `json:"target_id"`
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ModerationLogResponse struct {
+	Actions    []*ModerationActionResponse `json:"actions"`
+	Pagination PaginationMeta              `json:"pagination"`
+}
+
+type NotificationResponse struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	SenderUserID    *string   `json:"sender_user_id,omitempty"`
+	Message         string    `json:"message"`
+	RelatedEntityID *string   `json:"related_entity_id,omitempty"`
+	IsRead          bool      `json:"is_read"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type NotificationsResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	UnreadCount   int64                   `json:"unread_count"`
+	Pagination    PaginationMeta          `json:"pagination"`
+}
+
+type UserSuggestionResponse struct {
+	UserID    string  `json:"user_id"`
+	Username  string  `json:"username"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+type ReactionSummaryResponse struct {
+	TargetID     string           `json:"target_id"`
+	TargetType   string           `json:"target_type"`
+	Counts       map[string]int64 `json:"counts"`
+	Total        int64            `json:"total"`
+	Available    []string         `json:"available"`               // reaction types that can be left on this target
+	UserReaction string           `json:"user_reaction,omitempty"` // the current user's reaction, if any
+}
+
+// ReactorResponse is a user who reacted to a target, as shown in "who reacted" listings
+type ReactorResponse struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	FirstName *string   `json:"first_name,omitempty"`
+	LastName  *string   `json:"last_name,omitempty"`
+	AvatarURL *string   `json:"avatar_url,omitempty"`
+	Type      string    `json:"type"`
+	ReactedAt time.Time `json:"reacted_at"`
+}
+
+type ReactorsResponse struct {
+	TargetID   string             `json:"target_id"`
+	TargetType string             `json:"target_type"`
+	Reactors   []*ReactorResponse `json:"reactors"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// UserReactionResponse is one entry of a user's own reaction history
+type UserReactionResponse struct {
+	TargetID   string    `json:"target_id"`
+	TargetType string    `json:"target_type"`
+	Type       string    `json:"type"`
+	ReactedAt  time.Time `json:"reacted_at"`
+}
+
+type UserReactionsResponse struct {
+	Reactions  []*UserReactionResponse `json:"reactions"`
+	Pagination PaginationMeta          `json:"pagination"`
+}
+
+// CounterDiscrepancy is one stored counter that disagreed with its recomputed value
+type CounterDiscrepancy struct {
+	TargetType string  `json:"target_type"`
+	TargetID   string  `json:"target_id"`
+	Field      string  `json:"field"`
+	Stored     float64 `json:"stored"`
+	Actual     float64 `json:"actual"`
+}
+
+// ReconciliationReport summarizes a counter reconciliation run
+type ReconciliationReport struct {
+	StartedAt       time.Time             `json:"started_at"`
+	FinishedAt      time.Time             `json:"finished_at"`
+	DryRun          bool                  `json:"dry_run"`
+	BlogsChecked    int                   `json:"blogs_checked"`
+	BlogsFixed      int                   `json:"blogs_fixed"`
+	BlogsSkipped    int                   `json:"blogs_skipped"` // changed while being checked; left for the next run
+	CommentsChecked int                   `json:"comments_checked"`
+	CommentsFixed   int                   `json:"comments_fixed"`
+	CommentsSkipped int                   `json:"comments_skipped"`
+	Discrepancies   []*CounterDiscrepancy `json:"discrepancies"`
+	Truncated       bool                  `json:"truncated,omitempty"` // more discrepancies were found than are listed
+}
+
+// AnalyticsTotals sums blog activity over a period. Unique viewers are counted per bucket
+// and summed, so a viewer returning on another day is counted again.
+type AnalyticsTotals struct {
+	Views         int64 `json:"views"`
+	UniqueViewers int64 `json:"unique_viewers"`
+	Reactions     int64 `json:"reactions"`
+	Comments      int64 `json:"comments"`
+}
+
+type AnalyticsPoint struct {
+	Start time.Time `json:"start"`
+	AnalyticsTotals
+}
+
+type BreakdownEntry struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// BlogAnalytics is one blog's activity over the period, with a time series when requested
+type BlogAnalytics struct {
+	BlogID string           `json:"blog_id"`
+	Title  string           `json:"title"`
+	Slug   string           `json:"slug"`
+	Totals AnalyticsTotals  `json:"totals"`
+	Series []AnalyticsPoint `json:"series,omitempty"`
+}
+
+type AuthorAnalyticsResponse struct {
+	From        time.Time        `json:"from"`
+	To          time.Time        `json:"to"`
+	Granularity string           `json:"granularity"`
+	Totals      AnalyticsTotals  `json:"totals"`
+	Blogs       []*BlogAnalytics `json:"blogs"`
+	TopPosts    []*BlogAnalytics `json:"top_posts"`
+	Referrers   []BreakdownEntry `json:"referrers"`
+	UserAgents  []BreakdownEntry `json:"user_agents"`
+	Networks    []BreakdownEntry `json:"networks"`
+}
+
+// TrackViewRequest describes the request behind a blog view. Headers holds the request
+// headers with lowercase names, used for fingerprinting and privacy signals. The raw IP
+// address and user agent are never stored with the view.
+type TrackViewRequest struct {
+	UserID    string
+	IPAddress string
+	UserAgent string
+	Referrer  string
+	Headers   map[string]string
+}
+
+type FlaggedViewResponse struct {
+	ID              string    `json:"id"`
+	BlogID          string    `json:"blog_id"`
+	UserID          string    `json:"user_id,omitempty"`
+	IPHash          string    `json:"ip_hash"`
+	IPPrefix        string    `json:"ip_prefix,omitempty"`
+	UserAgentFamily string    `json:"user_agent_family,omitempty"`
+	Fingerprint     string    `json:"fingerprint"`
+	Action          string    `json:"action"`
+	Rules           []string  `json:"rules"`
+	Reasons         []string  `json:"reasons"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type FlaggedViewsResponse struct {
+	Views      []*FlaggedViewResponse `json:"views"`
+	Pagination PaginationMeta         `json:"pagination"`
+}
+
+// BlogSummaryResponse is the compact form of a blog used in bookmark and feed listings.
+type BlogSummaryResponse struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Slug         string     `json:"slug"`
+	AuthorID     string     `json:"author_id"`
+	Excerpt      string     `json:"excerpt"`
+	Tags         []string   `json:"tags"`
+	ViewCount    int        `json:"view_count"`
+	LikeCount    int        `json:"like_count"`
+	CommentCount int        `json:"comment_count"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+}
+
+type BookmarkResponse struct {
+	ID        string               `json:"id"`
+	BlogID    string               `json:"blog_id"`
+	ListID    string               `json:"list_id,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	Blog      *BlogSummaryResponse `json:"blog"`
+}
+
+type BookmarksResponse struct {
+	Bookmarks  []*BookmarkResponse `json:"bookmarks"`
+	Pagination PaginationMeta      `json:"pagination"`
+}
+
+type ReadingListResponse struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	Visibility    string    `json:"visibility"`
+	BookmarkCount int64     `json:"bookmark_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ReadingListsResponse struct {
+	Lists []*ReadingListResponse `json:"lists"`
+}
+
+type ReadingListDetailResponse struct {
+	List       *ReadingListResponse `json:"list"`
+	Bookmarks  []*BookmarkResponse  `json:"bookmarks"`
+	Pagination PaginationMeta       `json:"pagination"`
+}
+
+type FollowResponse struct {
+	FollowerID string    `json:"follower_id"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type FollowsResponse struct {
+	Follows    []*FollowResponse `json:"follows"`
+	Pagination PaginationMeta    `json:"pagination"`
+}
+
+// FollowStatsResponse carries the follow counts shown on a public profile. IsFollowing is
+// only set for an authenticated viewer looking at someone else's profile.
+type FollowStatsResponse struct {
+	FollowerCount    int64 `json:"follower_count"`
+	FollowingCount   int64 `json:"following_count"`
+	FollowedTagCount int64 `json:"followed_tag_count"`
+	IsFollowing      *bool `json:"is_following,omitempty"`
+}
+
+// FeedItemResponse is a blog in the personalized feed along with why it was included.
+type FeedItemResponse struct {
+	Blog           *BlogSummaryResponse `json:"blog"`
+	FollowedAuthor bool                 `json:"followed_author"`
+	FollowedTags   []string             `json:"followed_tags,omitempty"`
+	Score          float64              `json:"score"`
+}
+
+type FeedResponse struct {
+	Items      []*FeedItemResponse `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// RelatedPostResponse is a post recommended on blog detail, with the signals that matched.
+type RelatedPostResponse struct {
+	Blog           *BlogSummaryResponse `json:"blog"`
+	Score          float64              `json:"score"`
+	SharedTags     []string             `json:"shared_tags,omitempty"`
+	CoReaders      int64                `json:"co_readers,omitempty"`
+	TextSimilarity float64              `json:"text_similarity,omitempty"`
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+// AnalyticsHandler serves rolled-up blog analytics to authors.
+type AnalyticsHandler struct {
+	analyticsUsecase *usecase.AnalyticsUsecase
+}
+
+func NewAnalyticsHandler(analyticsUsecase *usecase.AnalyticsUsecase) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsUsecase: analyticsUsecase}
+}
+
+// GET /api/v1/me/analytics?granularity=day&from=2025-01-01&to=2025-01-31&blog_id=
+func (h *AnalyticsHandler) GetMyAnalytics(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	query := usecase.AnalyticsQuery{
+		BlogID:      c.Query("blog_id"),
+		Granularity: c.Query("granularity"),
+	}
+	for param, dst := range map[string]**time.Time{"from": &query.From, "to": &query.To} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		t, err := parseAnalyticsTime(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + ": use RFC3339 or YYYY-MM-DD"})
+			return
+		}
+		*dst = &t
+	}
+
+	analytics, err := h.analyticsUsecase.GetAuthorAnalytics(c.Request.Context(), userID, query)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "invalid"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case strings.HasPrefix(err.Error(), "unauthorized"):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case strings.HasPrefix(err.Error(), "blog not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "blog not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": analytics})
+}
+
+func parseAnalyticsTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	usecasedto "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/dto"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
 )
@@ -28,12 +29,16 @@ type BlogHandlerInterface interface {
 var _ BlogHandlerInterface = (*BlogHandler)(nil)
 
 type BlogHandler struct {
-	blogUsecase usecase.IBlogUseCase
+	blogUsecase     usecase.IBlogUseCase
+	bookmarkUsecase *usecase.BookmarkUsecase
+	relatedUsecase  *usecase.RelatedPostsUsecase
 }
 
-func NewBlogHandler(blogUsecase usecase.IBlogUseCase) *BlogHandler {
+func NewBlogHandler(blogUsecase usecase.IBlogUseCase, bookmarkUsecase *usecase.BookmarkUsecase, relatedUsecase *usecase.RelatedPostsUsecase) *BlogHandler {
 	return &BlogHandler{
-		blogUsecase: blogUsecase,
+		blogUsecase:     blogUsecase,
+		bookmarkUsecase: bookmarkUsecase,
+		relatedUsecase:  relatedUsecase,
 	}
 }
 
@@ -154,7 +159,21 @@ func (h *BlogHandler) GetBlogDetailHandler(cxt *gin.Context) {
 		return
 	}
 
-	SuccessHandler(cxt, http.StatusOK, dto.ToBlogResponse(&blog))
+	resp := dto.ToBlogResponse(&blog)
+	// Authenticated readers also learn whether they saved this blog
+	if userID := cxt.GetString("userID"); userID != "" && h.bookmarkUsecase != nil {
+		if bookmarked, err := h.bookmarkUsecase.IsBookmarked(cxt.Request.Context(), userID, blog.ID); err == nil {
+			resp.IsBookmarked = &bookmarked
+		}
+	}
+	// Related posts are precomputed; a lookup failure should not hide the post itself
+	if h.relatedUsecase != nil {
+		if related, err := h.relatedUsecase.GetRelatedPosts(cxt.Request.Context(), blog.ID); err == nil {
+			resp.RelatedPosts = related
+		}
+	}
+
+	SuccessHandler(cxt, http.StatusOK, resp)
 }
 
 // UpdateBlogHandler
@@ -236,14 +255,23 @@ func (h *BlogHandler) DeleteBlogHandler(cxt *gin.Context) {
 
 func (h *BlogHandler) TrackBlogViewHandler(c *gin.Context) {
 	blogID := c.Param("blogID")
-	ipAddress := c.ClientIP()
-	userAgent := c.Request.UserAgent()
 
 	// User can be anonymous, so we don't fail if userID is not present.
 	userIDAny, _ := c.Get("userID")
 	userID, _ := userIDAny.(string)
 
-	err := h.blogUsecase.TrackBlogView(c.Request.Context(), blogID, userID, ipAddress, userAgent)
+	headers := make(map[string]string, len(c.Request.Header))
+	for name := range c.Request.Header {
+		headers[strings.ToLower(name)] = c.Request.Header.Get(name)
+	}
+
+	err := h.blogUsecase.TrackBlogView(c.Request.Context(), blogID, usecasedto.TrackViewRequest{
+		UserID:    userID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Referrer:  c.Request.Referer(),
+		Headers:   headers,
+	})
 	if err != nil {
 		errMsg := err.Error()
 		switch {
@@ -256,6 +284,9 @@ func (h *BlogHandler) TrackBlogViewHandler(c *gin.Context) {
 		case errMsg == "exceeded IP rotation limit: too many IPs used by this user recently":
 			ErrorHandler(c, 429, "Exceeded IP rotation limit")
 			return
+		case strings.HasPrefix(errMsg, "view blocked"):
+			ErrorHandler(c, http.StatusForbidden, "View blocked")
+			return
 		default:
 			ErrorHandler(c, http.StatusInternalServerError, "Failed to process blog view")
 			return
@@ -269,6 +300,7 @@ func (h *BlogHandler) TrackBlogViewHandler(c *gin.Context) {
 func (h *BlogHandler) SearchAndFilterBlogsHandler(c *gin.Context) {
 	// Query and filter params
 	query := c.Query("q")
+	mode := c.Query("mode") // keyword, semantic or hybrid
 	tags := c.QueryArray("tags")
 	var dateFrom, dateTo *time.Time
 	if v := c.Query("dateFrom"); v != "" {
@@ -311,19 +343,51 @@ func (h *BlogHandler) SearchAndFilterBlogsHandler(c *gin.Context) {
 	// Pagination
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
-	// Call usecase
-	blogs, total, current, pages, err := h.blogUsecase.SearchAndFilterBlogs(c.Request.Context(), query, tags, dateFrom, dateTo, minViews, maxViews, minLikes, maxLikes, authorID, page, pageSize)
+	// Call usecase; signed-in authors may search their own posts in any status
+	req := usecasedto.SearchBlogsRequest{
+		Query:     query,
+		Mode:      mode,
+		SortBy:    c.Query("sortBy"),
+		SortOrder: c.Query("sortOrder"),
+		Tags:      tags,
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
+		MinViews:  minViews,
+		MaxViews:  maxViews,
+		MinLikes:  minLikes,
+		MaxLikes:  maxLikes,
+		AuthorID:  authorID,
+		Status:    c.Query("status"),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	result, err := h.blogUsecase.SearchAndFilterBlogs(c.Request.Context(), req, c.GetString("userID"))
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ErrorHandler(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		ErrorHandler(c, http.StatusInternalServerError, "Failed to search and filter blogs")
 		return
 	}
 	// Map to response
-	var resp []dto.BlogResponse
-	for _, b := range blogs {
-		resp = append(resp, dto.ToBlogResponse(&b))
-	}
-	result := dto.PaginatedBlogResponse{Blogs: resp, TotalCount: total, CurrentPage: current, TotalPages: pages}
-	SuccessHandler(c, http.StatusOK, result)
+	resp := dto.BlogSearchResponse{
+		Blogs:          make([]dto.BlogSearchHitResponse, 0, len(result.Hits)),
+		TotalCount:     result.TotalCount,
+		CurrentPage:    result.CurrentPage,
+		TotalPages:     result.TotalPages,
+		Mode:           result.Mode,
+		CorrectedQuery: result.CorrectedQuery,
+		Facets:         result.Facets,
+	}
+	for _, hit := range result.Hits {
+		resp.Blogs = append(resp.Blogs, dto.BlogSearchHitResponse{
+			BlogResponse: dto.ToBlogResponse(&hit.Blog),
+			Score:        hit.Score,
+			Highlight:    dto.BlogSearchHighlight{Title: hit.TitleHighlight, Content: hit.ContentHighlight},
+		})
+	}
+	SuccessHandler(c, http.StatusOK, resp)
 }
 
 // GetPopularBlogsHandler handles retrieval of popular blogs
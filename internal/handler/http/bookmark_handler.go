@@ -0,0 +1,227 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+type BookmarkHandler struct {
+	bookmarkUsecase *usecase.BookmarkUsecase
+}
+
+func NewBookmarkHandler(bookmarkUsecase *usecase.BookmarkUsecase) *BookmarkHandler {
+	return &BookmarkHandler{bookmarkUsecase: bookmarkUsecase}
+}
+
+// AddBookmarkRequest optionally names the reading list to save the blog to.
+type AddBookmarkRequest struct {
+	ListID string `json:"list_id"`
+}
+
+// ReadingListRequest creates or updates a reading list; omitted fields are left unchanged.
+type ReadingListRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility" binding:"omitempty,oneof=public private"`
+}
+
+// POST /api/v1/blogs/:blogID/bookmark
+// Responds 201 with a new bookmark, or 200 with the existing one when the blog was already saved.
+func (h *BookmarkHandler) AddBookmark(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req AddBookmarkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	bookmark, created, err := h.bookmarkUsecase.AddBookmark(c.Request.Context(), userID, c.Param("blogID"), req.ListID)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"data": bookmark})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": bookmark})
+}
+
+// DELETE /api/v1/blogs/:blogID/bookmark?list_id=
+// Without list_id the blog is removed from everywhere it was saved; an empty list_id
+// removes only the unsorted bookmark.
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var listID *string
+	if id, ok := c.GetQuery("list_id"); ok {
+		listID = &id
+	}
+	if err := h.bookmarkUsecase.RemoveBookmark(c.Request.Context(), userID, c.Param("blogID"), listID); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/v1/me/bookmarks?list_id=&page=1&page_size=20
+// Without list_id every bookmark is listed; an empty list_id lists the unsorted ones.
+func (h *BookmarkHandler) ListMyBookmarks(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	var listID *string
+	if id, ok := c.GetQuery("list_id"); ok {
+		listID = &id
+	}
+
+	bookmarks, err := h.bookmarkUsecase.ListBookmarks(c.Request.Context(), userID, listID, page, pageSize)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": bookmarks})
+}
+
+// GET /api/v1/me/reading-lists
+func (h *BookmarkHandler) ListMyReadingLists(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	lists, err := h.bookmarkUsecase.ListReadingLists(c.Request.Context(), userID, userID)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": lists})
+}
+
+// POST /api/v1/me/reading-lists
+func (h *BookmarkHandler) CreateReadingList(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req ReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var name, description, visibility string
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Description != nil {
+		description = *req.Description
+	}
+	if req.Visibility != nil {
+		visibility = *req.Visibility
+	}
+
+	list, err := h.bookmarkUsecase.CreateReadingList(c.Request.Context(), userID, name, description, visibility)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": list})
+}
+
+// PUT /api/v1/reading-lists/:listID
+func (h *BookmarkHandler) UpdateReadingList(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req ReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.bookmarkUsecase.UpdateReadingList(c.Request.Context(), userID, c.Param("listID"), req.Name, req.Description, req.Visibility)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// DELETE /api/v1/reading-lists/:listID
+func (h *BookmarkHandler) DeleteReadingList(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.bookmarkUsecase.DeleteReadingList(c.Request.Context(), userID, c.Param("listID")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/v1/users/:userId/reading-lists
+// Public lists only, unless the authenticated user is the owner.
+func (h *BookmarkHandler) ListUserReadingLists(c *gin.Context) {
+	lists, err := h.bookmarkUsecase.ListReadingLists(c.Request.Context(), c.Param("userId"), c.GetString("userID"))
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": lists})
+}
+
+// GET /api/v1/reading-lists/:listID?page=1&page_size=20
+func (h *BookmarkHandler) GetReadingList(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	list, err := h.bookmarkUsecase.GetReadingList(c.Request.Context(), c.Param("listID"), c.GetString("userID"), page, pageSize)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+func respondBookmarkError(c *gin.Context, err error) {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+	case strings.HasPrefix(msg, "unauthorized"):
+		c.JSON(http.StatusForbidden, gin.H{"error": msg})
+	case strings.HasPrefix(msg, "blog not found"), strings.HasPrefix(msg, "bookmark not found"), strings.HasPrefix(msg, "reading list not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": strings.SplitN(msg, ":", 2)[0]})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
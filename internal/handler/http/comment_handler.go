@@ -3,6 +3,8 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -46,6 +48,10 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "comments are locked on this blog" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -108,6 +114,13 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 		return
 	}
 
+	// Edit window configured by middleware.ValidateEditTimeWindow
+	if minutes, ok := c.Get("edit_window_minutes"); ok {
+		if m, ok := minutes.(int); ok && m > 0 {
+			req.EditWindow = time.Duration(m) * time.Minute
+		}
+	}
+
 	comment, err := h.commentUC.UpdateComment(c.Request.Context(), commentID.String(), userID.String(), req)
 	if err != nil {
 		if err.Error() == "comment not found" {
@@ -171,22 +184,18 @@ func (h *CommentHandler) GetBlogComments(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
 	// Get user ID if authenticated (optional)
 	var userID *string
-	if userIDStr, exists := c.Get("user_id"); exists {
+	if userIDStr, exists := c.Get("userID"); exists {
 		if uid, err := uuid.Parse(userIDStr.(string)); err == nil {
 			uidStr := uid.String()
 			userID = &uidStr
 		}
 	}
 
-	comments, err := h.commentUC.GetBlogComments(c.Request.Context(), blogID, page, pageSize, userID)
+	comments, err := h.commentUC.GetBlogComments(c.Request.Context(), blogID, parseListCommentsRequest(c, "page_size"), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleListError(c, err)
 		return
 	}
 
@@ -231,13 +240,9 @@ func (h *CommentHandler) GetUserComments(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	comments, err := h.commentUC.GetUserComments(c.Request.Context(), userID.String(), page, pageSize)
+	comments, err := h.commentUC.GetUserComments(c.Request.Context(), userID.String(), parseListCommentsRequest(c, "page_size"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleListError(c, err)
 		return
 	}
 
@@ -254,7 +259,7 @@ func (h *CommentHandler) UpdateCommentStatus(c *gin.Context) {
 
 	commentIDStr := c.Param("commentID")
 
-	moderatorIDStr, exists := c.Get("user_id")
+	moderatorIDStr, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
@@ -272,6 +277,10 @@ func (h *CommentHandler) UpdateCommentStatus(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.HasPrefix(err.Error(), "invalid comment status") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -283,7 +292,7 @@ func (h *CommentHandler) UpdateCommentStatus(c *gin.Context) {
 func (h *CommentHandler) LikeComment(c *gin.Context) {
 	commentIDStr := c.Param("commentID")
 
-	userIDStr, exists := c.Get("user_id")
+	userIDStr, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
@@ -315,7 +324,7 @@ func (h *CommentHandler) LikeComment(c *gin.Context) {
 func (h *CommentHandler) UnlikeComment(c *gin.Context) {
 	commentIDStr := c.Param("commentID")
 
-	userIDStr, exists := c.Get("user_id")
+	userIDStr, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
@@ -353,7 +362,7 @@ func (h *CommentHandler) ReportComment(c *gin.Context) {
 	}
 
 	commentIDStr := c.Param("commentID")
-	userIDStr, exists := c.Get("user_id")
+	userIDStr, exists := c.Get("userID")
 
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -372,6 +381,10 @@ func (h *CommentHandler) ReportComment(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "comment already reported by user" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -440,6 +453,10 @@ func (h *CommentHandler) CreateReply(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "comments are locked on this blog" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "parent comment not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -459,70 +476,23 @@ func (h *CommentHandler) GetCommentReplies(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	depth, _ := strconv.Atoi(c.DefaultQuery("depth", "3"))
-
-	// Validate page and pageSize
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 50 {
-		pageSize = 10
-	}
-	if depth < 1 || depth > 10 {
-		depth = 3
-	}
-
 	// Get optional user ID for personalized data
 	var userID *string
-	if userIDStr, exists := c.Get("user_id"); exists {
-		uid := userIDStr.(string)
-		userID = &uid
-	} else if userIDStr, exists := c.Get("userID"); exists {
+	if userIDStr, exists := c.Get("userID"); exists {
 		uid := userIDStr.(string)
 		userID = &uid
 	}
 
-	// Use the existing GetCommentThread to fetch the full nested tree
-	thread, err := h.commentUC.GetCommentThread(c.Request.Context(), commentID, userID)
+	replies, err := h.commentUC.GetCommentReplies(c.Request.Context(), commentID, parseListCommentsRequest(c, "limit"), userID)
 	if err != nil {
-		if err.Error() == "comment not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleListError(c, err)
 		return
 	}
 
-	// Flatten all nested replies into a single list
-	flat := make([]*dto.CommentThreadResponse, 0)
-	var flatten func(nodes []*dto.CommentThreadResponse)
-	flatten = func(nodes []*dto.CommentThreadResponse) {
-		for _, n := range nodes {
-			// Shallow copy without children to keep payload lean
-			copy := &dto.CommentThreadResponse{
-				Comment: n.Comment,
-				Depth:   n.Depth,
-				Replies: nil,
-			}
-			flat = append(flat, copy)
-			if len(n.Replies) > 0 {
-				flatten(n.Replies)
-			}
-		}
-	}
-	flatten(thread.Replies)
-
 	c.JSON(http.StatusOK, gin.H{
-		"replies": flat,
-		"pagination": gin.H{
-			"page":      page,
-			"page_size": pageSize,
-			"total":     len(flat),
-			"has_more":  false,
-		},
+		"replies":     replies.Comments,
+		"pagination":  replies.Pagination,
+		"next_cursor": replies.NextCursor,
 	})
 }
 
@@ -619,21 +589,9 @@ func (h *CommentHandler) GetCommentsByUser(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-
-	// Validate pagination
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	comments, err := h.commentUC.GetUserComments(c.Request.Context(), userID, page, pageSize)
+	comments, err := h.commentUC.GetUserComments(c.Request.Context(), userID, parseListCommentsRequest(c, "page_size"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleListError(c, err)
 		return
 	}
 
@@ -747,13 +705,6 @@ func (h *CommentHandler) GetCommentStatistics(c *gin.Context) {
 
 // BulkDeleteComments allows admins to delete multiple comments
 func (h *CommentHandler) BulkDeleteComments(c *gin.Context) {
-	// Check if user is admin
-	userRole, exists := c.Get("user_role")
-	if !exists || userRole.(string) != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-		return
-	}
-
 	var req struct {
 		CommentIDs []string `json:"comment_ids" validate:"required,min=1,max=100"`
 		Reason     string   `json:"reason" validate:"max=500"`
@@ -764,30 +715,15 @@ func (h *CommentHandler) BulkDeleteComments(c *gin.Context) {
 		return
 	}
 
-	userIDStr, _ := c.Get("user_id")
-	userID := userIDStr.(string)
-
-	deletedCount := 0
-	errors := make([]string, 0)
-
-	for _, commentID := range req.CommentIDs {
-		err := h.commentUC.DeleteComment(c.Request.Context(), commentID, userID)
-		if err != nil {
-			errors = append(errors, commentID+": "+err.Error())
-		} else {
-			deletedCount++
-		}
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"deleted_count":   deletedCount,
-		"total_requested": len(req.CommentIDs),
-		"errors":          errors,
-		"reason":          req.Reason,
+	h.bulkModerate(c, dto.BulkModerationRequest{
+		Action:     "delete",
+		CommentIDs: req.CommentIDs,
+		Reason:     req.Reason,
 	})
 }
 
-// SearchComments searches comments by content or author
+// SearchComments searches comments by content or author. Moderators may search across
+// all statuses; everyone else only sees approved comments.
 func (h *CommentHandler) SearchComments(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
@@ -796,34 +732,212 @@ func (h *CommentHandler) SearchComments(c *gin.Context) {
 	}
 
 	// Parse pagination and filters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
-	blogID := c.Query("blog_id")
-	authorID := c.Query("author_id")
-	status := c.DefaultQuery("status", "approved")
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page. Use a positive number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page_size. Use a number between 1 and 100"})
+		return
+	}
 
-	// Validate pagination
-	if page < 1 {
-		page = 1
+	var dateFrom, dateTo *time.Time
+	if v := c.Query("date_from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_from format. Use RFC3339 (e.g., 2025-08-06T15:04:05Z)"})
+			return
+		}
+		dateFrom = &t
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	if v := c.Query("date_to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date_to format. Use RFC3339 (e.g., 2025-08-06T15:04:05Z)"})
+			return
+		}
+		dateTo = &t
 	}
 
-	// For now, we'll return a simple response
-	// In a real implementation, you'd implement search in the usecase
-	c.JSON(http.StatusOK, gin.H{
-		"query": query,
-		"filters": gin.H{
-			"blog_id":   blogID,
-			"author_id": authorID,
-			"status":    status,
-		},
-		"pagination": gin.H{
-			"page":      page,
-			"page_size": pageSize,
-		},
-		"comments": []interface{}{},
-		"message":  "Search functionality not fully implemented yet",
-	})
+	var userID *string
+	if userIDStr, exists := c.Get("userID"); exists {
+		uid := userIDStr.(string)
+		userID = &uid
+	}
+
+	req := dto.SearchCommentsRequest{
+		Query:    query,
+		BlogID:   c.Query("blog_id"),
+		AuthorID: c.Query("author_id"),
+		Status:   c.DefaultQuery("status", "approved"),
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	results, err := h.commentUC.SearchComments(c.Request.Context(), req, userID, isAdmin(c))
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// GetCommentBlocklist returns the keywords the blog author has blocked from comments
+func (h *CommentHandler) GetCommentBlocklist(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blocklist, err := h.commentUC.GetBlogCommentBlocklist(c.Request.Context(), c.Param("blogID"), userIDStr.(string))
+	if err != nil {
+		h.handleOwnedBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": blocklist})
+}
+
+// UpdateCommentBlocklist replaces the keywords the blog author has blocked from comments
+func (h *CommentHandler) UpdateCommentBlocklist(c *gin.Context) {
+	var req dto.UpdateCommentBlocklistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blocklist, err := h.commentUC.UpdateBlogCommentBlocklist(c.Request.Context(), c.Param("blogID"), userIDStr.(string), req)
+	if err != nil {
+		h.handleOwnedBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": blocklist})
+}
+
+// GetCommentEditHistory returns every earlier version of a comment (moderators only)
+func (h *CommentHandler) GetCommentEditHistory(c *gin.Context) {
+	history, err := h.commentUC.GetCommentEditHistory(c.Request.Context(), c.Param("commentID"))
+	if err != nil {
+		if err.Error() == "comment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
+// SetCommentsLock locks or unlocks new comments on a blog (blog author only)
+func (h *CommentHandler) SetCommentsLock(c *gin.Context) {
+	var req dto.UpdateCommentLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blogID := c.Param("blogID")
+	if err := h.commentUC.SetBlogCommentsLocked(c.Request.Context(), blogID, userIDStr.(string), *req.Locked); err != nil {
+		h.handleOwnedBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"blog_id": blogID, "comments_locked": *req.Locked}})
+}
+
+// PinComment pins a top-level comment to the top of its blog's comments (blog author only)
+func (h *CommentHandler) PinComment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	comment, err := h.commentUC.PinComment(c.Request.Context(), c.Param("commentID"), userIDStr.(string))
+	if err != nil {
+		h.handleOwnedBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comment})
+}
+
+// UnpinComment removes a comment's pin (blog author only)
+func (h *CommentHandler) UnpinComment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.commentUC.UnpinComment(c.Request.Context(), c.Param("commentID"), userIDStr.(string)); err != nil {
+		h.handleOwnedBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment unpinned successfully"})
+}
+
+// handleOwnedBlogError maps errors from blog-author comment tools to HTTP responses
+func (h *CommentHandler) handleOwnedBlogError(c *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "blog not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+	case err.Error() == "comment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseListCommentsRequest reads page/cursor pagination and sorting from the query string.
+// sizeParam names the page size parameter, which differs between endpoints.
+func parseListCommentsRequest(c *gin.Context, sizeParam string) dto.ListCommentsRequest {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery(sizeParam, "20"))
+	return dto.ListCommentsRequest{
+		Page:     page,
+		PageSize: pageSize,
+		Cursor:   c.Query("cursor"),
+		Sort:     c.Query("sort"),
+	}
+}
+
+func (h *CommentHandler) handleListError(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "comment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+// Admin moderation console. All handlers here are mounted behind middleware.RequireRole.
+
+// GetModerationQueue lists comments awaiting review (?status=pending|flagged|hidden|all)
+func (h *CommentHandler) GetModerationQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	queue, err := h.commentUC.GetModerationQueue(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid queue status") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": queue})
+}
+
+// GetReportedComments lists reported comments grouped with their report counts
+func (h *CommentHandler) GetReportedComments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	reported, err := h.commentUC.GetReportedComments(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reported})
+}
+
+// BulkModerateComments approves, rejects or deletes several comments at once
+func (h *CommentHandler) BulkModerateComments(c *gin.Context) {
+	var req dto.BulkModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	h.bulkModerate(c, req)
+}
+
+func (h *CommentHandler) bulkModerate(c *gin.Context, req dto.BulkModerationRequest) {
+	moderatorID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	result, err := h.commentUC.BulkModerateComments(c.Request.Context(), moderatorID.(string), req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// ResolveReport marks a report as reviewed or dismissed with the reviewer's notes
+func (h *CommentHandler) ResolveReport(c *gin.Context) {
+	var req dto.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	reviewerID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	err := h.commentUC.ResolveReport(c.Request.Context(), c.Param("reportID"), reviewerID.(string), req)
+	if err != nil {
+		if err.Error() == "report not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid report status") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report resolved successfully"})
+}
+
+// GetModerationAuditLog returns the moderator action history (?moderator_id, ?target_id)
+func (h *CommentHandler) GetModerationAuditLog(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	log, err := h.commentUC.GetModerationLog(c.Request.Context(), c.Query("moderator_id"), c.Query("target_id"), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": log})
+}
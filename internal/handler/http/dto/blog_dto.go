@@ -3,7 +3,10 @@ package dto
 import (
 	"time"
 
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	usecasedto "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
 )
 
 // Request DTOs for Blog Handlers
@@ -32,20 +35,27 @@ type UpdateBlogRequest struct {
 
 // BlogResponse defines the standard JSON response for a single blog
 type BlogResponse struct {
-	ID              string     `json:"id"`
-	Title           string     `json:"title"`
-	Content         string     `json:"content"`
-	AuthorID        string     `json:"author_id"`
-	Slug            string     `json:"slug"`
-	Status          string     `json:"status"`
-	ViewCount       int        `json:"view_count"`
-	LikeCount       int        `json:"like_count"`
-	CommentCount    int        `json:"comment_count"`
-	Popularity      float64    `json:"popularity"`
-	FeaturedImageID *string    `json:"featured_image_id,omitempty"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
-	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	ID              string                            `json:"id"`
+	Title           string                            `json:"title"`
+	Content         string                            `json:"content"`
+	AuthorID        string                            `json:"author_id"`
+	Slug            string                            `json:"slug"`
+	Status          string                            `json:"status"`
+	ViewCount       int                               `json:"view_count"`
+	LikeCount       int                               `json:"like_count"`
+	ReactionCounts  map[string]int64                  `json:"reaction_counts,omitempty"`
+	CommentCount    int                               `json:"comment_count"`
+	Popularity      float64                           `json:"popularity"`
+	TrendingScores  map[string]float64                `json:"trending_scores,omitempty"`
+	FeaturedImageID *string                           `json:"featured_image_id,omitempty"`
+	CreatedAt       time.Time                         `json:"created_at"`
+	UpdatedAt       time.Time                         `json:"updated_at"`
+	PublishedAt     *time.Time                        `json:"published_at,omitempty"`
+	CommentsLocked  bool                              `json:"comments_locked"`
+	Mentions        []entity.Mention                  `json:"mentions,omitempty"`
+	RenderedContent string                            `json:"rendered_content"`        // HTML-escaped content with mentions turned into profile links
+	IsBookmarked    *bool                             `json:"is_bookmarked,omitempty"` // set on blog detail for an authenticated reader
+	RelatedPosts    []*usecasedto.RelatedPostResponse `json:"related_posts,omitempty"` // set on blog detail
 }
 
 // PaginatedBlogResponse defines the structure for a paginated list of blogs.
@@ -56,6 +66,31 @@ type PaginatedBlogResponse struct {
 	TotalPages  int            `json:"total_pages"`
 }
 
+// BlogSearchHighlight holds a search hit's title and content snippet, HTML-escaped, with the
+// matched terms wrapped in <mark> tags.
+type BlogSearchHighlight struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// BlogSearchHitResponse is a blog found by search with its score and highlights.
+type BlogSearchHitResponse struct {
+	BlogResponse
+	Score     float64             `json:"score"`
+	Highlight BlogSearchHighlight `json:"highlight"`
+}
+
+// BlogSearchResponse is a page of search hits with facet counts over all of the matches.
+type BlogSearchResponse struct {
+	Blogs          []BlogSearchHitResponse `json:"blogs"`
+	TotalCount     int                     `json:"total_count"`
+	CurrentPage    int                     `json:"current_page"`
+	TotalPages     int                     `json:"total_pages"`
+	Mode           string                  `json:"mode"`
+	CorrectedQuery string                  `json:"corrected_query,omitempty"` // "did you mean" suggestion
+	Facets         contract.BlogFacets     `json:"facets"`
+}
+
 // DTO Mapper
 // a mapper function to convert *entity.Blog to a BlogResponse
 
@@ -69,11 +104,16 @@ func ToBlogResponse(blog *entity.Blog) BlogResponse {
 		Status:          string(blog.Status),
 		ViewCount:       blog.ViewCount,
 		LikeCount:       blog.LikeCount,
+		ReactionCounts:  blog.ReactionCounts,
 		CommentCount:    blog.CommentCount,
 		Popularity:      blog.Popularity,
+		TrendingScores:  blog.TrendingScores,
 		FeaturedImageID: blog.FeaturedImageID,
 		CreatedAt:       blog.CreatedAt,
 		UpdatedAt:       blog.UpdatedAt,
 		PublishedAt:     blog.PublishedAt,
+		CommentsLocked:  blog.CommentsLocked,
+		Mentions:        blog.Mentions,
+		RenderedContent: utils.RenderMentions(blog.Content, blog.Mentions),
 	}
 }
@@ -24,10 +24,11 @@ type RegisterRequest struct {
 
 // UpdateUserRequest is the DTO for updating user profile.
 type UpdateUserRequest struct {
-	Username  *string `json:"username,omitempty" binding:"omitempty,min=3,max=32"`
-	FirstName *string `json:"firstname,omitempty" binding:"omitempty,max=50"`
-	LastName  *string `json:"lastname,omitempty" binding:"omitempty,max=50"`
-	AvatarURL *string `json:"avatar_url,omitempty" binding:"omitempty,url"`
+	Username      *string `json:"username,omitempty" binding:"omitempty,min=3,max=32"`
+	FirstName     *string `json:"firstname,omitempty" binding:"omitempty,max=50"`
+	LastName      *string `json:"lastname,omitempty" binding:"omitempty,max=50"`
+	AvatarURL     *string `json:"avatar_url,omitempty" binding:"omitempty,url"`
+	HideReactions *bool   `json:"hide_reactions,omitempty"`
 }
 
 // ForgotPasswordRequest is the DTO for requesting password reset.
@@ -56,3 +57,8 @@ type ResendVerificationRequest struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
+
+// ReactRequest is the DTO for reacting to a blog or comment.
+type ReactRequest struct {
+	Type string `json:"type" binding:"required,max=32"`
+}
@@ -8,14 +8,20 @@ import (
 
 // UserResponse is the DTO for a user.
 type UserResponse struct {
-	ID        string  `json:"id"`
-	Username  string  `json:"username"`
-	Email     string  `json:"email"`
-	Role      string  `json:"role"`
-	FirstName *string `json:"first_name"`
-	LastName  *string `json:"last_name"`
-	AvatarURL *string `json:"avatar_url"`
-	CreatedAt string  `json:"created_at"`
+	ID            string  `json:"id"`
+	Username      string  `json:"username"`
+	Email         string  `json:"email"`
+	Role          string  `json:"role"`
+	FirstName     *string `json:"first_name"`
+	LastName      *string `json:"last_name"`
+	AvatarURL     *string `json:"avatar_url"`
+	HideReactions bool    `json:"hide_reactions"`
+	CreatedAt     string  `json:"created_at"`
+	// Follow stats, filled in on public profiles only
+	FollowerCount    *int64 `json:"follower_count,omitempty"`
+	FollowingCount   *int64 `json:"following_count,omitempty"`
+	FollowedTagCount *int64 `json:"followed_tag_count,omitempty"`
+	IsFollowing      *bool  `json:"is_following,omitempty"`
 }
 
 // LoginResponse is the DTO for a successful login.
@@ -28,14 +34,15 @@ type LoginResponse struct {
 // converts an entity.User to a UserResponse DTO.
 func ToUserResponse(user entity.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      string(user.Role),
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		AvatarURL: user.AvatarURL,
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		AvatarURL:     user.AvatarURL,
+		HideReactions: user.HideReactions,
+		CreatedAt:     user.CreatedAt.Format(time.RFC3339),
 	}
 }
 
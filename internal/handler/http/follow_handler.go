@@ -0,0 +1,147 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+type FollowHandler struct {
+	followUsecase *usecase.FollowUsecase
+}
+
+func NewFollowHandler(followUsecase *usecase.FollowUsecase) *FollowHandler {
+	return &FollowHandler{followUsecase: followUsecase}
+}
+
+// POST /api/v1/users/:userId/follow
+func (h *FollowHandler) FollowAuthor(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	created, err := h.followUsecase.FollowAuthor(c.Request.Context(), userID, c.Param("userId"))
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	respondFollowed(c, created)
+}
+
+// DELETE /api/v1/users/:userId/follow
+func (h *FollowHandler) UnfollowAuthor(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.followUsecase.UnfollowAuthor(c.Request.Context(), userID, c.Param("userId")); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// POST /api/v1/tags/:tag/follow
+func (h *FollowHandler) FollowTag(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	created, err := h.followUsecase.FollowTag(c.Request.Context(), userID, c.Param("tag"))
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	respondFollowed(c, created)
+}
+
+// DELETE /api/v1/tags/:tag/follow
+func (h *FollowHandler) UnfollowTag(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.followUsecase.UnfollowTag(c.Request.Context(), userID, c.Param("tag")); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/v1/me/following?type=author|tag&page=1&page_size=20
+func (h *FollowHandler) ListMyFollowing(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	follows, err := h.followUsecase.ListFollowing(c.Request.Context(), userID, c.Query("type"), page, pageSize)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": follows})
+}
+
+// GET /api/v1/users/:userId/followers?page=1&page_size=20
+func (h *FollowHandler) ListFollowers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	follows, err := h.followUsecase.ListFollowers(c.Request.Context(), c.Param("userId"), page, pageSize)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": follows})
+}
+
+// GET /api/v1/feed?cursor=&limit=20
+func (h *FollowHandler) GetFeed(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	feed, err := h.followUsecase.GetFeed(c.Request.Context(), userID, c.Query("cursor"), limit)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": feed})
+}
+
+func respondFollowed(c *gin.Context, created bool) {
+	if created {
+		c.JSON(http.StatusCreated, gin.H{"data": gin.H{"following": true}})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"following": true}})
+}
+
+func respondFollowError(c *gin.Context, err error) {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+	case strings.HasPrefix(msg, "user not found"), strings.HasPrefix(msg, "follow not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/dto"
 )
 
@@ -30,3 +31,18 @@ func BindAndValidate(c *gin.Context, req interface{}) error {
 	}
 	return nil
 }
+
+// isAdmin reports whether the authenticated user carries the admin role
+func isAdmin(c *gin.Context) bool {
+	role, exists := c.Get("userRole")
+	if !exists {
+		return false
+	}
+	switch r := role.(type) {
+	case entity.UserRole:
+		return r == entity.UserRoleAdmin
+	case string:
+		return r == string(entity.UserRoleAdmin)
+	}
+	return false
+}
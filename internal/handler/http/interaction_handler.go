@@ -2,9 +2,12 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/dto"
 	usecase "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
 )
 
@@ -83,3 +86,162 @@ func (h *InteractionHandler) DislikeBlogHandler(c *gin.Context) {
 		SuccessHandler(c, http.StatusOK, "Blog undisliked successfully")
 	}
 }
+
+func (h *InteractionHandler) DislikeCommentHandler(c *gin.Context) {
+	commentID := c.Param("commentID")
+	userID, exists := c.Get("userID")
+	if !exists {
+		ErrorHandler(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	userIDStr, ok := userID.(string)
+	if !ok {
+		ErrorHandler(c, http.StatusBadRequest, "Invalid user ID format in token")
+		return
+	}
+
+	if !h.likeUsecase.ExistsComment(c.Request.Context(), commentID) {
+		ErrorHandler(c, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	err := h.likeUsecase.ToggleDislike(c.Request.Context(), userIDStr, commentID, entity.TargetTypeComment)
+	if err != nil {
+		ErrorHandler(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// Determine the new state by checking if the user has disliked the comment
+	reaction, _ := h.likeUsecase.GetUserReaction(c.Request.Context(), userIDStr, commentID)
+	if reaction != nil && reaction.Type == entity.LIKE_TYPE_DISLIKE {
+		SuccessHandler(c, http.StatusOK, "Comment disliked successfully")
+	} else {
+		SuccessHandler(c, http.StatusOK, "Comment undisliked successfully")
+	}
+}
+
+// ReactToBlogHandler sets, switches or removes the user's reaction on a blog.
+func (h *InteractionHandler) ReactToBlogHandler(c *gin.Context) {
+	blogID := c.Param("blogID")
+	if !h.likeUsecase.ExistsBlog(c.Request.Context(), blogID) {
+		ErrorHandler(c, http.StatusNotFound, "Blog not found")
+		return
+	}
+	h.react(c, blogID, entity.TargetTypeBlog)
+}
+
+// ReactToCommentHandler sets, switches or removes the user's reaction on a comment.
+func (h *InteractionHandler) ReactToCommentHandler(c *gin.Context) {
+	commentID := c.Param("commentID")
+	if !h.likeUsecase.ExistsComment(c.Request.Context(), commentID) {
+		ErrorHandler(c, http.StatusNotFound, "Comment not found")
+		return
+	}
+	h.react(c, commentID, entity.TargetTypeComment)
+}
+
+// GetBlogReactionsHandler returns the reaction breakdown of a blog.
+func (h *InteractionHandler) GetBlogReactionsHandler(c *gin.Context) {
+	blogID := c.Param("blogID")
+	if !h.likeUsecase.ExistsBlog(c.Request.Context(), blogID) {
+		ErrorHandler(c, http.StatusNotFound, "Blog not found")
+		return
+	}
+	h.reactionSummary(c, blogID, entity.TargetTypeBlog)
+}
+
+// GetCommentReactionsHandler returns the reaction breakdown of a comment.
+func (h *InteractionHandler) GetCommentReactionsHandler(c *gin.Context) {
+	commentID := c.Param("commentID")
+	if !h.likeUsecase.ExistsComment(c.Request.Context(), commentID) {
+		ErrorHandler(c, http.StatusNotFound, "Comment not found")
+		return
+	}
+	h.reactionSummary(c, commentID, entity.TargetTypeComment)
+}
+
+// ListBlogReactorsHandler lists the users who reacted to a blog.
+func (h *InteractionHandler) ListBlogReactorsHandler(c *gin.Context) {
+	blogID := c.Param("blogID")
+	if !h.likeUsecase.ExistsBlog(c.Request.Context(), blogID) {
+		ErrorHandler(c, http.StatusNotFound, "Blog not found")
+		return
+	}
+	h.listReactors(c, blogID, entity.TargetTypeBlog)
+}
+
+// ListCommentReactorsHandler lists the users who reacted to a comment.
+func (h *InteractionHandler) ListCommentReactorsHandler(c *gin.Context) {
+	commentID := c.Param("commentID")
+	if !h.likeUsecase.ExistsComment(c.Request.Context(), commentID) {
+		ErrorHandler(c, http.StatusNotFound, "Comment not found")
+		return
+	}
+	h.listReactors(c, commentID, entity.TargetTypeComment)
+}
+
+// ListMyReactionsHandler returns the current user's reaction history.
+func (h *InteractionHandler) ListMyReactionsHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		ErrorHandler(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	reactions, err := h.likeUsecase.ListUserReactions(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		ErrorHandler(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SuccessHandler(c, http.StatusOK, reactions)
+}
+
+func (h *InteractionHandler) listReactors(c *gin.Context, targetID string, targetType entity.TargetType) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	reactionType := entity.LikeType(strings.ToLower(strings.TrimSpace(c.Query("type"))))
+
+	reactors, err := h.likeUsecase.ListReactors(c.Request.Context(), targetID, targetType, reactionType, page, pageSize)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ErrorHandler(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		ErrorHandler(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SuccessHandler(c, http.StatusOK, reactors)
+}
+
+func (h *InteractionHandler) react(c *gin.Context, targetID string, targetType entity.TargetType) {
+	var req dto.ReactRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return
+	}
+	userID := c.GetString("userID")
+	if userID == "" {
+		ErrorHandler(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	reactionType := entity.LikeType(strings.ToLower(strings.TrimSpace(req.Type)))
+	if _, err := h.likeUsecase.React(c.Request.Context(), userID, targetID, targetType, reactionType); err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ErrorHandler(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		ErrorHandler(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.reactionSummary(c, targetID, targetType)
+}
+
+func (h *InteractionHandler) reactionSummary(c *gin.Context, targetID string, targetType entity.TargetType) {
+	summary, err := h.likeUsecase.GetReactionSummary(c.Request.Context(), targetID, targetType, c.GetString("userID"))
+	if err != nil {
+		ErrorHandler(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SuccessHandler(c, http.StatusOK, summary)
+}
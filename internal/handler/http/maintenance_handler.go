@@ -0,0 +1,47 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+// MaintenanceHandler exposes admin-only maintenance jobs.
+type MaintenanceHandler struct {
+	counterReconciler *usecase.CounterReconciler
+	blogUsecase       usecase.IBlogUseCase
+}
+
+func NewMaintenanceHandler(counterReconciler *usecase.CounterReconciler, blogUsecase usecase.IBlogUseCase) *MaintenanceHandler {
+	return &MaintenanceHandler{counterReconciler: counterReconciler, blogUsecase: blogUsecase}
+}
+
+// POST /api/v1/admin/counters/reconcile
+func (h *MaintenanceHandler) ReconcileCounters(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	report, err := h.counterReconciler.Reconcile(c.Request.Context(), dryRun)
+	if err != nil {
+		if errors.Is(err, usecase.ErrReconciliationRunning) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// POST /api/v1/admin/search/rebuild
+func (h *MaintenanceHandler) RebuildSearchIndex(c *gin.Context) {
+	if err := h.blogUsecase.RebuildSearchIndex(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "search index rebuilt"})
+}
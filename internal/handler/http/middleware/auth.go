@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
 )
@@ -35,3 +36,46 @@ func AuthMiddleWare(jwtService usecase.JWTService, userUseCase usecasecontract.I
 		ctx.Next()
 	}
 }
+
+// RequireRole only lets through requests whose authenticated user has one of the given roles.
+// It must run after AuthMiddleWare, which puts the role on the context.
+func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, exists := ctx.Get("userRole")
+		if !exists {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		var role entity.UserRole
+		switch r := value.(type) {
+		case entity.UserRole:
+			role = r
+		case string:
+			role = entity.UserRole(r)
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	}
+}
+
+// OptionalAuth identifies the user when a valid bearer token is present and otherwise lets
+// the request through anonymously, for public routes that personalise their response.
+func OptionalAuth(jwtService usecase.JWTService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parts := strings.Split(ctx.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+			if claims, err := jwtService.ParseAccessToken(parts[1]); err == nil {
+				ctx.Set("userID", claims.UserID)
+				ctx.Set("userRole", claims.Role)
+			}
+		}
+		ctx.Next()
+	}
+}
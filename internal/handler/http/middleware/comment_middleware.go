@@ -6,13 +6,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// ValidateEditTimeWindow validates that comments can only be edited within a certain time window
+// ValidateEditTimeWindow validates that comments can only be edited within a certain time window.
+// The comment is only loaded by the use case, so the window is handed to the handler here and
+// checked against the comment's creation time there. A non-positive window disables the limit.
 func ValidateEditTimeWindow(windowMinutes int) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// This middleware would typically check the comment's creation time
-		// against the current time to ensure it's within the edit window
-		// For now, we'll set a flag that the handler can use
-
 		c.Set("edit_window_minutes", windowMinutes)
 		c.Set("edit_deadline_check", true)
 		c.Next()
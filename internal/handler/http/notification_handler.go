@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+type NotificationHandler struct {
+	notificationUsecase *usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(notificationUsecase *usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// MarkNotificationsReadRequest lists the notifications to mark as read; empty marks all.
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids" binding:"omitempty,max=100"`
+}
+
+// GET /api/v1/notifications?unread=true&page=1&page_size=20
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	unreadOnly, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+
+	notifications, err := h.notificationUsecase.ListNotifications(c.Request.Context(), userID, unreadOnly, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": notifications})
+}
+
+// PUT /api/v1/notifications/read
+func (h *NotificationHandler) MarkNotificationsRead(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req MarkNotificationsReadRequest
+	// An empty body marks everything as read
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	updated, err := h.notificationUsecase.MarkNotificationsRead(c.Request.Context(), userID, req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"updated_count": updated}})
+}
+
+// GET /api/v1/users/autocomplete?q=ali&limit=10
+func (h *NotificationHandler) SuggestUsernames(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	suggestions, err := h.notificationUsecase.SuggestUsernames(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestions})
+}
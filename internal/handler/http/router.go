@@ -8,6 +8,7 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/middleware"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
@@ -15,28 +16,45 @@ import (
 )
 
 type Router struct {
-	userHandler        *UserHandler
-	blogHandler        *BlogHandler
-	emailHandler       *EmailHandler
-	interactionHandler *InteractionHandler
-	userUsecase        *usecase.UserUsecase
-	jwtService         usecase.JWTService
-	authHandler        *AuthHandler
-	commentHandler     *CommentHandler
+	userHandler         *UserHandler
+	blogHandler         *BlogHandler
+	emailHandler        *EmailHandler
+	interactionHandler  *InteractionHandler
+	userUsecase         *usecase.UserUsecase
+	jwtService          usecase.JWTService
+	authHandler         *AuthHandler
+	commentHandler      *CommentHandler
+	notificationHandler *NotificationHandler
+	maintenanceHandler  *MaintenanceHandler
+	trendingHandler     *TrendingHandler
+	analyticsHandler    *AnalyticsHandler
+	viewFraudHandler    *ViewFraudHandler
+	bookmarkHandler     *BookmarkHandler
+	followHandler       *FollowHandler
+	commentEditWindow   int // minutes
 }
 
-func NewRouter(userUsecase usecasecontract.IUserUseCase, blogUsecase usecase.IBlogUseCase, likeUsecase *usecase.LikeUsecase, emailVerUC usecasecontract.IEmailVerificationUC, userRepo contract.IUserRepository, tokenRepo contract.ITokenRepository, hasher contract.IHasher, jwtService usecase.JWTService, mailService contract.IEmailService, logger usecasecontract.IAppLogger, config usecasecontract.IConfigProvider, validator usecasecontract.IValidator, uuidGen contract.IUUIDGenerator, randomGen contract.IRandomGenerator, commentRepo contract.ICommentRepository, blogRepo contract.IBlogRepository) *Router {
+func NewRouter(userUsecase usecasecontract.IUserUseCase, blogUsecase usecase.IBlogUseCase, likeUsecase *usecase.LikeUsecase, emailVerUC usecasecontract.IEmailVerificationUC, userRepo contract.IUserRepository, tokenRepo contract.ITokenRepository, hasher contract.IHasher, jwtService usecase.JWTService, mailService contract.IEmailService, logger usecasecontract.IAppLogger, config usecasecontract.IConfigProvider, validator usecasecontract.IValidator, uuidGen contract.IUUIDGenerator, randomGen contract.IRandomGenerator, commentUC usecasecontract.ICommentUseCase, notificationUsecase *usecase.NotificationUsecase, counterReconciler *usecase.CounterReconciler, trendingUsecase *usecase.TrendingUsecase, analyticsUsecase *usecase.AnalyticsUsecase, viewFraudUsecase *usecase.ViewFraudUsecase, bookmarkUsecase *usecase.BookmarkUsecase, followUsecase *usecase.FollowUsecase, relatedUsecase *usecase.RelatedPostsUsecase) *Router {
 	baseURL := config.GetAppBaseURL()
-	commentUC := usecase.NewCommentUseCase(commentRepo, blogRepo, userRepo)
+	userHandler := NewUserHandler(userUsecase)
+	userHandler.SetFollowUsecase(followUsecase)
 	return &Router{
-		userHandler:        NewUserHandler(userUsecase),
-		blogHandler:        NewBlogHandler(blogUsecase),
-		emailHandler:       NewEmailHandler(emailVerUC, userRepo),
-		interactionHandler: NewInteractionHandler(likeUsecase),
-		userUsecase:        usecase.NewUserUsecase(userRepo, tokenRepo, emailVerUC, hasher, jwtService, mailService, logger, config, validator, uuidGen, randomGen),
-		jwtService:         jwtService,
-		authHandler:        NewAuthHandler(userUsecase, baseURL),
-		commentHandler:     NewCommentHandler(commentUC),
+		userHandler:         userHandler,
+		blogHandler:         NewBlogHandler(blogUsecase, bookmarkUsecase, relatedUsecase),
+		emailHandler:        NewEmailHandler(emailVerUC, userRepo),
+		interactionHandler:  NewInteractionHandler(likeUsecase),
+		userUsecase:         usecase.NewUserUsecase(userRepo, tokenRepo, emailVerUC, hasher, jwtService, mailService, logger, config, validator, uuidGen, randomGen),
+		jwtService:          jwtService,
+		authHandler:         NewAuthHandler(userUsecase, baseURL),
+		commentHandler:      NewCommentHandler(commentUC),
+		notificationHandler: NewNotificationHandler(notificationUsecase),
+		maintenanceHandler:  NewMaintenanceHandler(counterReconciler, blogUsecase),
+		trendingHandler:     NewTrendingHandler(trendingUsecase),
+		analyticsHandler:    NewAnalyticsHandler(analyticsUsecase),
+		viewFraudHandler:    NewViewFraudHandler(viewFraudUsecase),
+		bookmarkHandler:     NewBookmarkHandler(bookmarkUsecase),
+		followHandler:       NewFollowHandler(followUsecase),
+		commentEditWindow:   config.GetCommentEditWindowMinutes(),
 	}
 }
 
@@ -80,16 +98,22 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 	// Public user routes
 	users := v1.Group("/users")
 	{
-		users.GET("/profile/:id", r.userHandler.GetUser)
+		users.GET("/profile/:id", middleware.OptionalAuth(r.jwtService), r.userHandler.GetUser) // is_following for signed-in viewers
+		users.GET("/:userId/followers", r.followHandler.ListFollowers)
+		users.GET("/:userId/reading-lists", middleware.OptionalAuth(r.jwtService), r.bookmarkHandler.ListUserReadingLists) // Public lists; all lists for the owner
 	}
 
+	// Public reading list routes (private lists only for their owner)
+	v1.GET("/reading-lists/:listID", middleware.OptionalAuth(r.jwtService), r.bookmarkHandler.GetReadingList)
+
 	// Public blog routes
 	blogs := v1.Group("/blogs")
 	{
 		blogs.GET("", r.blogHandler.GetBlogsHandler)
-		blogs.GET("/search", r.blogHandler.SearchAndFilterBlogsHandler)
+		blogs.GET("/search", middleware.OptionalAuth(r.jwtService), r.blogHandler.SearchAndFilterBlogsHandler) // own drafts and status facet for authors
 		blogs.GET("/popular", r.blogHandler.GetPopularBlogsHandler)
-		blogs.GET("/slug/:slug", r.blogHandler.GetBlogDetailHandler)
+		blogs.GET("/trending", r.trendingHandler.GetTrendingBlogsHandler)                                   // ?window=24h|7d|30d
+		blogs.GET("/slug/:slug", middleware.OptionalAuth(r.jwtService), r.blogHandler.GetBlogDetailHandler) // is_bookmarked for signed-in readers
 	}
 
 	// Protected routes (authentication required)
@@ -99,6 +123,25 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 		// Current user routes
 		protected.GET("/me", r.userHandler.GetCurrentUser)
 		protected.PUT("/me", r.userHandler.UpdateUser)
+		protected.GET("/me/reactions", r.interactionHandler.ListMyReactionsHandler)
+		protected.GET("/me/analytics", r.analyticsHandler.GetMyAnalytics) // ?granularity=hour|day&from=&to=&blog_id=
+
+		// Bookmarks & reading lists
+		protected.POST("/blogs/:blogID/bookmark", r.bookmarkHandler.AddBookmark)      // Save a blog, optionally to a reading list
+		protected.DELETE("/blogs/:blogID/bookmark", r.bookmarkHandler.RemoveBookmark) // ?list_id= to remove from one list only
+		protected.GET("/me/bookmarks", r.bookmarkHandler.ListMyBookmarks)
+		protected.GET("/me/reading-lists", r.bookmarkHandler.ListMyReadingLists)
+		protected.POST("/me/reading-lists", r.bookmarkHandler.CreateReadingList)
+		protected.PUT("/reading-lists/:listID", r.bookmarkHandler.UpdateReadingList)
+		protected.DELETE("/reading-lists/:listID", r.bookmarkHandler.DeleteReadingList)
+
+		// Follows & personalized feed
+		protected.POST("/users/:userId/follow", r.followHandler.FollowAuthor)
+		protected.DELETE("/users/:userId/follow", r.followHandler.UnfollowAuthor)
+		protected.POST("/tags/:tag/follow", r.followHandler.FollowTag)
+		protected.DELETE("/tags/:tag/follow", r.followHandler.UnfollowTag)
+		protected.GET("/me/following", r.followHandler.ListMyFollowing) // ?type=author|tag
+		protected.GET("/feed", r.followHandler.GetFeed)                 // ?cursor=&limit=
 
 		// Blog routes
 		protected.POST("/blogs", r.blogHandler.CreateBlogHandler)
@@ -109,26 +152,64 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 		protected.POST("/blogs/:blogID/like", r.interactionHandler.LikeBlogHandler)
 		protected.POST("/blogs/:blogID/dislike", r.interactionHandler.DislikeBlogHandler)
 		protected.POST("/blogs/:blogID/view", r.blogHandler.TrackBlogViewHandler)
+		protected.GET("/blogs/:blogID/reactions", r.interactionHandler.GetBlogReactionsHandler)       // Reaction breakdown + your reaction
+		protected.POST("/blogs/:blogID/reactions", r.interactionHandler.ReactToBlogHandler)           // Set, switch or remove your reaction
+		protected.GET("/blogs/:blogID/reactions/users", r.interactionHandler.ListBlogReactorsHandler) // Who reacted, optionally ?type=
 
 		// Comment CRUD routes
 		protected.POST("/blogs/:blogID/comment", r.commentHandler.CreateComment)
 		protected.POST("/comments/:commentID/reply", r.commentHandler.CreateReply) // Create a reply to a comment
 		protected.GET("/blogs/:blogID/comments", r.commentHandler.GetBlogComments)
-		protected.GET("/blogs/:blogID/comments/count", r.commentHandler.GetBlogCommentsCount) // Total comments in a blog
-		protected.GET("/comments/:commentID", r.commentHandler.GetComment)                    // Single comment by ID
-		protected.GET("/comments/:commentID/replies", r.commentHandler.GetCommentReplies)     // Fetch all replies (nested) for a comment
-		protected.GET("/comments/:commentID/count", r.commentHandler.GetCommentStatistics)    // Fetch comment by ID with total reply count
-		protected.GET("/comments/:commentID/depth", r.commentHandler.GetCommentDepth)         // Depth of a comment thread
-		protected.PUT("/comments/:commentID", r.commentHandler.UpdateComment)
+		protected.GET("/blogs/:blogID/comments/count", r.commentHandler.GetBlogCommentsCount)       // Total comments in a blog
+		protected.GET("/blogs/:blogID/comments/blocklist", r.commentHandler.GetCommentBlocklist)    // Author's comment keyword blocklist
+		protected.PUT("/blogs/:blogID/comments/blocklist", r.commentHandler.UpdateCommentBlocklist) // Replace the blocklist
+		protected.PUT("/blogs/:blogID/comments/lock", r.commentHandler.SetCommentsLock)             // Lock or unlock new comments
+		protected.GET("/comments/search", r.commentHandler.SearchComments)                          // Full-text comment search
+		protected.GET("/comments/:commentID", r.commentHandler.GetComment)                          // Single comment by ID
+		protected.GET("/comments/:commentID/replies", r.commentHandler.GetCommentReplies)           // Fetch all replies (nested) for a comment
+		protected.GET("/comments/:commentID/count", r.commentHandler.GetCommentStatistics)          // Fetch comment by ID with total reply count
+		protected.GET("/comments/:commentID/depth", r.commentHandler.GetCommentDepth)               // Depth of a comment thread
+		protected.PUT("/comments/:commentID", middleware.ValidateEditTimeWindow(r.commentEditWindow), r.commentHandler.UpdateComment)
 		protected.DELETE("/comments/:commentID", r.commentHandler.DeleteComment)
 		protected.GET("/comments/:commentID/thread", r.commentHandler.GetCommentThread) // Fetch comment thread (all nested replies)
+		protected.POST("/comments/:commentID/pin", r.commentHandler.PinComment)         // Blog author pins a top-level comment
+		protected.DELETE("/comments/:commentID/pin", r.commentHandler.UnpinComment)
 
 		// Comment engagement & moderation
 		protected.POST("/comments/:commentID/like", r.commentHandler.LikeComment)
 		protected.POST("/comments/:commentID/unlike", r.commentHandler.UnlikeComment)
+		protected.POST("/comments/:commentID/dislike", r.interactionHandler.DislikeCommentHandler) // Toggle a dislike
+		protected.GET("/comments/:commentID/reactions", r.interactionHandler.GetCommentReactionsHandler)
+		protected.POST("/comments/:commentID/reactions", r.interactionHandler.ReactToCommentHandler)
+		protected.GET("/comments/:commentID/reactions/users", r.interactionHandler.ListCommentReactorsHandler)
 		protected.POST("/comments/:commentID/report", r.commentHandler.ReportComment)
-		protected.PUT("/comments/:commentID/status", r.commentHandler.UpdateCommentStatus)
 		protected.GET("/users/:userId/comments", r.commentHandler.GetUserComments)
+
+		// Mentions & notifications
+		protected.GET("/users/autocomplete", r.notificationHandler.SuggestUsernames) // Username suggestions for @mentions
+		protected.GET("/notifications", r.notificationHandler.ListNotifications)
+		protected.PUT("/notifications/read", r.notificationHandler.MarkNotificationsRead)
+	}
+
+	// Admin routes (authentication and admin role required)
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleWare(r.jwtService, r.userUsecase), middleware.RequireRole(entity.UserRoleAdmin))
+	{
+		// Comment moderation console
+		admin.GET("/comments/queue", r.commentHandler.GetModerationQueue)     // Pending/flagged/hidden comments
+		admin.GET("/comments/reported", r.commentHandler.GetReportedComments) // Reported comments grouped with counts
+		admin.GET("/comments/reports", r.commentHandler.GetCommentReports)    // Individual pending reports
+		admin.POST("/comments/bulk", r.commentHandler.BulkModerateComments)
+		admin.DELETE("/comments/bulk", r.commentHandler.BulkDeleteComments)
+		admin.PUT("/comments/:commentID/status", r.commentHandler.UpdateCommentStatus)
+		admin.PUT("/comments/reports/:reportID", r.commentHandler.ResolveReport)
+		admin.GET("/comments/:commentID/history", r.commentHandler.GetCommentEditHistory) // Full edit history
+		admin.GET("/moderation/audit", r.commentHandler.GetModerationAuditLog)
+		admin.POST("/counters/reconcile", r.maintenanceHandler.ReconcileCounters) // Recount and repair blog/comment counters (?dry_run=true to only report)
+		admin.POST("/search/rebuild", r.maintenanceHandler.RebuildSearchIndex)    // Reindex every blog in the search backend
+		admin.GET("/views/rules", r.viewFraudHandler.ListRules)                   // Active view fraud rules
+		admin.GET("/views/flagged", r.viewFraudHandler.ListFlaggedViews)          // Views caught by fraud rules, newest first
+		admin.GET("/views/offenders", r.viewFraudHandler.ListTopOffenders)        // Top offending IPs, users or fingerprints
 	}
 
 	// Logout route (no authentication required just accept the refresh token from the request body and invalidate the user session)
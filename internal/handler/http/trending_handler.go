@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+// TrendingHandler serves blogs ranked by recent, time-decayed activity.
+type TrendingHandler struct {
+	trendingUsecase *usecase.TrendingUsecase
+}
+
+func NewTrendingHandler(trendingUsecase *usecase.TrendingUsecase) *TrendingHandler {
+	return &TrendingHandler{trendingUsecase: trendingUsecase}
+}
+
+// GetTrendingBlogsHandler handles retrieval of trending blogs
+func (h *TrendingHandler) GetTrendingBlogsHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	window := strings.ToLower(strings.TrimSpace(c.DefaultQuery("window", "24h")))
+
+	blogs, total, current, pages, err := h.trendingUsecase.GetTrendingBlogs(c.Request.Context(), window, page, pageSize)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ErrorHandler(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		ErrorHandler(c, http.StatusInternalServerError, "Failed to get trending blogs")
+		return
+	}
+	resp := make([]dto.BlogResponse, 0, len(blogs))
+	for _, b := range blogs {
+		resp = append(resp, dto.ToBlogResponse(&b))
+	}
+	result := dto.PaginatedBlogResponse{Blogs: resp, TotalCount: total, CurrentPage: current, TotalPages: pages}
+	SuccessHandler(c, http.StatusOK, result)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/handler/http/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
 )
 
@@ -26,7 +27,8 @@ type UserHandlerInterface interface {
 var _ UserHandlerInterface = (*UserHandler)(nil)
 
 type UserHandler struct {
-	userUsecase usecasecontract.IUserUseCase
+	userUsecase   usecasecontract.IUserUseCase
+	followUsecase *usecase.FollowUsecase
 }
 
 func NewUserHandler(userUsecase usecasecontract.IUserUseCase) *UserHandler {
@@ -35,6 +37,11 @@ func NewUserHandler(userUsecase usecasecontract.IUserUseCase) *UserHandler {
 	}
 }
 
+// SetFollowUsecase enables follower and following counts on public profiles.
+func (h *UserHandler) SetFollowUsecase(followUsecase *usecase.FollowUsecase) {
+	h.followUsecase = followUsecase
+}
+
 // CreateUser handles user registration (signup)
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req dto.CreateUserRequest
@@ -82,7 +89,17 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		ErrorHandler(c, http.StatusNotFound, "User not found")
 		return
 	}
-	SuccessHandler(c, http.StatusOK, dto.ToUserResponse(*user))
+
+	resp := dto.ToUserResponse(*user)
+	if h.followUsecase != nil {
+		if stats, err := h.followUsecase.GetFollowStats(c.Request.Context(), user.ID, c.GetString("userID")); err == nil {
+			resp.FollowerCount = &stats.FollowerCount
+			resp.FollowingCount = &stats.FollowingCount
+			resp.FollowedTagCount = &stats.FollowedTagCount
+			resp.IsFollowing = stats.IsFollowing
+		}
+	}
+	SuccessHandler(c, http.StatusOK, resp)
 }
 
 // GetCurrentUser handles retrieving the current authenticated user
@@ -228,6 +245,9 @@ func updateUserRequestToMap(req dto.UpdateUserRequest) map[string]interface{} {
 	if req.AvatarURL != nil {
 		updates["avatarURL"] = *req.AvatarURL
 	}
+	if req.HideReactions != nil {
+		updates["hide_reactions"] = *req.HideReactions
+	}
 
 	return updates
 }
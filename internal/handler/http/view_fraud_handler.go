@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+)
+
+// ViewFraudHandler lets admins review views caught by the fraud rules.
+type ViewFraudHandler struct {
+	viewFraudUsecase *usecase.ViewFraudUsecase
+}
+
+func NewViewFraudHandler(viewFraudUsecase *usecase.ViewFraudUsecase) *ViewFraudHandler {
+	return &ViewFraudHandler{viewFraudUsecase: viewFraudUsecase}
+}
+
+// GET /api/v1/admin/views/rules
+func (h *ViewFraudHandler) ListRules(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.viewFraudUsecase.Rules()})
+}
+
+// GET /api/v1/admin/views/flagged?action=block&rule=ip_velocity&blog_id=&hours=24&page=1&page_size=20
+func (h *ViewFraudHandler) ListFlaggedViews(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	filter := contract.FlaggedViewFilter{
+		Action: entity.ViewFraudAction(c.Query("action")),
+		Rule:   c.Query("rule"),
+		BlogID: c.Query("blog_id"),
+	}
+	if hours, err := strconv.Atoi(c.Query("hours")); err == nil && hours > 0 {
+		filter.Since = time.Now().Add(-time.Duration(hours) * time.Hour)
+	}
+
+	views, err := h.viewFraudUsecase.ListFlaggedViews(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": views})
+}
+
+// GET /api/v1/admin/views/offenders?by=ip|user|fingerprint&hours=24&limit=20
+func (h *ViewFraudHandler) ListTopOffenders(c *gin.Context) {
+	hours, _ := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	offenders, err := h.viewFraudUsecase.TopOffenders(c.Request.Context(), c.DefaultQuery("by", "ip"), time.Duration(hours)*time.Hour, limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": offenders})
+}
+
+func (h *ViewFraudHandler) respondError(c *gin.Context, err error) {
+	if strings.HasPrefix(err.Error(), "invalid") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
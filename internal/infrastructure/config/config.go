@@ -1,9 +1,11 @@
 package config
 
 import (
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,33 @@ type Config struct {
 	RefreshTokenExpiry           time.Duration
 	PasswordResetTokenExpiry     time.Duration
 	EmailVerificationTokenExpiry time.Duration
+	CommentRejectWords           []string
+	CommentHoldWords             []string
+	CommentMaxLinks              int
+	CommentMaxRepeatedChars      int
+	CommentNewAccountHoldPeriod  time.Duration
+	CommentReportHideThreshold   float64
+	CommentEditWindowMinutes     int
+	BlogReactionTypes            []string
+	CommentReactionTypes         []string
+	CounterReconcileInterval     time.Duration
+	TrendingGravity              float64
+	TrendingRecalcInterval       time.Duration
+	AnalyticsRollupInterval      time.Duration
+	RelatedPostsInterval         time.Duration
+	EmbeddingProvider            string
+	EmbeddingAPIURL              string
+	EmbeddingAPIKey              string
+	EmbeddingModel               string
+	EmbeddingDimensions          int
+	EmbeddingIndexInterval       time.Duration
+	SearchKeywordWeight          float64
+	SearchMinSimilarity          float64
+	SearchVocabularyInterval     time.Duration
+	SearchBackend                string
+	ViewFlushInterval            time.Duration
+	ViewFraudRules               []entity.ViewFraudRule
+	ViewIPHashSecret             string
 }
 
 // NewConfig creates a new Config instance, loading values from environment variables.
@@ -24,6 +53,33 @@ func NewConfig() usecasecontract.IConfigProvider {
 		RefreshTokenExpiry:           time.Hour * time.Duration(getEnvAsInt("REFRESH_TOKEN_EXPIRY_HOURS", 168)), // 7 days
 		PasswordResetTokenExpiry:     time.Minute * time.Duration(getEnvAsInt("PASSWORD_RESET_TOKEN_EXPIRY_MINUTES", 15)),
 		EmailVerificationTokenExpiry: time.Minute * time.Duration(getEnvAsInt("EMAIL_VERIFICATION_TOKEN_EXPIRY_MINUTES", 60)),
+		CommentRejectWords:           getEnvAsList("COMMENT_REJECT_WORDS", nil),
+		CommentHoldWords:             getEnvAsList("COMMENT_HOLD_WORDS", nil),
+		CommentMaxLinks:              getEnvAsInt("COMMENT_MAX_LINKS", 2),
+		CommentMaxRepeatedChars:      getEnvAsInt("COMMENT_MAX_REPEATED_CHARS", 10),
+		CommentNewAccountHoldPeriod:  time.Hour * time.Duration(getEnvAsInt("COMMENT_NEW_ACCOUNT_HOLD_HOURS", 24)),
+		CommentReportHideThreshold:   getEnvAsFloat("COMMENT_REPORT_HIDE_THRESHOLD", 3),
+		CommentEditWindowMinutes:     getEnvAsInt("COMMENT_EDIT_WINDOW_MINUTES", 15),
+		BlogReactionTypes:            getEnvAsList("BLOG_REACTION_TYPES", nil),
+		CommentReactionTypes:         getEnvAsList("COMMENT_REACTION_TYPES", nil),
+		CounterReconcileInterval:     time.Minute * time.Duration(getEnvAsInt("COUNTER_RECONCILE_INTERVAL_MINUTES", 60)),
+		TrendingGravity:              getEnvAsFloat("TRENDING_GRAVITY", 1.8),
+		TrendingRecalcInterval:       time.Minute * time.Duration(getEnvAsInt("TRENDING_RECALC_INTERVAL_MINUTES", 15)),
+		AnalyticsRollupInterval:      time.Minute * time.Duration(getEnvAsInt("ANALYTICS_ROLLUP_INTERVAL_MINUTES", 15)),
+		RelatedPostsInterval:         time.Minute * time.Duration(getEnvAsInt("RELATED_POSTS_INTERVAL_MINUTES", 60)),
+		EmbeddingProvider:            getEnv("EMBEDDING_PROVIDER", ""),
+		EmbeddingAPIURL:              getEnv("EMBEDDING_API_URL", ""),
+		EmbeddingAPIKey:              getEnv("EMBEDDING_API_KEY", ""),
+		EmbeddingModel:               getEnv("EMBEDDING_MODEL", ""),
+		EmbeddingDimensions:          getEnvAsInt("EMBEDDING_DIMENSIONS", 256),
+		EmbeddingIndexInterval:       time.Minute * time.Duration(getEnvAsInt("EMBEDDING_INDEX_INTERVAL_MINUTES", 10)),
+		SearchKeywordWeight:          getEnvAsFloat("SEARCH_HYBRID_KEYWORD_WEIGHT", 0.4),
+		SearchMinSimilarity:          getEnvAsFloat("SEARCH_SEMANTIC_MIN_SIMILARITY", 0.2),
+		SearchVocabularyInterval:     time.Minute * time.Duration(getEnvAsInt("SEARCH_VOCABULARY_INTERVAL_MINUTES", 30)),
+		SearchBackend:                getEnv("SEARCH_BACKEND", "mongo"),
+		ViewFlushInterval:            time.Second * time.Duration(getEnvAsInt("VIEW_FLUSH_INTERVAL_SECONDS", 10)),
+		ViewFraudRules:               loadViewFraudRules(),
+		ViewIPHashSecret:             getEnv("VIEW_IP_HASH_SECRET", ""),
 	}
 }
 
@@ -52,6 +108,142 @@ func (c *Config) GetEmailVerificationTokenExpiry() time.Duration {
 	return c.EmailVerificationTokenExpiry
 }
 
+// GetCommentRejectWords returns the words that get a comment rejected by auto-moderation.
+func (c *Config) GetCommentRejectWords() []string {
+	return c.CommentRejectWords
+}
+
+// GetCommentHoldWords returns the words that hold a comment for manual review.
+func (c *Config) GetCommentHoldWords() []string {
+	return c.CommentHoldWords
+}
+
+// GetCommentMaxLinks returns how many links a comment may contain before it is held.
+func (c *Config) GetCommentMaxLinks() int {
+	return c.CommentMaxLinks
+}
+
+// GetCommentMaxRepeatedChars returns the longest allowed run of a single repeated character.
+func (c *Config) GetCommentMaxRepeatedChars() int {
+	return c.CommentMaxRepeatedChars
+}
+
+// GetCommentNewAccountHoldPeriod returns how long comments from new accounts are held for review.
+func (c *Config) GetCommentNewAccountHoldPeriod() time.Duration {
+	return c.CommentNewAccountHoldPeriod
+}
+
+// GetCommentReportHideThreshold returns the weighted report score at which a comment is hidden.
+func (c *Config) GetCommentReportHideThreshold() float64 {
+	return c.CommentReportHideThreshold
+}
+
+// GetCommentEditWindowMinutes returns how long after posting authors may edit a comment.
+func (c *Config) GetCommentEditWindowMinutes() int {
+	return c.CommentEditWindowMinutes
+}
+
+// GetBlogReactionTypes returns the reactions offered on blogs; empty means the default set.
+func (c *Config) GetBlogReactionTypes() []string {
+	return c.BlogReactionTypes
+}
+
+// GetCommentReactionTypes returns the reactions offered on comments; empty means the default set.
+func (c *Config) GetCommentReactionTypes() []string {
+	return c.CommentReactionTypes
+}
+
+// GetCounterReconcileInterval returns how often blog and comment counters are reconciled; zero disables it.
+func (c *Config) GetCounterReconcileInterval() time.Duration {
+	return c.CounterReconcileInterval
+}
+
+// GetTrendingGravity returns how quickly trending scores decay with a post's age.
+func (c *Config) GetTrendingGravity() float64 {
+	return c.TrendingGravity
+}
+
+// GetTrendingRecalcInterval returns how often trending scores are recalculated; zero disables it.
+func (c *Config) GetTrendingRecalcInterval() time.Duration {
+	return c.TrendingRecalcInterval
+}
+
+// GetAnalyticsRollupInterval returns how often blog activity is rolled up into analytics; zero disables it.
+func (c *Config) GetAnalyticsRollupInterval() time.Duration {
+	return c.AnalyticsRollupInterval
+}
+
+// GetRelatedPostsInterval returns how often related posts are recomputed; zero disables it.
+func (c *Config) GetRelatedPostsInterval() time.Duration {
+	return c.RelatedPostsInterval
+}
+
+// GetEmbeddingProvider returns which embedding provider powers semantic search: "local",
+// "http" or empty to disable it.
+func (c *Config) GetEmbeddingProvider() string {
+	return c.EmbeddingProvider
+}
+
+// GetEmbeddingAPIURL returns the embeddings endpoint of the http provider.
+func (c *Config) GetEmbeddingAPIURL() string {
+	return c.EmbeddingAPIURL
+}
+
+// GetEmbeddingAPIKey returns the bearer token sent to the embeddings endpoint.
+func (c *Config) GetEmbeddingAPIKey() string {
+	return c.EmbeddingAPIKey
+}
+
+// GetEmbeddingModel returns the model requested from the embeddings endpoint.
+func (c *Config) GetEmbeddingModel() string {
+	return c.EmbeddingModel
+}
+
+// GetEmbeddingDimensions returns the vector size of the local provider.
+func (c *Config) GetEmbeddingDimensions() int {
+	return c.EmbeddingDimensions
+}
+
+// GetEmbeddingIndexInterval returns how often new and edited blogs are embedded; zero only loads stored vectors at startup.
+func (c *Config) GetEmbeddingIndexInterval() time.Duration {
+	return c.EmbeddingIndexInterval
+}
+
+// GetSearchKeywordWeight returns the share of the keyword score in hybrid search ranking.
+func (c *Config) GetSearchKeywordWeight() float64 {
+	return c.SearchKeywordWeight
+}
+
+// GetSearchMinSimilarity returns the cosine similarity a blog needs to be a semantic match.
+func (c *Config) GetSearchMinSimilarity() float64 {
+	return c.SearchMinSimilarity
+}
+
+// GetSearchVocabularyInterval returns how often the typo-correction vocabulary is rebuilt; zero loads it once at startup.
+func (c *Config) GetSearchVocabularyInterval() time.Duration {
+	return c.SearchVocabularyInterval
+}
+
+// GetSearchBackend returns the keyword search backend: "mongo" (the text index) or "memory" (an in-process index).
+func (c *Config) GetSearchBackend() string {
+	return c.SearchBackend
+}
+
+// GetViewFlushInterval returns how often buffered blog views are written; zero writes every view directly.
+func (c *Config) GetViewFlushInterval() time.Duration {
+	return c.ViewFlushInterval
+}
+
+// GetViewFraudRules returns the view fraud rules with their configured actions and limits.
+func (c *Config) GetViewFraudRules() []entity.ViewFraudRule {
+	return c.ViewFraudRules
+}
+
+// GetViewIPHashSecret returns the key viewer IP addresses are hashed with before storage.
+func (c *Config) GetViewIPHashSecret() string {
+	return c.ViewIPHashSecret
+}
+
 // Helper function to get an environment variable or return a default value.
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
@@ -78,6 +270,30 @@ func getEnvAsBool(name string, fallback bool) bool {
 	return fallback
 }
 
+// Helper function to get an environment variable as a float or return a default value.
+func getEnvAsFloat(name string, fallback float64) float64 {
+	valStr := getEnv(name, "")
+	if val, err := strconv.ParseFloat(valStr, 64); err == nil {
+		return val
+	}
+	return fallback
+}
+
+// Helper function to get a comma-separated environment variable as a list or return a default value.
+func getEnvAsList(name string, fallback []string) []string {
+	valStr := getEnv(name, "")
+	if valStr == "" {
+		return fallback
+	}
+	var values []string
+	for _, v := range strings.Split(valStr, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func (c *Config) GetAIServiceAPIKey() string {
 	return getEnv("AI_SERVICE_API_KEY", "")
 }
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// defaultViewFraudRules are the fraud rules and their defaults. Bots are dropped and
+// velocity abuse is blocked, as before the rules were configurable; the weaker signals
+// only flag views for review.
+func defaultViewFraudRules() []entity.ViewFraudRule {
+	return []entity.ViewFraudRule{
+		{
+			Name:   entity.ViewFraudRuleBotUserAgent,
+			Action: entity.ViewFraudActionDrop,
+			Values: []string{"bot", "spider", "crawl", "slurp", "curl", "wget", "python-requests", "httpclient", "feedfetcher", "mediapartners-google"},
+		},
+		{Name: entity.ViewFraudRuleMissingUserAgent, Action: entity.ViewFraudActionFlag},
+		{Name: entity.ViewFraudRuleUnknownUserAgent, Action: entity.ViewFraudActionFlag},
+		{Name: entity.ViewFraudRuleHeaderFingerprint, Action: entity.ViewFraudActionFlag, Values: []string{"accept", "accept-language"}},
+		{Name: entity.ViewFraudRuleDatacenterIP, Action: entity.ViewFraudActionFlag},
+		{Name: entity.ViewFraudRuleIPVelocity, Action: entity.ViewFraudActionBlock, Limit: 10, Window: 5 * time.Minute},
+		{Name: entity.ViewFraudRuleUserIPRotation, Action: entity.ViewFraudActionBlock, Limit: 5, Window: time.Hour},
+	}
+}
+
+// loadViewFraudRules applies VIEW_FRAUD_<RULE>_ACTION, _LIMIT, _WINDOW_MINUTES and _VALUES
+// overrides to the default rules. Datacenter ranges are also read, one per line, from the
+// file named by VIEW_FRAUD_DATACENTER_IP_RANGES_FILE.
+func loadViewFraudRules() []entity.ViewFraudRule {
+	rules := defaultViewFraudRules()
+	for i := range rules {
+		rule := &rules[i]
+		prefix := "VIEW_FRAUD_" + strings.ToUpper(rule.Name) + "_"
+
+		switch action := entity.ViewFraudAction(strings.ToLower(getEnv(prefix+"ACTION", ""))); action {
+		case entity.ViewFraudActionFlag, entity.ViewFraudActionDrop, entity.ViewFraudActionBlock, entity.ViewFraudActionOff:
+			rule.Action = action
+		}
+		rule.Limit = getEnvAsInt(prefix+"LIMIT", rule.Limit)
+		rule.Window = time.Minute * time.Duration(getEnvAsInt(prefix+"WINDOW_MINUTES", int(rule.Window/time.Minute)))
+		rule.Values = getEnvAsList(prefix+"VALUES", rule.Values)
+
+		if rule.Name == entity.ViewFraudRuleDatacenterIP {
+			if path := getEnv("VIEW_FRAUD_DATACENTER_IP_RANGES_FILE", ""); path != "" {
+				if data, err := os.ReadFile(path); err == nil {
+					rule.Values = append(rule.Values, strings.Split(string(data), "\n")...)
+				}
+			}
+		}
+	}
+	return rules
+}
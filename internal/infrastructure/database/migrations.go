@@ -0,0 +1,247 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// runMigrations applies idempotent data migrations. Each one only touches documents that
+// have not been migrated yet, so running them on every start-up is cheap.
+func runMigrations(ctx context.Context, db *mongo.Database) error {
+	if err := backfillCommentPaths(ctx, db.Collection("comments")); err != nil {
+		return fmt.Errorf("failed to backfill comment paths: %w", err)
+	}
+	if err := migrateCommentLikes(ctx, db); err != nil {
+		return fmt.Errorf("failed to migrate comment likes: %w", err)
+	}
+	if err := scrubRawViewerData(ctx, db); err != nil {
+		return fmt.Errorf("failed to scrub raw viewer data: %w", err)
+	}
+	return nil
+}
+
+// backfillCommentPaths sets root_id, path and depth on comments created before materialized
+// paths existed. Top-level comments are done first, then replies one level at a time, each
+// level copying its parent's path.
+func backfillCommentPaths(ctx context.Context, comments *mongo.Collection) error {
+	missing := bson.M{"root_id": bson.M{"$exists": false}}
+
+	// Top-level comments are their own root
+	topLevel := bson.M{"root_id": bson.M{"$exists": false}, "parent_id": nil}
+	res, err := comments.UpdateMany(ctx, topLevel, mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.M{"root_id": "$_id", "path": bson.A{}, "depth": 0}}},
+	})
+	if err != nil {
+		return err
+	}
+	migrated := res.ModifiedCount
+
+	for {
+		cursor, err := comments.Find(ctx, missing, options.Find().SetProjection(bson.M{"_id": 1, "parent_id": 1}))
+		if err != nil {
+			return err
+		}
+		var pending []struct {
+			ID       string `bson:"_id"`
+			ParentID string `bson:"parent_id"`
+		}
+		if err := cursor.All(ctx, &pending); err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		parentIDs := make([]string, 0, len(pending))
+		for _, c := range pending {
+			parentIDs = append(parentIDs, c.ParentID)
+		}
+		cursor, err = comments.Find(ctx,
+			bson.M{"_id": bson.M{"$in": parentIDs}, "root_id": bson.M{"$exists": true}},
+			options.Find().SetProjection(bson.M{"_id": 1, "root_id": 1, "path": 1}),
+		)
+		if err != nil {
+			return err
+		}
+		var parents []struct {
+			ID     string   `bson:"_id"`
+			RootID string   `bson:"root_id"`
+			Path   []string `bson:"path"`
+		}
+		if err := cursor.All(ctx, &parents); err != nil {
+			return err
+		}
+		byID := make(map[string]int, len(parents))
+		for i, p := range parents {
+			byID[p.ID] = i
+		}
+
+		var models []mongo.WriteModel
+		for _, c := range pending {
+			i, ok := byID[c.ParentID]
+			if !ok {
+				continue // parent not migrated yet (or gone); picked up on a later pass
+			}
+			path := append(append([]string{}, parents[i].Path...), parents[i].ID)
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": c.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"root_id": parents[i].RootID, "path": path, "depth": len(path)}}))
+		}
+		// No progress means the rest are orphans whose parent no longer exists
+		if len(models) == 0 {
+			log.Printf("comment path backfill: %d comments left without a resolvable parent", len(pending))
+			break
+		}
+
+		res, err := comments.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return err
+		}
+		migrated += res.ModifiedCount
+	}
+
+	if migrated > 0 {
+		log.Printf("comment path backfill: migrated %d comments", migrated)
+	}
+	return nil
+}
+
+// migrateCommentLikes moves likes from the legacy comment_likes collection into the shared
+// reactions collection (blog_likes, target_type "comment"), recomputes the counters of the
+// affected comments and removes the migrated documents. An empty comment_likes is a no-op.
+func migrateCommentLikes(ctx context.Context, db *mongo.Database) error {
+	legacy := db.Collection("comment_likes")
+	reactions := db.Collection("blog_likes")
+	comments := db.Collection("comments")
+
+	cursor, err := legacy.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	var likes []struct {
+		ID        string    `bson:"_id"`
+		CommentID string    `bson:"comment_id"`
+		UserID    string    `bson:"user_id"`
+		CreatedAt time.Time `bson:"created_at"`
+	}
+	if err := cursor.All(ctx, &likes); err != nil {
+		return err
+	}
+	if len(likes) == 0 {
+		return nil
+	}
+
+	// Upsert so an existing reaction by the same user (e.g. a dislike) wins over the legacy like
+	models := make([]mongo.WriteModel, 0, len(likes))
+	commentIDs := make([]string, 0, len(likes))
+	seen := make(map[string]struct{}, len(likes))
+	for _, like := range likes {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": like.UserID, "target_id": like.CommentID, "target_type": "comment"}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{
+				"_id":        like.ID,
+				"type":       "like",
+				"is_deleted": false,
+				"created_at": like.CreatedAt,
+				"updated_at": like.CreatedAt,
+			}}).
+			SetUpsert(true))
+		if _, ok := seen[like.CommentID]; !ok {
+			seen[like.CommentID] = struct{}{}
+			commentIDs = append(commentIDs, like.CommentID)
+		}
+	}
+	if _, err := reactions.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return err
+	}
+
+	if err := recountCommentReactions(ctx, reactions, comments, commentIDs); err != nil {
+		return err
+	}
+
+	ids := make([]string, len(likes))
+	for i, like := range likes {
+		ids[i] = like.ID
+	}
+	if _, err := legacy.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return err
+	}
+
+	log.Printf("comment like migration: moved %d likes on %d comments", len(likes), len(commentIDs))
+	return nil
+}
+
+// recountCommentReactions sets like_count and dislike_count on the given comments from
+// their active reaction records.
+func recountCommentReactions(ctx context.Context, reactions, comments *mongo.Collection, commentIDs []string) error {
+	cursor, err := reactions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_type": "comment", "target_id": bson.M{"$in": commentIDs}, "is_deleted": false}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$target_id",
+			"likes":    bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "like"}}, 1, 0}}},
+			"dislikes": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "dislike"}}, 1, 0}}},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	var counts []struct {
+		CommentID string `bson:"_id"`
+		Likes     int64  `bson:"likes"`
+		Dislikes  int64  `bson:"dislikes"`
+	}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return err
+	}
+	byID := make(map[string]int, len(counts))
+	for i, c := range counts {
+		byID[c.CommentID] = i
+	}
+
+	models := make([]mongo.WriteModel, 0, len(commentIDs))
+	for _, id := range commentIDs {
+		var likes, dislikes int64
+		if i, ok := byID[id]; ok {
+			likes, dislikes = counts[i].Likes, counts[i].Dislikes
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"like_count":      likes,
+				"dislike_count":   dislikes,
+				"reaction_counts": bson.M{"like": likes, "dislike": dislikes},
+			}}))
+	}
+	_, err = comments.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// scrubRawViewerData removes the raw IP addresses and user agents stored with views and
+// flagged views before they were replaced by hashes and browser families. The views keep
+// counting; they just can no longer be deduplicated against, which only matters for the day
+// they have left before expiring.
+func scrubRawViewerData(ctx context.Context, db *mongo.Database) error {
+	fields := bson.M{"ip_address": "", "user_agent": ""}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"ip_address": bson.M{"$exists": true}},
+		bson.M{"user_agent": bson.M{"$exists": true}},
+	}}
+	var scrubbed int64
+	for _, name := range []string{"blog_views", "flagged_views"} {
+		res, err := db.Collection(name).UpdateMany(ctx, filter, bson.M{"$unset": fields})
+		if err != nil {
+			return err
+		}
+		scrubbed += res.ModifiedCount
+	}
+	if scrubbed > 0 {
+		log.Printf("viewer data scrub: removed raw IP addresses and user agents from %d documents", scrubbed)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -33,87 +34,191 @@ func NewMongoDBClient(uri string) (*MongoDBClient, error) {
 		return nil, err
 	}
 
-	// Create indexes
+	// Create indexes; the server cannot run safely without the required ones
 	if err := createIndexes(ctx, client.Database(os.Getenv("MONGODB_DB_NAME"))); err != nil {
-		log.Println("Failed to create indexes:", err)
-		// We can choose to return the error or just log it
+		log.Println("Failed to create required indexes:", err)
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to create required indexes: %w", err)
+	}
+
+	// Bring existing documents up to the current schema
+	if err := runMigrations(ctx, client.Database(os.Getenv("MONGODB_DB_NAME"))); err != nil {
+		log.Println("Failed to run migrations:", err)
 	}
 
 	return &MongoDBClient{Client: client}, nil
 }
 
-func createIndexes(ctx context.Context, db *mongo.Database) error {
-	// TTL index for blog_views
-	blogViewsCollection := db.Collection("blog_views")
-	ttlIndex := mongo.IndexModel{
-		Keys:    bson.M{"viewed_at": 1},
-		Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60), // 24 hours
-	}
-	_, err := blogViewsCollection.Indexes().CreateOne(ctx, ttlIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create TTL index for blog_views: %w", err)
-	}
+// indexSpec is one collection's indexes. Required indexes enforce uniqueness or back a query
+// that fails without them, such as $text search; the rest only speed queries up or expire
+// old documents, so the server can run without them for a while.
+type indexSpec struct {
+	collection  string
+	description string
+	required    bool
+	models      []mongo.IndexModel
+}
 
+var indexSpecs = []indexSpec{
+	// TTL index for blog_views
+	{collection: "blog_views", description: "view TTL", models: []mongo.IndexModel{
+		{Keys: bson.M{"viewed_at": 1}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)}, // 24 hours
+	}},
 	// Unique index for user email
-	usersCollection := db.Collection("users")
-	emailIndex := mongo.IndexModel{
-		Keys:    bson.M{"email": 1},
-		Options: options.Index().SetUnique(true),
-	}
-	_, err = usersCollection.Indexes().CreateOne(ctx, emailIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create unique index for users email: %w", err)
-	}
-
-	// Compound index for blogs: author_id + created_at (for author timeline queries)
-	blogsCollection := db.Collection("blogs")
-	authorCreatedIndex := mongo.IndexModel{
-		Keys: bson.D{{Key: "author_id", Value: 1}},
-	}
-	_, err = blogsCollection.Indexes().CreateOne(ctx, authorCreatedIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create compound index for blogs: %w", err)
-	}
-
+	{collection: "users", description: "unique email", required: true, models: []mongo.IndexModel{
+		{Keys: bson.M{"email": 1}, Options: options.Index().SetUnique(true)},
+	}},
+	// Unique index for blogs.slug (for fast lookup by slug)
+	{collection: "blogs", description: "unique slug", required: true, models: []mongo.IndexModel{
+		{Keys: bson.M{"slug": 1}, Options: options.Index().SetUnique(true)},
+	}},
 	// Text index for blogs: title and content (for search)
-	blogTextIndex := mongo.IndexModel{
-		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
-	}
-	_, err = blogsCollection.Indexes().CreateOne(ctx, blogTextIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create text index for blogs: %w", err)
-	}
-
-	// Index for blogs._id (for fast lookup by blog id)
-	blogIDIndex := mongo.IndexModel{
-		Keys: bson.M{"_id": 1},
-	}
-	_, err = blogsCollection.Indexes().CreateOne(ctx, blogIDIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create index for blogs._id: %w", err)
-	}
-
-	// Index for blogs.slug (for fast lookup by slug)
-	slugIndex := mongo.IndexModel{
-		Keys:    bson.M{"slug": 1},
-		Options: options.Index().SetUnique(true),
-	}
-	_, err = blogsCollection.Indexes().CreateOne(ctx, slugIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create index for blogs.slug: %w", err)
-	}
-
+	{collection: "blogs", description: "text search", required: true, models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}}},
+	}},
+	// Blog lookups by author and by id
+	{collection: "blogs", description: "lookup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "author_id", Value: 1}}},
+		{Keys: bson.M{"_id": 1}},
+	}},
 	// Index for blog_tags.blog_id and blog_tags.tag_id (for tag lookups)
-	blogTagsCollection := db.Collection("blog_tags")
-	blogTagIndex := mongo.IndexModel{
-		Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "tag_id", Value: 1}},
-	}
-	_, err = blogTagsCollection.Indexes().CreateOne(ctx, blogTagIndex)
-	if err != nil {
-		return fmt.Errorf("failed to create index for blog_tags: %w", err)
-	}
+	{collection: "blog_tags", description: "tag lookup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "tag_id", Value: 1}}},
+	}},
+	// Text index for comments: content and author name (for comment search)
+	{collection: "comments", description: "text search", required: true, models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "content", Value: "text"}, {Key: "author_name", Value: "text"}}},
+	}},
+	// Index for comment_reports by comment and reporter (report threshold checks)
+	{collection: "comment_reports", description: "report lookup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "reporter_id", Value: 1}}},
+	}},
+	// Index for comment_edits by comment (edit history lookups)
+	{collection: "comment_edits", description: "edit history", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "edited_at", Value: 1}}},
+	}},
+	// Multikey index on the materialized path so a whole thread loads in one query
+	{collection: "comments", description: "thread path", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "path", Value: 1}, {Key: "created_at", Value: 1}}},
+	}},
+	// Indexes backing comment listings (newest and top sorting, keyset pagination)
+	{collection: "comments", description: "listing", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "like_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+	}},
+	// Index for notifications by recipient (inbox listing, newest first)
+	{collection: "notifications", description: "inbox", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "recipient_user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	// Reactions on blogs and comments: one per user and target, which CreateReaction's upsert relies on
+	{collection: "blog_likes", description: "unique reaction", required: true, models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "target_id", Value: 1}, {Key: "target_type", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}},
+	// Per-target reaction counts
+	{collection: "blog_likes", description: "reaction counts", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "type", Value: 1}, {Key: "is_deleted", Value: 1}}},
+	}},
+	// Hourly view totals for trending, kept a little longer than the longest trending window
+	{collection: "blog_view_stats", description: "TTL", models: []mongo.IndexModel{
+		{Keys: bson.M{"hour": 1}, Options: options.Index().SetExpireAfterSeconds(31 * 24 * 60 * 60)},
+	}},
+	// Indexes for trending: recent reactions and comments, and ranking by each window's score
+	{collection: "blog_likes", description: "recency", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "updated_at", Value: -1}}},
+	}},
+	{collection: "comments", description: "recency", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	}},
+	{collection: "blogs", description: "trending", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "trending_scores.24h", Value: -1}}},
+		{Keys: bson.D{{Key: "trending_scores.7d", Value: -1}}},
+		{Keys: bson.D{{Key: "trending_scores.30d", Value: -1}}},
+	}},
+	// Applied view batch IDs only need to outlive the retries of a failed flush
+	{collection: "blog_view_batches", description: "TTL", models: []mongo.IndexModel{
+		{Keys: bson.M{"applied_at": 1}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)},
+	}},
+	// Analytics rollups: per-author range queries, hourly buckets expire after 90 days
+	{collection: "blog_analytics_hourly", description: "rollup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "start", Value: 1}}},
+		{Keys: bson.M{"start": 1}, Options: options.Index().SetExpireAfterSeconds(90 * 24 * 60 * 60)},
+	}},
+	{collection: "blog_analytics_daily", description: "rollup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "start", Value: 1}}},
+	}},
+	{collection: "blog_analytics_viewers", description: "TTL", models: []mongo.IndexModel{
+		{Keys: bson.M{"day": 1}, Options: options.Index().SetExpireAfterSeconds(3 * 24 * 60 * 60)},
+	}},
+	// Flagged views: admin listings and offender rankings, kept for 30 days
+	{collection: "flagged_views", description: "review", models: []mongo.IndexModel{
+		{Keys: bson.M{"created_at": 1}, Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60)},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "rules", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	// Index for blog_views by blog and IP hash (recent-view deduplication)
+	{collection: "blog_views", description: "deduplication", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "ip_hash", Value: 1}}},
+	}},
+	// Bookmarks: one per user, blog and list, which AddBookmark's upsert relies on
+	{collection: "bookmarks", description: "unique bookmark", required: true, models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "blog_id", Value: 1}, {Key: "list_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}},
+	// Bookmark listings newest first, and reading lists by owner
+	{collection: "bookmarks", description: "listing", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "list_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "list_id", Value: 1}}},
+	}},
+	{collection: "reading_lists", description: "listing", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}}},
+	}},
+	// Follows: one per follower and target
+	{collection: "follows", description: "unique follow", required: true, models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "follower_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}},
+	// Follower listings and counts by target
+	{collection: "follows", description: "listing", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "follower_id", Value: 1}, {Key: "target_type", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	// Feed candidates: recent published posts by author or tag
+	{collection: "blogs", description: "feed", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "published_at", Value: -1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}, {Key: "published_at", Value: -1}}},
+	}},
+	// Co-read pairs not read together for 180 days are dropped
+	{collection: "blog_coreads", description: "TTL and ranking", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "updated_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(180 * 24 * 60 * 60)},
+		{Keys: bson.D{{Key: "count", Value: 1}}},
+	}},
+	// Blog embeddings are loaded per model
+	{collection: "blog_embeddings", description: "model lookup", models: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "model", Value: 1}}},
+	}},
+}
 
-	log.Println("Successfully created database indexes.")
+// createIndexes creates every index on its own, so one failure does not keep the rest from
+// being created. Failed optional indexes are logged; it returns an error naming every
+// required index that could not be created.
+func createIndexes(ctx context.Context, db *mongo.Database) error {
+	var missing []error
+	for _, spec := range indexSpecs {
+		for _, model := range spec.models {
+			if _, err := db.Collection(spec.collection).Indexes().CreateOne(ctx, model); err != nil {
+				err = fmt.Errorf("failed to create %s index on %s %v: %w", spec.description, spec.collection, model.Keys, err)
+				if spec.required {
+					missing = append(missing, err)
+				} else {
+					log.Println(err)
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Join(missing...)
+	}
+
+	log.Println("Created database indexes.")
 	return nil
 }
 
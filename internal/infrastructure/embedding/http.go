@@ -0,0 +1,102 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+)
+
+// -------------- embeddings req & res dtos --------------
+// The wire format is the widely supported OpenAI-style embeddings API.
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// -------------- end of dto -------------------
+
+// HTTPEmbeddingProvider calls a remote embeddings endpoint.
+type HTTPEmbeddingProvider struct {
+	url    string
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+var _ usecasecontract.IEmbeddingProvider = (*HTTPEmbeddingProvider)(nil)
+
+// NewHTTPEmbeddingProvider creates a provider posting to url with the given model. The API
+// key, when set, is sent as a bearer token.
+func NewHTTPEmbeddingProvider(url, apiKey, model string) *HTTPEmbeddingProvider {
+	return &HTTPEmbeddingProvider{
+		url:    url,
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Model returns the configured model name.
+func (p *HTTPEmbeddingProvider) Model() string {
+	return p.model
+}
+
+// Embed sends the texts in one request and returns the vectors in input order.
+func (p *HTTPEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	payload, err := json.Marshal(embeddingRequest{Model: p.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embeddings payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embeddings endpoint returned status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var response embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range response.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has out-of-range index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("embeddings response is missing input %d", i)
+		}
+	}
+	return vectors, nil
+}
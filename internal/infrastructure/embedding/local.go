@@ -0,0 +1,87 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// defaultLocalDimensions is used when no positive dimension count is configured.
+const defaultLocalDimensions = 256
+
+// LocalEmbeddingProvider is a deterministic embedding computed in-process by hashing words
+// and their character trigrams into a fixed number of dimensions. It needs no network and
+// always returns the same vector for the same text, which makes it suitable for tests and
+// development; trigrams let inflected forms ("index", "indexing") land close together.
+type LocalEmbeddingProvider struct {
+	dimensions int
+}
+
+var _ usecasecontract.IEmbeddingProvider = (*LocalEmbeddingProvider)(nil)
+
+// NewLocalEmbeddingProvider creates a LocalEmbeddingProvider with the given vector size.
+func NewLocalEmbeddingProvider(dimensions int) *LocalEmbeddingProvider {
+	if dimensions <= 0 {
+		dimensions = defaultLocalDimensions
+	}
+	return &LocalEmbeddingProvider{dimensions: dimensions}
+}
+
+// Model identifies the hashing scheme and size, so changing the size re-embeds every blog.
+func (p *LocalEmbeddingProvider) Model() string {
+	return fmt.Sprintf("local-hash-%d", p.dimensions)
+}
+
+// Embed hashes each text into an L2-normalized vector.
+func (p *LocalEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = p.embed(text)
+	}
+	return vectors, nil
+}
+
+func (p *LocalEmbeddingProvider) embed(text string) []float32 {
+	vector := make([]float64, p.dimensions)
+	for _, token := range utils.Tokenize(text) {
+		p.add(vector, "w:"+token, 1)
+		padded := []rune("^" + token + "$")
+		for i := 0; i+3 <= len(padded); i++ {
+			p.add(vector, "t:"+string(padded[i:i+3]), 0.5)
+		}
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	out := make([]float32, p.dimensions)
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vector {
+		out[i] = float32(v / norm)
+	}
+	return out
+}
+
+// add hashes a feature to a dimension and a sign, so unrelated features cancel out on
+// average instead of piling up.
+func (p *LocalEmbeddingProvider) add(vector []float64, feature string, weight float64) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	idx := int(sum % uint64(p.dimensions))
+	if sum&(1<<63) != 0 {
+		weight = -weight
+	}
+	vector[idx] += weight
+}
@@ -0,0 +1,82 @@
+package embedding_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func embedOne(t *testing.T, p *embedding.LocalEmbeddingProvider, text string) []float32 {
+	t.Helper()
+	vectors, err := p.Embed(context.Background(), []string{text})
+	require.NoError(t, err)
+	require.Len(t, vectors, 1)
+	return vectors[0]
+}
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestLocalEmbeddingProvider_Deterministic(t *testing.T) {
+	texts := []string{"Indexing blog posts", "Graceful shutdown in Go", "ünïcödé wörds"}
+	first, err := embedding.NewLocalEmbeddingProvider(64).Embed(context.Background(), texts)
+	require.NoError(t, err)
+	second, err := embedding.NewLocalEmbeddingProvider(64).Embed(context.Background(), texts)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "a new provider returns the same vectors")
+
+	for i, text := range texts {
+		assert.Equal(t, first[i], embedOne(t, embedding.NewLocalEmbeddingProvider(64), text), "batching does not change a vector")
+	}
+}
+
+func TestLocalEmbeddingProvider_Normalized(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimensions int
+		text       string
+		wantLen    int
+		wantNorm   float64
+	}{
+		{name: "short text", dimensions: 32, text: "search", wantLen: 32, wantNorm: 1},
+		{name: "long text", dimensions: 128, text: "Semantic search ranks blogs by meaning rather than by exact words", wantLen: 128, wantNorm: 1},
+		{name: "repeated words", dimensions: 16, text: "go go go go go go", wantLen: 16, wantNorm: 1},
+		{name: "default size", dimensions: 0, text: "search", wantLen: 256, wantNorm: 1},
+		{name: "empty text is the zero vector", dimensions: 32, text: "", wantLen: 32, wantNorm: 0},
+		{name: "punctuation only is the zero vector", dimensions: 32, text: "?!, ...", wantLen: 32, wantNorm: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := embedOne(t, embedding.NewLocalEmbeddingProvider(tt.dimensions), tt.text)
+			assert.Len(t, v, tt.wantLen)
+			assert.InDelta(t, tt.wantNorm, math.Sqrt(cosine(v, v)), 1e-5)
+		})
+	}
+}
+
+func TestLocalEmbeddingProvider_Model(t *testing.T) {
+	assert.Equal(t, "local-hash-64", embedding.NewLocalEmbeddingProvider(64).Model())
+	assert.Equal(t, "local-hash-256", embedding.NewLocalEmbeddingProvider(-1).Model())
+}
+
+func TestLocalEmbeddingProvider_RelatedWordsAreCloser(t *testing.T) {
+	p := embedding.NewLocalEmbeddingProvider(256)
+	index := embedOne(t, p, "index")
+	assert.Greater(t, cosine(index, embedOne(t, p, "indexing")), cosine(index, embedOne(t, p, "banana")))
+}
+
+func TestLocalEmbeddingProvider_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := embedding.NewLocalEmbeddingProvider(32).Embed(ctx, []string{"search"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,277 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// rollupStateID is the analytics_state document that tracks rollup progress.
+const rollupStateID = "blog_analytics_rollup"
+
+// AnalyticsRepository is the MongoDB implementation of IAnalyticsRepository.
+type AnalyticsRepository struct {
+	views     *mongo.Collection
+	reactions *mongo.Collection
+	comments  *mongo.Collection
+	hourly    *mongo.Collection
+	daily     *mongo.Collection
+	viewers   *mongo.Collection
+	state     *mongo.Collection
+}
+
+var _ contract.IAnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// NewAnalyticsRepository creates and returns a new AnalyticsRepository instance.
+func NewAnalyticsRepository(db *mongo.Database) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		views:     db.Collection("blog_views"),
+		reactions: db.Collection("blog_likes"),
+		comments:  db.Collection("comments"),
+		hourly:    db.Collection("blog_analytics_hourly"),
+		daily:     db.Collection("blog_analytics_daily"),
+		viewers:   db.Collection("blog_analytics_viewers"),
+		state:     db.Collection("analytics_state"),
+	}
+}
+
+// GroupViews returns the views recorded in [from, to), grouped per blog, viewer, referrer,
+// user agent family and network, so only one row per distinct combination is sent back.
+func (r *AnalyticsRepository) GroupViews(ctx context.Context, from, to time.Time) ([]contract.ViewGroup, error) {
+	cursor, err := r.views.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"viewed_at": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"blog_id":           "$blog_id",
+				"user_id":           "$user_id",
+				"ip_hash":           "$ip_hash",
+				"referrer":          "$referrer",
+				"user_agent_family": "$user_agent_family",
+				"ip_prefix":         "$ip_prefix",
+			},
+			"views": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to group blog views: %w", err)
+	}
+	var rows []struct {
+		ID struct {
+			BlogID          string `bson:"blog_id"`
+			UserID          string `bson:"user_id"`
+			IPHash          string `bson:"ip_hash"`
+			Referrer        string `bson:"referrer"`
+			UserAgentFamily string `bson:"user_agent_family"`
+			IPPrefix        string `bson:"ip_prefix"`
+		} `bson:"_id"`
+		Views int64 `bson:"views"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode blog view groups: %w", err)
+	}
+	groups := make([]contract.ViewGroup, len(rows))
+	for i, row := range rows {
+		groups[i] = contract.ViewGroup{
+			BlogID:          row.ID.BlogID,
+			UserID:          row.ID.UserID,
+			IPHash:          row.ID.IPHash,
+			Referrer:        row.ID.Referrer,
+			UserAgentFamily: row.ID.UserAgentFamily,
+			IPPrefix:        row.ID.IPPrefix,
+			Views:           row.Views,
+		}
+	}
+	return groups, nil
+}
+
+// CountReactions returns the blog reactions made or changed in [from, to), per blog and type.
+func (r *AnalyticsRepository) CountReactions(ctx context.Context, from, to time.Time) (map[string]map[string]int64, error) {
+	cursor, err := r.reactions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"target_type": entity.TargetTypeBlog,
+			"is_deleted":  false,
+			"updated_at":  bson.M{"$gte": from, "$lt": to},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"blog_id": "$target_id", "type": "$type"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	var rows []struct {
+		ID struct {
+			BlogID string `bson:"blog_id"`
+			Type   string `bson:"type"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode reaction counts: %w", err)
+	}
+
+	counts := make(map[string]map[string]int64)
+	for _, row := range rows {
+		if counts[row.ID.BlogID] == nil {
+			counts[row.ID.BlogID] = make(map[string]int64)
+		}
+		counts[row.ID.BlogID][row.ID.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// CountComments returns the approved comments posted in [from, to), per blog.
+func (r *AnalyticsRepository) CountComments(ctx context.Context, from, to time.Time) (map[string]int64, error) {
+	cursor, err := r.comments.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"created_at": bson.M{"$gte": from, "$lt": to},
+			"is_deleted": false,
+			"status":     entity.CommentStatusApproved,
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$blog_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+	var rows []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode comment counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+	return counts, nil
+}
+
+// AddDailyViewers stores one document per blog, day and viewer; duplicates are ignored.
+func (r *AnalyticsRepository) AddDailyViewers(ctx context.Context, day time.Time, viewers map[string][]string) error {
+	dayKey := day.Format("2006-01-02")
+	var models []mongo.WriteModel
+	for blogID, keys := range viewers {
+		for _, key := range keys {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": blogID + "|" + dayKey + "|" + key}).
+				SetUpdate(bson.M{"$setOnInsert": bson.M{"blog_id": blogID, "day": day}}).
+				SetUpsert(true))
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := r.viewers.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record daily viewers: %w", err)
+	}
+	return nil
+}
+
+// CountDailyViewers returns the number of distinct viewers of each blog on a day.
+func (r *AnalyticsRepository) CountDailyViewers(ctx context.Context, day time.Time, blogIDs []string) (map[string]int64, error) {
+	cursor, err := r.viewers.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": day, "blog_id": bson.M{"$in": blogIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$blog_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count daily viewers: %w", err)
+	}
+	var rows []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode daily viewer counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+	return counts, nil
+}
+
+// SaveBuckets replaces the given rollup buckets, so re-running a rollup is harmless.
+func (r *AnalyticsRepository) SaveBuckets(ctx context.Context, granularity entity.AnalyticsGranularity, buckets []*entity.BlogAnalyticsBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, len(buckets))
+	for i, b := range buckets {
+		b.ID = b.BlogID + "|" + b.Start.UTC().Format(time.RFC3339)
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": b.ID}).
+			SetReplacement(b).
+			SetUpsert(true)
+	}
+	if _, err := r.bucketCollection(granularity).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to save %s analytics: %w", granularity, err)
+	}
+	return nil
+}
+
+// ListBuckets returns the buckets matching the filter, ordered by blog and start time.
+func (r *AnalyticsRepository) ListBuckets(ctx context.Context, granularity entity.AnalyticsGranularity, filter contract.AnalyticsBucketFilter) ([]*entity.BlogAnalyticsBucket, error) {
+	query := bson.M{
+		"author_id": filter.AuthorID,
+		"start":     bson.M{"$gte": filter.From, "$lt": filter.To},
+	}
+	if filter.BlogID != "" {
+		query["blog_id"] = filter.BlogID
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "blog_id", Value: 1}, {Key: "start", Value: 1}})
+
+	cursor, err := r.bucketCollection(granularity).Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s analytics: %w", granularity, err)
+	}
+	var buckets []*entity.BlogAnalyticsBucket
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to decode %s analytics: %w", granularity, err)
+	}
+	return buckets, nil
+}
+
+// GetRollupWatermark returns the start of the last hour rolled up, or the zero time.
+func (r *AnalyticsRepository) GetRollupWatermark(ctx context.Context) (time.Time, error) {
+	var state struct {
+		LastHour time.Time `bson:"last_hour"`
+	}
+	err := r.state.FindOne(ctx, bson.M{"_id": rollupStateID}).Decode(&state)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to read rollup watermark: %w", err)
+	}
+	return state.LastHour, nil
+}
+
+// SetRollupWatermark records the start of the last hour rolled up.
+func (r *AnalyticsRepository) SetRollupWatermark(ctx context.Context, hour time.Time) error {
+	_, err := r.state.UpdateByID(ctx, rollupStateID,
+		bson.M{"$set": bson.M{"last_hour": hour}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store rollup watermark: %w", err)
+	}
+	return nil
+}
+
+func (r *AnalyticsRepository) bucketCollection(granularity entity.AnalyticsGranularity) *mongo.Collection {
+	if granularity == entity.AnalyticsGranularityDay {
+		return r.daily
+	}
+	return r.hourly
+}
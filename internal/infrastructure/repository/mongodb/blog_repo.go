@@ -20,6 +20,8 @@ type BlogRepository struct {
 	usersCollection     *mongo.Collection // For accessing user data for search
 	blogViewsCollection *mongo.Collection // For tracking blog views
 	blogTagsCollection  *mongo.Collection
+	viewStatsCollection *mongo.Collection // Hourly view totals per blog, used for trending
+	viewBatchCollection *mongo.Collection // IDs of applied view batches, so a retried batch is skipped
 }
 
 // NewBlogRepository creates and returns a new BlogRepository instance.
@@ -29,6 +31,8 @@ func NewBlogRepository(db *mongo.Database, user *mongo.Collection) *BlogReposito
 		blogTagsCollection:  db.Collection("blog_tags"),
 		usersCollection:     user,
 		blogViewsCollection: db.Collection("blog_views"),
+		viewStatsCollection: db.Collection("blog_view_stats"),
+		viewBatchCollection: db.Collection("blog_view_batches"),
 	}
 }
 
@@ -47,6 +51,16 @@ func buildBlogFilterAndSort(opts *contract.BlogFilterOptions) (bson.M, *sortStag
 		filter["author_id"] = *opts.AuthorID
 	}
 
+	// Restrict to specific blogs
+	if len(opts.IDs) > 0 {
+		filter["_id"] = bson.M{"$in": opts.IDs}
+	}
+
+	// Filter by status
+	if len(opts.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": opts.Statuses}
+	}
+
 	// Filter by tags
 	if len(opts.TagIDs) > 0 {
 		filter["tags"] = bson.M{"$in": opts.TagIDs}
@@ -144,6 +158,65 @@ func (r *BlogRepository) GetBlogByID(ctx context.Context, blogID string) (*entit
 	return &blog, nil
 }
 
+// GetBlogsByIDs retrieves the non-deleted blog posts among the given ids.
+func (r *BlogRepository) GetBlogsByIDs(ctx context.Context, blogIDs []string) ([]*entity.Blog, error) {
+	blogs := []*entity.Blog{}
+	if len(blogIDs) == 0 {
+		return blogs, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": blogIDs}, "is_deleted": false})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve blog posts: %w", err)
+	}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, fmt.Errorf("failed to decode blog posts: %w", err)
+	}
+	return blogs, nil
+}
+
+// GetFeedBlogs retrieves recent published blogs by the given authors or with the given tags,
+// continuing after the given position.
+func (r *BlogRepository) GetFeedBlogs(ctx context.Context, query contract.FeedBlogsQuery) ([]*entity.Blog, error) {
+	blogs := []*entity.Blog{}
+	var or []bson.M
+	if len(query.AuthorIDs) > 0 {
+		or = append(or, bson.M{"author_id": bson.M{"$in": query.AuthorIDs}})
+	}
+	if len(query.Tags) > 0 {
+		or = append(or, bson.M{"tags": bson.M{"$in": query.Tags}})
+	}
+	if len(or) == 0 || query.Limit < 1 {
+		return blogs, nil
+	}
+
+	filter := bson.M{
+		"status":       entity.BlogStatusPublished,
+		"is_deleted":   false,
+		"published_at": bson.M{"$gte": query.From, "$lte": query.To},
+		"$or":          or,
+	}
+	if query.ExcludeAuthorID != "" {
+		filter["author_id"] = bson.M{"$ne": query.ExcludeAuthorID}
+	}
+	if query.After != nil {
+		filter["$and"] = bson.A{bson.M{"$or": bson.A{
+			bson.M{"published_at": bson.M{"$lt": query.After.PublishedAt}},
+			bson.M{"published_at": query.After.PublishedAt, "_id": bson.M{"$gt": query.After.ID}},
+		}}}
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(query.Limit))
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve feed blogs: %w", err)
+	}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, fmt.Errorf("failed to decode feed blogs: %w", err)
+	}
+	return blogs, nil
+}
+
 // GetBlogBySlug retrieves a single blog post by its unique slug.
 func (r *BlogRepository) GetBlogBySlug(ctx context.Context, slug string) (*entity.Blog, error) {
 	var blog entity.Blog
@@ -251,18 +324,13 @@ func (r *BlogRepository) DeleteBlog(ctx context.Context, blogID string) error {
 	return nil
 }
 
-// SearchBlogs searches for blog posts based on a query (title, author name, or author ID) and applies filter options.
-func (r *BlogRepository) SearchBlogs(ctx context.Context, query string, filterOptions *contract.BlogFilterOptions) ([]*entity.Blog, int64, error) {
-	// Build filter from options, but add the text search part
+// FilterBlogIDs returns the IDs of every blog matching the filters, in the requested order.
+// Only the IDs are read, so it is cheap enough to run over every match of a search.
+func (r *BlogRepository) FilterBlogIDs(ctx context.Context, filterOptions *contract.BlogFilterOptions) ([]string, error) {
 	filter, sortStage := buildBlogFilterAndSort(filterOptions)
-	filter["$text"] = bson.M{"$search": query}
-
-	// Create the aggregation pipeline
 	pipeline := mongo.Pipeline{
 		bson.D{{Key: "$match", Value: filter}},
 	}
-
-	// Apply conditional stages for author details
 	if strings.HasPrefix(sortStage.sortKey, "authorDetails.") {
 		pipeline = append(pipeline,
 			bson.D{{Key: "$lookup", Value: bson.M{
@@ -271,37 +339,60 @@ func (r *BlogRepository) SearchBlogs(ctx context.Context, query string, filterOp
 				"foreignField": "_id",
 				"as":           "authorDetails",
 			}}},
-			bson.D{{Key: "$unwind", Value: "$authorDetails"}},
+			bson.D{{Key: "$unwind", Value: bson.M{
+				"path":                       "$authorDetails",
+				"preserveNullAndEmptyArrays": true,
+			}}},
 		)
 	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: sortStage.sortOrder}},
+		bson.D{{Key: "$project", Value: bson.M{"_id": 1}}},
+	)
 
-	// First, get the total count for all matching documents
-	totalCount, err := r.collection.CountDocuments(ctx, filter)
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get total search count: %w", err)
+		return nil, fmt.Errorf("failed to filter blogs: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	// Apply sorting, skipping, and limiting to the pipeline
-	if sortStage.sortKey != "" {
-		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortStage.sortOrder}})
+	var rows []struct {
+		ID string `bson:"_id"`
 	}
-	skip := int64((filterOptions.Page - 1) * filterOptions.PageSize)
-	limit := int64(filterOptions.PageSize)
-	pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
-	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode blog ids: %w", err)
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids, nil
+}
 
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+// ListSearchableBlogs returns the ID, title and content of every blog in one of the
+// statuses, or of every blog when none are given.
+func (r *BlogRepository) ListSearchableBlogs(ctx context.Context, statuses ...entity.BlogStatus) ([]*entity.Blog, error) {
+	filter := bson.M{"is_deleted": false}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+	findOptions := options.Find().SetProjection(bson.M{"title": 1, "content": 1})
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to retrieve search results: %w", err)
+		return nil, fmt.Errorf("failed to list searchable blogs: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	var blogs []*entity.Blog
+	blogs := []*entity.Blog{}
 	if err := cursor.All(ctx, &blogs); err != nil {
-		return nil, 0, fmt.Errorf("failed to decode search results: %w", err)
+		return nil, fmt.Errorf("failed to decode searchable blogs: %w", err)
 	}
+	return blogs, nil
+}
 
-	return blogs, totalCount, nil
+// GetBlogFacets counts the blogs matching the filters per tag, author, publication month
+// and, optionally, status.
+func (r *BlogRepository) GetBlogFacets(ctx context.Context, filterOptions *contract.BlogFilterOptions, includeStatus bool) (*contract.BlogFacets, error) {
+	filter, _ := buildBlogFilterAndSort(filterOptions)
+	return aggregateBlogFacets(ctx, r.collection, filter, includeStatus)
 }
 
 // IncrementViewCount increments the view count of a specific blog post.
@@ -384,37 +475,21 @@ func (r *BlogRepository) IncrementDislikeCount(ctx context.Context, blogID strin
 // 	return nil
 // }
 
-// IncrementCommentCount increments the comment count of a specific blog post.
-// func (r *BlogRepository) IncrementCommentCount(ctx context.Context, blogID string) error {
-// 	filter := bson.M{"_id": blogID, "is_deleted": false}
-// 	update := bson.M{"$inc": bson.M{"comment_count": 1}}
-
-// 	res, err := r.collection.UpdateOne(ctx, filter, update)
-// 	if err != nil {
-// 		return fmt.Errorf("failed to increment comment count: %w", err)
-// 	}
-// 	if res.ModifiedCount == 0 {
-// 		return errors.New("blog post not found")
-// 	}
-
-// 	return nil
-// }
-
-// DecrementCommentCount decrements the comment count of a specific blog post.
-// func (r *BlogRepository) DecrementCommentCount(ctx context.Context, blogID string) error {
-// 	filter := bson.M{"_id": blogID, "is_deleted": false}
-// 	update := bson.M{"$inc": bson.M{"comment_count": -1}}
+// IncrementCommentCount atomically adds delta to a blog post's comment count.
+func (r *BlogRepository) IncrementCommentCount(ctx context.Context, blogID string, delta int) error {
+	filter := bson.M{"_id": blogID}
+	update := bson.M{"$inc": bson.M{"comment_count": delta}}
 
-// 	res, err := r.collection.UpdateOne(ctx, filter, update)
-// 	if err != nil {
-// 		return fmt.Errorf("failed to decrement comment count: %w", err)
-// 	}
-// 	if res.ModifiedCount == 0 {
-// 		return errors.New("blog post not found")
-// 	}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update comment count: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("blog post not found")
+	}
 
-// 	return nil
-// }
+	return nil
+}
 
 // GetBlogCounts returns the current counts for a blog post.
 func (r *BlogRepository) GetBlogCounts(ctx context.Context, blogID string) (viewCount, likeCount, dislikeCount, commentCount int, err error) {
@@ -522,33 +597,30 @@ func (r *BlogRepository) GetBlogsByTagIDs(ctx context.Context, tagIDs []string,
 	return r.GetBlogs(ctx, filterOpts)
 }
 
-// HasViewedRecently checks if a user (by user ID or IP address) has viewed a blog within the last 24 hours.
-func (r *BlogRepository) HasViewedRecently(ctx context.Context, blogID, userID, ipAddress string) (bool, error) {
-	filter := bson.M{
-		"blog_id": blogID,
-		"$or": []bson.M{
-			{"ip_address": ipAddress},
-		},
+// HasViewedRecently checks if a user (by user ID or IP hash) has viewed a blog within the last 24 hours.
+func (r *BlogRepository) HasViewedRecently(ctx context.Context, blogID, userID string, ipHashes []string) (bool, error) {
+	var or []bson.M
+	if len(ipHashes) > 0 {
+		or = append(or, bson.M{"ip_hash": bson.M{"$in": ipHashes}})
 	}
 	if userID != "" {
-		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{"user_id": userID})
+		or = append(or, bson.M{"user_id": userID})
+	}
+	if len(or) == 0 {
+		return false, nil
 	}
 
-	count, err := r.blogViewsCollection.CountDocuments(ctx, filter)
+	count, err := r.blogViewsCollection.CountDocuments(ctx, bson.M{"blog_id": blogID, "$or": or})
 	if err != nil {
 		return false, fmt.Errorf("failed to check for recent blog view: %w", err)
 	}
 	return count > 0, nil
 }
 
-// RecordView records a user's view of a blog, including IP address and user agent.
-func (r *BlogRepository) RecordView(ctx context.Context, blogID, userID, ipAddress, userAgent string) error {
-	view := entity.BlogView{
-		BlogID:    blogID,
-		UserID:    userID,
-		IPAddress: ipAddress,
-		UserAgent: userAgent,
-		ViewedAt:  time.Now(),
+// RecordView records a user's view of a blog.
+func (r *BlogRepository) RecordView(ctx context.Context, view *entity.BlogView) error {
+	if view.ViewedAt.IsZero() {
+		view.ViewedAt = time.Now()
 	}
 	_, err := r.blogViewsCollection.InsertOne(ctx, view)
 	if err != nil {
@@ -557,11 +629,56 @@ func (r *BlogRepository) RecordView(ctx context.Context, blogID, userID, ipAddre
 	return nil
 }
 
-// GetRecentViewsByIP retrieves recent views from a specific IP address.
-func (r *BlogRepository) GetRecentViewsByIP(ctx context.Context, ipAddress string, since time.Time) ([]entity.BlogView, error) {
+// ApplyViewCounts adds a batch of views to the blogs' view counts and to the hourly totals
+// used for trending, with one bulk write per collection. Run it in a unit of work so that
+// the writes commit together. The batch ID is inserted first: a batch already applied is
+// skipped, and one being applied concurrently fails on the duplicate ID.
+func (r *BlogRepository) ApplyViewCounts(ctx context.Context, batchID string, counts map[string]int64, at time.Time) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	if batchID != "" {
+		applied, err := r.viewBatchCollection.CountDocuments(ctx, bson.M{"_id": batchID})
+		if err != nil {
+			return fmt.Errorf("failed to check view batch: %w", err)
+		}
+		if applied > 0 {
+			return nil
+		}
+		if _, err := r.viewBatchCollection.InsertOne(ctx, bson.M{"_id": batchID, "applied_at": at}); err != nil {
+			return fmt.Errorf("failed to record view batch: %w", err)
+		}
+	}
+
+	// Individual views expire after a day, so keep hourly totals for the longer trending windows
+	hour := at.UTC().Truncate(time.Hour)
+	blogModels := make([]mongo.WriteModel, 0, len(counts))
+	statModels := make([]mongo.WriteModel, 0, len(counts))
+	for blogID, n := range counts {
+		blogModels = append(blogModels, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": blogID, "is_deleted": false}).
+			SetUpdate(bson.M{"$inc": bson.M{"view_count": n}}))
+		statModels = append(statModels, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": blogID + "|" + hour.Format(time.RFC3339)}).
+			SetUpdate(bson.M{"$inc": bson.M{"views": n}, "$setOnInsert": bson.M{"blog_id": blogID, "hour": hour}}).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, blogModels, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to apply view counts: %w", err)
+	}
+	if _, err := r.viewStatsCollection.BulkWrite(ctx, statModels, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record hourly blog views: %w", err)
+	}
+	return nil
+}
+
+// GetRecentViewsByIPHash retrieves recent views from the IP address with the given hash.
+func (r *BlogRepository) GetRecentViewsByIPHash(ctx context.Context, ipHash string, since time.Time) ([]entity.BlogView, error) {
 	filter := bson.M{
-		"ip_address": ipAddress,
-		"viewed_at":  bson.M{"$gte": since},
+		"ip_hash":   ipHash,
+		"viewed_at": bson.M{"$gte": since},
 	}
 
 	cursor, err := r.blogViewsCollection.Find(ctx, filter)
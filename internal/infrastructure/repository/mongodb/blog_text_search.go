@@ -0,0 +1,182 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BlogTextSearch searches blogs with the MongoDB text index on title and content. Mongo keeps
+// the index up to date on every write, so Index, Remove and Rebuild have nothing to do.
+type BlogTextSearch struct {
+	collection *mongo.Collection
+}
+
+// NewBlogTextSearch creates a BlogTextSearch over the blogs collection.
+func NewBlogTextSearch(db *mongo.Database) *BlogTextSearch {
+	return &BlogTextSearch{collection: db.Collection("blogs")}
+}
+
+var _ contract.IBlogSearchIndex = (*BlogTextSearch)(nil)
+
+// Search searches for blog posts with the text index and applies filter options. Each
+// match carries its text score; SortBy "relevance" orders by it, best first.
+func (s *BlogTextSearch) Search(ctx context.Context, query string, filterOptions *contract.BlogFilterOptions, includeStatus bool) (*contract.BlogSearchPage, error) {
+	// Build filter from options, but add the text search part
+	filter, sortStage := buildBlogFilterAndSort(filterOptions)
+	filter["$text"] = bson.M{"$search": query}
+
+	// Create the aggregation pipeline
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$addFields", Value: bson.M{"text_score": bson.M{"$meta": "textScore"}}}},
+	}
+
+	// Apply conditional stages for author details
+	if strings.HasPrefix(sortStage.sortKey, "authorDetails.") {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from":         "users",
+				"localField":   "author_id",
+				"foreignField": "_id",
+				"as":           "authorDetails",
+			}}},
+			bson.D{{Key: "$unwind", Value: "$authorDetails"}},
+		)
+	}
+
+	// First, get the total count for all matching documents
+	totalCount, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total search count: %w", err)
+	}
+
+	// Apply sorting, skipping, and limiting to the pipeline
+	if filterOptions.SortBy == "relevance" {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "text_score", Value: -1}, {Key: "_id", Value: 1}}}})
+	} else if sortStage.sortKey != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortStage.sortOrder}})
+	}
+	skip := int64((filterOptions.Page - 1) * filterOptions.PageSize)
+	limit := int64(filterOptions.PageSize)
+	pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+
+	scored, err := s.aggregateScored(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := aggregateBlogFacets(ctx, s.collection, filter, includeStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &contract.BlogSearchPage{Blogs: scored, Total: totalCount, Facets: facets}, nil
+}
+
+// SearchRanked returns the best keyword matches by Mongo text score.
+func (s *BlogTextSearch) SearchRanked(ctx context.Context, query string, filterOptions *contract.BlogFilterOptions, limit int) ([]contract.ScoredBlog, error) {
+	filter, _ := buildBlogFilterAndSort(filterOptions)
+	filter["$text"] = bson.M{"$search": query}
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$addFields", Value: bson.M{"text_score": bson.M{"$meta": "textScore"}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "text_score", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: int64(limit)}},
+	}
+	return s.aggregateScored(ctx, pipeline)
+}
+
+// Index is a no-op; the text index is maintained by MongoDB.
+func (s *BlogTextSearch) Index(ctx context.Context, blog *entity.Blog) error {
+	return nil
+}
+
+// Remove is a no-op; the text index is maintained by MongoDB.
+func (s *BlogTextSearch) Remove(ctx context.Context, blogID string) error {
+	return nil
+}
+
+// Rebuild is a no-op; the text index is maintained by MongoDB.
+func (s *BlogTextSearch) Rebuild(ctx context.Context) error {
+	return nil
+}
+
+// aggregateScored runs a search pipeline that sets text_score and decodes its matches.
+func (s *BlogTextSearch) aggregateScored(ctx context.Context, pipeline mongo.Pipeline) ([]contract.ScoredBlog, error) {
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve search results: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		entity.Blog `bson:",inline"`
+		TextScore   float64 `bson:"text_score"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode search results: %w", err)
+	}
+	scored := make([]contract.ScoredBlog, len(results))
+	for i := range results {
+		scored[i] = contract.ScoredBlog{Blog: &results[i].Blog, Score: results[i].TextScore}
+	}
+	return scored, nil
+}
+
+// aggregateBlogFacets counts the blogs matching filter per tag, author, publication month
+// and, optionally, status in a single aggregation.
+func aggregateBlogFacets(ctx context.Context, collection *mongo.Collection, filter bson.M, includeStatus bool) (*contract.BlogFacets, error) {
+	countBy := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": utils.MaxFacetValues},
+		}
+	}
+	facets := bson.M{
+		"tags":    append(bson.A{bson.M{"$unwind": "$tags"}}, countBy("$tags")...),
+		"authors": countBy("$author_id"),
+		"months": bson.A{
+			bson.M{"$match": bson.M{"published_at": bson.M{"$type": "date"}}},
+			bson.M{"$group": bson.M{
+				"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$published_at"}},
+				"count": bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": bson.M{"_id": -1}},
+			bson.M{"$limit": utils.MaxFacetMonths},
+		},
+	}
+	if includeStatus {
+		facets["statuses"] = countBy("$status")
+	}
+
+	// Only the faceted fields are carried into $facet, not the blog bodies
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$project", Value: bson.M{"tags": 1, "author_id": 1, "published_at": 1, "status": 1}}},
+		bson.D{{Key: "$facet", Value: facets}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	result := &contract.BlogFacets{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(result); err != nil {
+			return nil, fmt.Errorf("failed to decode search facets: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BookmarkRepository is the MongoDB implementation of IBookmarkRepository.
+type BookmarkRepository struct {
+	bookmarks    *mongo.Collection
+	readingLists *mongo.Collection
+}
+
+var _ contract.IBookmarkRepository = (*BookmarkRepository)(nil)
+
+// NewBookmarkRepository creates and returns a new BookmarkRepository instance.
+func NewBookmarkRepository(db *mongo.Database) *BookmarkRepository {
+	return &BookmarkRepository{
+		bookmarks:    db.Collection("bookmarks"),
+		readingLists: db.Collection("reading_lists"),
+	}
+}
+
+// CreateReadingList stores a new reading list.
+func (r *BookmarkRepository) CreateReadingList(ctx context.Context, list *entity.ReadingList) error {
+	if list.ID == "" {
+		list.ID = uuidgen.NewGenerator().NewUUID()
+	}
+	if _, err := r.readingLists.InsertOne(ctx, list); err != nil {
+		return fmt.Errorf("failed to create reading list: %w", err)
+	}
+	return nil
+}
+
+// GetReadingList retrieves a reading list by ID.
+func (r *BookmarkRepository) GetReadingList(ctx context.Context, listID string) (*entity.ReadingList, error) {
+	var list entity.ReadingList
+	if err := r.readingLists.FindOne(ctx, bson.M{"_id": listID}).Decode(&list); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("reading list with id '%s' not found: %w", listID, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve reading list: %w", err)
+	}
+	return &list, nil
+}
+
+// ListReadingLists returns a user's reading lists sorted by name.
+func (r *BookmarkRepository) ListReadingLists(ctx context.Context, userID string, publicOnly bool) ([]*entity.ReadingList, error) {
+	query := bson.M{"user_id": userID}
+	if publicOnly {
+		query["visibility"] = entity.ReadingListPublic
+	}
+	cursor, err := r.readingLists.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reading lists: %w", err)
+	}
+	lists := []*entity.ReadingList{}
+	if err := cursor.All(ctx, &lists); err != nil {
+		return nil, fmt.Errorf("failed to decode reading lists: %w", err)
+	}
+	return lists, nil
+}
+
+// UpdateReadingList applies field updates to a reading list.
+func (r *BookmarkRepository) UpdateReadingList(ctx context.Context, listID string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	res, err := r.readingLists.UpdateOne(ctx, bson.M{"_id": listID}, bson.M{"$set": updates})
+	if err != nil {
+		return fmt.Errorf("failed to update reading list: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("reading list with id '%s' not found", listID)
+	}
+	return nil
+}
+
+// DeleteReadingList removes a reading list and its bookmarks.
+func (r *BookmarkRepository) DeleteReadingList(ctx context.Context, listID string) error {
+	if _, err := r.bookmarks.DeleteMany(ctx, bson.M{"list_id": listID}); err != nil {
+		return fmt.Errorf("failed to delete reading list bookmarks: %w", err)
+	}
+	if _, err := r.readingLists.DeleteOne(ctx, bson.M{"_id": listID}); err != nil {
+		return fmt.Errorf("failed to delete reading list: %w", err)
+	}
+	return nil
+}
+
+// CountBookmarksByList returns the number of bookmarks in each list.
+func (r *BookmarkRepository) CountBookmarksByList(ctx context.Context, listIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(listIDs))
+	if len(listIDs) == 0 {
+		return counts, nil
+	}
+	cursor, err := r.bookmarks.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"list_id": bson.M{"$in": listIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$list_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookmarks: %w", err)
+	}
+	var rows []struct {
+		ListID string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode bookmark counts: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.ListID] = row.Count
+	}
+	return counts, nil
+}
+
+// AddBookmark upserts on (user, blog, list) so saving twice keeps the first bookmark, whose
+// ID and creation time are copied back into bookmark.
+func (r *BookmarkRepository) AddBookmark(ctx context.Context, bookmark *entity.Bookmark) (bool, error) {
+	if bookmark.ID == "" {
+		bookmark.ID = uuidgen.NewGenerator().NewUUID()
+	}
+	if bookmark.CreatedAt.IsZero() {
+		bookmark.CreatedAt = time.Now()
+	}
+	var stored entity.Bookmark
+	err := r.bookmarks.FindOneAndUpdate(ctx,
+		bson.M{"user_id": bookmark.UserID, "blog_id": bookmark.BlogID, "list_id": bookmark.ListID},
+		bson.M{"$setOnInsert": bson.M{"_id": bookmark.ID, "created_at": bookmark.CreatedAt}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err != nil {
+		return false, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+	created := stored.ID == bookmark.ID
+	bookmark.ID, bookmark.CreatedAt = stored.ID, stored.CreatedAt
+	return created, nil
+}
+
+// RemoveBookmark deletes the user's bookmarks of a blog in one list or in all of them.
+func (r *BookmarkRepository) RemoveBookmark(ctx context.Context, userID, blogID string, listID *string) (int64, error) {
+	query := bson.M{"user_id": userID, "blog_id": blogID}
+	if listID != nil {
+		query["list_id"] = *listID
+	}
+	res, err := r.bookmarks.DeleteMany(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	return res.DeletedCount, nil
+}
+
+// ListBookmarks returns a user's bookmarks of blogs the viewer can see, newest first. The
+// blogs are joined in before paginating so that hidden ones are not counted.
+func (r *BookmarkRepository) ListBookmarks(ctx context.Context, userID string, listID *string, viewerID string, pagination contract.Pagination) ([]*entity.Bookmark, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	query := bson.M{"user_id": userID}
+	if listID != nil {
+		query["list_id"] = *listID
+	}
+	visible := bson.A{bson.M{"status": entity.BlogStatusPublished}}
+	if viewerID != "" {
+		visible = append(visible, bson.M{"author_id": viewerID})
+	}
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "blogs",
+			"let":  bson.M{"blog_id": "$blog_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":      bson.M{"$eq": bson.A{"$_id", "$$blog_id"}},
+					"is_deleted": false,
+					"$or":        visible,
+				}},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "blog",
+		}}},
+		{{Key: "$match", Value: bson.M{"blog": bson.M{"$ne": bson.A{}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "n"}},
+			"items": bson.A{bson.M{"$skip": skip}, bson.M{"$limit": int64(pagination.PageSize)}, bson.M{"$project": bson.M{"blog": 0}}},
+		}}},
+	}
+	cursor, err := r.bookmarks.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find bookmarks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+		Items []*entity.Bookmark `bson:"items"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode bookmarks: %w", err)
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return []*entity.Bookmark{}, 0, nil
+	}
+	return result[0].Items, result[0].Total[0].N, nil
+}
+
+// BookmarkedBlogIDs returns the subset of blogIDs the user has bookmarked.
+func (r *BookmarkRepository) BookmarkedBlogIDs(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error) {
+	bookmarked := make(map[string]bool, len(blogIDs))
+	if userID == "" || len(blogIDs) == 0 {
+		return bookmarked, nil
+	}
+	ids, err := r.bookmarks.Distinct(ctx, "blog_id", bson.M{"user_id": userID, "blog_id": bson.M{"$in": blogIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bookmarks: %w", err)
+	}
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			bookmarked[s] = true
+		}
+	}
+	return bookmarked, nil
+}
@@ -16,26 +16,25 @@ import (
 
 var (
 	ErrCommentNotFound     = errors.New("comment not found")
+	ErrCommentNotModified  = errors.New("comment not modified")
 	ErrCommentCreation     = errors.New("failed to create comment")
 	ErrCommentUpdate       = errors.New("failed to update comment")
 	ErrCommentDeletion     = errors.New("failed to delete comment")
 	ErrInvalidPagination   = errors.New("invalid pagination parameters")
 	ErrInvalidParentTarget = errors.New("invalid parent/target relationship")
-	ErrCommentAlreadyLiked = errors.New("comment already liked by user")
-	ErrCommentNotLiked     = errors.New("comment not liked by user")
 )
 
 type CommentRepository struct {
 	collection       *mongo.Collection
-	likeCollection   *mongo.Collection
 	reportCollection *mongo.Collection
+	editCollection   *mongo.Collection
 }
 
 func NewCommentRepository(db *mongo.Database) *CommentRepository {
 	return &CommentRepository{
 		collection:       db.Collection("comments"),
-		likeCollection:   db.Collection("comment_likes"),
 		reportCollection: db.Collection("comment_reports"),
+		editCollection:   db.Collection("comment_edits"),
 	}
 }
 
@@ -46,10 +45,22 @@ func (r *CommentRepository) Create(ctx context.Context, comment *entity.Comment)
 	comment.ID = uuidgen.NewGenerator().NewUUID()
 
 	// Validate parent/target logic
-	if err := r.validateParentTargetLogic(ctx, comment); err != nil {
+	parent, err := r.validateParentTargetLogic(ctx, comment)
+	if err != nil {
 		return fmt.Errorf("%w: %v", ErrInvalidParentTarget, err)
 	}
 
+	// Materialized path so whole threads can be fetched with a single query
+	comment.RootID, comment.Path, comment.Depth = comment.ID, []string{}, 0
+	if parent != nil {
+		comment.RootID = parent.RootID
+		if comment.RootID == "" {
+			comment.RootID = parent.ID
+		}
+		comment.Path = append(append([]string{}, parent.Path...), parent.ID)
+		comment.Depth = len(comment.Path)
+	}
+
 	comment.CreatedAt = time.Now()
 	comment.UpdatedAt = time.Now()
 	comment.IsDeleted = false
@@ -58,7 +69,7 @@ func (r *CommentRepository) Create(ctx context.Context, comment *entity.Comment)
 		comment.Status = "approved"
 	}
 
-	_, err := r.collection.InsertOne(ctx, comment)
+	_, err = r.collection.InsertOne(ctx, comment)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrCommentCreation, err)
 	}
@@ -81,6 +92,25 @@ func (r *CommentRepository) GetByID(ctx context.Context, id string) (*entity.Com
 	return &comment, nil
 }
 
+// GetByIDs returns the non-deleted comments among ids, in no particular order.
+func (r *CommentRepository) GetByIDs(ctx context.Context, ids []string) ([]*entity.Comment, error) {
+	if len(ids) == 0 {
+		return []*entity.Comment{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}, "is_deleted": false})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	comments := []*entity.Comment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, fmt.Errorf("failed to decode comments: %w", err)
+	}
+	return comments, nil
+}
+
 func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment) error {
 	comment.UpdatedAt = time.Now()
 
@@ -88,6 +118,7 @@ func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment)
 	update := bson.M{
 		"$set": bson.M{
 			"content":    comment.Content,
+			"mentions":   comment.Mentions,
 			"updated_at": comment.UpdatedAt,
 		},
 	}
@@ -104,6 +135,50 @@ func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment)
 	return nil
 }
 
+// Edit History
+func (r *CommentRepository) RecordEdit(ctx context.Context, edit *entity.CommentEdit) error {
+	if edit.ID == "" {
+		edit.ID = uuidgen.NewGenerator().NewUUID()
+	}
+	if edit.EditedAt.IsZero() {
+		edit.EditedAt = time.Now()
+	}
+
+	if _, err := r.editCollection.InsertOne(ctx, edit); err != nil {
+		return fmt.Errorf("failed to record comment edit: %w", err)
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"edit_count": 1},
+		"$set": bson.M{"edited_at": edit.EditedAt},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": edit.CommentID}, update)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCommentUpdate, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// GetCommentEdits returns the content snapshots of a comment, oldest first.
+func (r *CommentRepository) GetCommentEdits(ctx context.Context, commentID string) ([]*entity.CommentEdit, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "edited_at", Value: 1}})
+	cursor, err := r.editCollection.Find(ctx, bson.M{"comment_id": commentID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find comment edits: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var edits []*entity.CommentEdit
+	if err := cursor.All(ctx, &edits); err != nil {
+		return nil, fmt.Errorf("failed to decode comment edits: %w", err)
+	}
+	return edits, nil
+}
+
 func (r *CommentRepository) Delete(ctx context.Context, id string) error {
 	filter := bson.M{"_id": id, "is_deleted": false}
 	update := bson.M{
@@ -134,6 +209,7 @@ func (r *CommentRepository) GetTopLevelComments(ctx context.Context, blogID stri
 	filter := bson.M{
 		"blog_id":    blogID,
 		"parent_id":  nil,
+		"pinned_at":  nil, // pinned comments are listed separately, ahead of the rest
 		"is_deleted": false,
 		"status":     bson.M{"$in": []string{"approved"}},
 	}
@@ -145,11 +221,7 @@ func (r *CommentRepository) GetTopLevelComments(ctx context.Context, blogID stri
 	}
 
 	// Get paginated results
-	skip := int64((pagination.Page - 1) * pagination.PageSize)
-	findOptions := options.Find().
-		SetSkip(skip).
-		SetLimit(int64(pagination.PageSize)).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+	findOptions := paginateComments(filter, pagination, contract.CommentSortNewest)
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -182,8 +254,8 @@ func (r *CommentRepository) GetCommentThread(ctx context.Context, parentID strin
 		Depth:   0,
 	}
 
-	// Get all replies for this thread
-	replies, err := r.getRepliesRecursively(ctx, parentID, 1)
+	// Load every descendant within the depth limit in one query, then assemble in memory
+	replies, err := r.getThreadReplies(ctx, parentComment)
 	if err != nil {
 		return nil, err
 	}
@@ -207,11 +279,7 @@ func (r *CommentRepository) GetCommentsByUser(ctx context.Context, userID string
 		return nil, 0, fmt.Errorf("failed to count user comments: %w", err)
 	}
 
-	skip := int64((pagination.Page - 1) * pagination.PageSize)
-	findOptions := options.Find().
-		SetSkip(skip).
-		SetLimit(int64(pagination.PageSize)).
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+	findOptions := paginateComments(filter, pagination, contract.CommentSortNewest)
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -227,7 +295,111 @@ func (r *CommentRepository) GetCommentsByUser(ctx context.Context, userID string
 	return comments, total, nil
 }
 
+// GetReplies lists the approved replies anywhere below a comment, oldest first by default.
+func (r *CommentRepository) GetReplies(ctx context.Context, commentID string, pagination contract.Pagination) ([]*entity.Comment, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	filter := bson.M{
+		"path":       commentID,
+		"is_deleted": false,
+		"status":     "approved",
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count replies: %w", err)
+	}
+
+	findOptions := paginateComments(filter, pagination, contract.CommentSortOldest)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find replies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var replies []*entity.Comment
+	if err := cursor.All(ctx, &replies); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode replies: %w", err)
+	}
+
+	return replies, total, nil
+}
+
+// Search
+func (r *CommentRepository) SearchComments(ctx context.Context, opts contract.CommentSearchOptions, pagination contract.Pagination) ([]*contract.CommentSearchHit, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	filter := bson.M{
+		"$text":      bson.M{"$search": opts.Query},
+		"is_deleted": false,
+	}
+	if opts.BlogID != "" {
+		filter["blog_id"] = opts.BlogID
+	}
+	if opts.AuthorID != "" {
+		filter["author_id"] = opts.AuthorID
+	}
+	if len(opts.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": opts.Statuses}
+	}
+	dateFilter := bson.M{}
+	if opts.DateFrom != nil {
+		dateFilter["$gte"] = *opts.DateFrom
+	}
+	if opts.DateTo != nil {
+		dateFilter["$lte"] = *opts.DateTo
+	}
+	if len(dateFilter) > 0 {
+		filter["created_at"] = dateFilter
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	// Rank by text relevance, newest first among equally relevant comments
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{
+			{Key: "score", Value: bson.M{"$meta": "textScore"}},
+			{Key: "created_at", Value: -1},
+		}).
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		entity.Comment `bson:",inline"`
+		Score          float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode search results: %w", err)
+	}
+
+	hits := make([]*contract.CommentSearchHit, len(docs))
+	for i := range docs {
+		comment := docs[i].Comment
+		hits[i] = &contract.CommentSearchHit{Comment: &comment, Score: docs[i].Score}
+	}
+
+	return hits, total, nil
+}
+
 // Status and Moderation
+
+// UpdateStatus sets a comment's status and clears any auto-hide marker.
 func (r *CommentRepository) UpdateStatus(ctx context.Context, id string, status string) error {
 	filter := bson.M{"_id": id, "is_deleted": false}
 	update := bson.M{
@@ -235,6 +407,8 @@ func (r *CommentRepository) UpdateStatus(ctx context.Context, id string, status
 			"status":     status,
 			"updated_at": time.Now(),
 		},
+		// A moderator's decision settles the comment, so it no longer counts as auto-hidden
+		"$unset": bson.M{"auto_hidden_at": ""},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -249,121 +423,209 @@ func (r *CommentRepository) UpdateStatus(ctx context.Context, id string, status
 	return nil
 }
 
-func (r *CommentRepository) GetCommentCount(ctx context.Context, blogID string) (int64, error) {
+// BulkUpdateStatus sets the status of several comments and clears their auto-hide markers.
+func (r *CommentRepository) BulkUpdateStatus(ctx context.Context, ids []string, status string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ids}, "is_deleted": false}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+		"$unset": bson.M{"auto_hidden_at": ""},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk update comment status: %w", err)
+	}
+
+	return result.MatchedCount, nil
+}
+
+func (r *CommentRepository) BulkDelete(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ids}, "is_deleted": false}
+	update := bson.M{
+		"$set": bson.M{
+			"is_deleted": true,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCommentDeletion, err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// GetCommentsByStatus lists comments in the given statuses for the moderation queue.
+// Comments hidden after crossing the report threshold come first, then the rest oldest first.
+func (r *CommentRepository) GetCommentsByStatus(ctx context.Context, statuses []string, pagination contract.Pagination) ([]*entity.Comment, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
 	filter := bson.M{
-		"blog_id":    blogID,
 		"is_deleted": false,
-		"status":     "approved",
+		"status":     bson.M{"$in": statuses},
 	}
 
-	count, err := r.collection.CountDocuments(ctx, filter)
+	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count comments: %w", err)
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
 	}
 
-	return count, nil
-}
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "auto_hidden_at", Value: -1}, {Key: "created_at", Value: 1}})
 
-// Like System
-func (r *CommentRepository) LikeComment(ctx context.Context, commentID string, userID string) error {
-	// Check if already liked
-	exists, err := r.IsCommentLikedByUser(ctx, commentID, userID)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return err
+		return nil, 0, fmt.Errorf("failed to find comments: %w", err)
 	}
-	if exists {
-		return ErrCommentAlreadyLiked
+	defer cursor.Close(ctx)
+
+	var comments []*entity.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode comments: %w", err)
 	}
 
-	// Create like record
-	like := &entity.CommentLike{
-		ID:        uuidgen.NewGenerator().NewUUID(),
-		CommentID: commentID,
-		UserID:    userID,
-		CreatedAt: time.Now(),
+	return comments, total, nil
+}
+
+func (r *CommentRepository) GetPinnedComments(ctx context.Context, blogID string) ([]*entity.Comment, error) {
+	filter := bson.M{
+		"blog_id":    blogID,
+		"pinned_at":  bson.M{"$ne": nil},
+		"is_deleted": false,
 	}
 
-	session, err := r.collection.Database().Client().StartSession()
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "pinned_at", Value: 1}}))
 	if err != nil {
-		return fmt.Errorf("failed to start session: %w", err)
+		return nil, fmt.Errorf("failed to find pinned comments: %w", err)
 	}
-	defer session.EndSession(ctx)
+	defer cursor.Close(ctx)
 
-	err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
-		// Insert like
-		_, err := r.likeCollection.InsertOne(sc, like)
-		if err != nil {
-			return err
-		}
+	var comments []*entity.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, fmt.Errorf("failed to decode pinned comments: %w", err)
+	}
+	return comments, nil
+}
 
-		// Increment like count
-		filter := bson.M{"_id": commentID}
-		update := bson.M{"$inc": bson.M{"like_count": 1}}
-		_, err = r.collection.UpdateOne(sc, filter, update)
-		return err
-	})
+func (r *CommentRepository) SetPinned(ctx context.Context, id string, pinnedAt *time.Time) error {
+	filter := bson.M{"_id": id, "is_deleted": false}
+	update := bson.M{"$set": bson.M{"pinned_at": pinnedAt}}
+	if pinnedAt == nil {
+		update = bson.M{"$unset": bson.M{"pinned_at": ""}}
+	}
 
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return fmt.Errorf("failed to like comment: %w", err)
+		return fmt.Errorf("failed to update comment pin: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrCommentNotFound
 	}
 
 	return nil
 }
 
-func (r *CommentRepository) UnlikeComment(ctx context.Context, commentID, userID string) error {
-	// Check if liked
-	exists, err := r.IsCommentLikedByUser(ctx, commentID, userID)
-	if err != nil {
-		return err
+func (r *CommentRepository) SetAutoHidden(ctx context.Context, id, fromStatus, status string, hiddenAt *time.Time) error {
+	filter := bson.M{"_id": id, "is_deleted": false, "status": fromStatus}
+	if hiddenAt == nil {
+		// Only a comment that was hidden automatically is restored; a moderator's decision stands.
+		filter["auto_hidden_at"] = bson.M{"$exists": true}
 	}
-	if !exists {
-		return ErrCommentNotLiked
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}
+	if hiddenAt != nil {
+		update["$set"].(bson.M)["auto_hidden_at"] = hiddenAt
+	} else {
+		update["$unset"] = bson.M{"auto_hidden_at": ""}
 	}
 
-	session, err := r.collection.Database().Client().StartSession()
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return fmt.Errorf("failed to start session: %w", err)
+		return fmt.Errorf("failed to update comment status: %w", err)
 	}
-	defer session.EndSession(ctx)
 
-	err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
-		// Remove like
-		filter := bson.M{"comment_id": commentID, "user_id": userID}
-		_, err := r.likeCollection.DeleteOne(sc, filter)
-		if err != nil {
-			return err
-		}
+	if result.MatchedCount == 0 {
+		return ErrCommentNotModified
+	}
 
-		// Decrement like count
-		commentFilter := bson.M{"_id": commentID}
-		update := bson.M{"$inc": bson.M{"like_count": -1}}
-		_, err = r.collection.UpdateOne(sc, commentFilter, update)
-		return err
-	})
+	return nil
+}
+
+func (r *CommentRepository) GetCommentCount(ctx context.Context, blogID string) (int64, error) {
+	filter := bson.M{
+		"blog_id":    blogID,
+		"is_deleted": false,
+		"status":     "approved",
+	}
 
+	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("failed to unlike comment: %w", err)
+		return 0, fmt.Errorf("failed to count comments: %w", err)
 	}
 
-	return nil
+	return count, nil
 }
 
-func (r *CommentRepository) IsCommentLikedByUser(ctx context.Context, commentID, userID string) (bool, error) {
-	filter := bson.M{"comment_id": commentID, "user_id": userID}
-	count, err := r.likeCollection.CountDocuments(ctx, filter)
+// Reactions
+// SetReactionCounts stores the per-type reaction totals computed from the reaction records.
+func (r *CommentRepository) SetReactionCounts(ctx context.Context, id string, counts map[string]int64) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"like_count":      counts[string(entity.LIKE_TYPE_LIKE)],
+		"dislike_count":   counts[string(entity.LIKE_TYPE_DISLIKE)],
+		"reaction_counts": counts,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return false, fmt.Errorf("failed to check like status: %w", err)
+		return fmt.Errorf("failed to update comment reaction counts: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrCommentNotFound
 	}
-	return count > 0, nil
+
+	return nil
 }
 
-func (r *CommentRepository) GetCommentLikeCount(ctx context.Context, commentID string) (int64, error) {
-	filter := bson.M{"comment_id": commentID}
-	count, err := r.likeCollection.CountDocuments(ctx, filter)
+// IncrementReplyCount atomically adds delta to a comment's reply_count. Deleted comments are
+// included so that replies to them can still be moderated.
+func (r *CommentRepository) IncrementReplyCount(ctx context.Context, id string, delta int) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$inc": bson.M{"reply_count": delta}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get like count: %w", err)
+		return fmt.Errorf("failed to update comment reply count: %w", err)
 	}
-	return count, nil
+
+	if result.MatchedCount == 0 {
+		return ErrCommentNotFound
+	}
+
+	return nil
 }
 
 // Reporting System
@@ -414,7 +676,146 @@ func (r *CommentRepository) GetCommentReports(ctx context.Context, pagination co
 	return reports, total, nil
 }
 
-func (r *CommentRepository) UpdateReportStatus(ctx context.Context, reportID string, status string, reviewerID string) error {
+// GetReportedComments groups pending reports by comment, most reported first.
+func (r *CommentRepository) GetReportedComments(ctx context.Context, pagination contract.Pagination) ([]*contract.ReportedCommentSummary, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"status": entity.ReportStatusPending}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":              "$comment_id",
+			"report_count":     bson.M{"$sum": 1},
+			"reasons":          bson.M{"$addToSet": "$reason"},
+			"latest_report_at": bson.M{"$max": "$created_at"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{
+			{Key: "report_count", Value: -1},
+			{Key: "latest_report_at", Value: -1},
+		}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"items": bson.A{
+				bson.M{"$skip": skip},
+				bson.M{"$limit": int64(pagination.PageSize)},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}}},
+	}
+
+	cursor, err := r.reportCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to aggregate reported comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Items []struct {
+			CommentID      string    `bson:"_id"`
+			ReportCount    int64     `bson:"report_count"`
+			Reasons        []string  `bson:"reasons"`
+			LatestReportAt time.Time `bson:"latest_report_at"`
+		} `bson:"items"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode reported comments: %w", err)
+	}
+	if len(results) == 0 {
+		return []*contract.ReportedCommentSummary{}, 0, nil
+	}
+
+	var total int64
+	if len(results[0].Total) > 0 {
+		total = results[0].Total[0].Count
+	}
+
+	summaries := make([]*contract.ReportedCommentSummary, len(results[0].Items))
+	for i, item := range results[0].Items {
+		summaries[i] = &contract.ReportedCommentSummary{
+			CommentID:      item.CommentID,
+			ReportCount:    item.ReportCount,
+			Reasons:        item.Reasons,
+			LatestReportAt: item.LatestReportAt,
+		}
+	}
+
+	return summaries, total, nil
+}
+
+func (r *CommentRepository) GetReportByID(ctx context.Context, reportID string) (*entity.CommentReport, error) {
+	var report entity.CommentReport
+	err := r.reportCollection.FindOne(ctx, bson.M{"_id": reportID}).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("report not found")
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return &report, nil
+}
+
+// GetReportsByComment returns the reports filed against a comment, optionally limited to the given statuses.
+func (r *CommentRepository) GetReportsByComment(ctx context.Context, commentID string, statuses []string) ([]*entity.CommentReport, error) {
+	filter := bson.M{"comment_id": commentID}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	cursor, err := r.reportCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reports: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reports []*entity.CommentReport
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("failed to decode reports: %w", err)
+	}
+	return reports, nil
+}
+
+func (r *CommentRepository) GetReporterStats(ctx context.Context, reporterID string) (*contract.ReporterStats, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{
+			"reporter_id": reporterID,
+			"status":      bson.M{"$in": []string{entity.ReportStatusReviewed, entity.ReportStatusDismissed}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.reportCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate reporter stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode reporter stats: %w", err)
+	}
+
+	stats := &contract.ReporterStats{}
+	for _, g := range groups {
+		switch g.Status {
+		case entity.ReportStatusReviewed:
+			stats.Upheld = g.Count
+		case entity.ReportStatusDismissed:
+			stats.Dismissed = g.Count
+		}
+	}
+	return stats, nil
+}
+
+func (r *CommentRepository) UpdateReportStatus(ctx context.Context, reportID string, status string, reviewerID string, notes string) error {
 	filter := bson.M{"_id": reportID}
 	now := time.Now()
 	update := bson.M{
@@ -422,6 +823,7 @@ func (r *CommentRepository) UpdateReportStatus(ctx context.Context, reportID str
 			"status":      status,
 			"reviewed_at": &now,
 			"reviewed_by": &reviewerID,
+			"notes":       notes,
 		},
 	}
 
@@ -438,49 +840,99 @@ func (r *CommentRepository) UpdateReportStatus(ctx context.Context, reportID str
 }
 
 // Helper Methods
-func (r *CommentRepository) validateParentTargetLogic(ctx context.Context, comment *entity.Comment) error {
+
+// paginateComments returns find options for a comment listing. With a cursor it narrows filter
+// to the comments that sort after the cursor (keyset pagination); otherwise it skips by page.
+// Call it after counting so the total reflects the whole listing.
+func paginateComments(filter bson.M, pagination contract.Pagination, defaultSort string) *options.FindOptions {
+	sortBy := pagination.Sort
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+
+	var sort bson.D
+	switch sortBy {
+	case contract.CommentSortTop:
+		sort = bson.D{{Key: "like_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	case contract.CommentSortOldest:
+		sort = bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	default:
+		sort = bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(sort)
+
+	if after := pagination.After; after != nil {
+		switch sortBy {
+		case contract.CommentSortTop:
+			filter["$or"] = bson.A{
+				bson.M{"like_count": bson.M{"$lt": after.LikeCount}},
+				bson.M{"like_count": after.LikeCount, "created_at": bson.M{"$lt": after.CreatedAt}},
+				bson.M{"like_count": after.LikeCount, "created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+			}
+		case contract.CommentSortOldest:
+			filter["$or"] = bson.A{
+				bson.M{"created_at": bson.M{"$gt": after.CreatedAt}},
+				bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$gt": after.ID}},
+			}
+		default:
+			filter["$or"] = bson.A{
+				bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+				bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+			}
+		}
+		return findOptions
+	}
+
+	return findOptions.SetSkip(int64((pagination.Page - 1) * pagination.PageSize))
+}
+
+// validateParentTargetLogic checks the reply relationships and returns the parent comment,
+// or nil for a top-level comment.
+func (r *CommentRepository) validateParentTargetLogic(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
 	// If no parent, this is a top-level comment
 	if comment.ParentID == nil {
 		if comment.TargetID != nil {
-			return errors.New("top-level comments cannot have target_id")
+			return nil, errors.New("top-level comments cannot have target_id")
 		}
-		return nil
+		return nil, nil
 	}
 
 	// Validate parent exists and is top-level
 	parent, err := r.GetByID(ctx, *comment.ParentID)
 	if err != nil {
-		return fmt.Errorf("parent comment not found: %w", err)
+		return nil, fmt.Errorf("parent comment not found: %w", err)
 	}
 
 	if parent.ParentID != nil {
-		return errors.New("parent must be a top-level comment")
+		return nil, errors.New("parent must be a top-level comment")
 	}
 
 	// Validate target if specified
 	if comment.TargetID != nil {
 		target, err := r.GetByID(ctx, *comment.TargetID)
 		if err != nil {
-			return fmt.Errorf("target comment not found: %w", err)
+			return nil, fmt.Errorf("target comment not found: %w", err)
 		}
 
 		// Target must be either the parent or a reply in the same thread
 		if target.ID != *comment.ParentID &&
 			(target.ParentID == nil || *target.ParentID != *comment.ParentID) {
-			return errors.New("target comment must be in the same thread")
+			return nil, errors.New("target comment must be in the same thread")
 		}
 	}
 
-	return nil
+	return parent, nil
 }
 
-func (r *CommentRepository) getRepliesRecursively(ctx context.Context, parentID string, depth int) ([]*entity.CommentThread, error) {
-	if depth > contract.MaxCommentDepth { // Prevent excessive nesting
-		return []*entity.CommentThread{}, nil
-	}
-
+// getThreadReplies fetches all approved descendants of root using the materialized path
+// and nests them under their parents, oldest first at every level.
+func (r *CommentRepository) getThreadReplies(ctx context.Context, root *entity.Comment) ([]*entity.CommentThread, error) {
 	filter := bson.M{
-		"parent_id":  parentID,
+		"path":       root.ID,
+		"depth":      bson.M{"$lte": root.Depth + contract.MaxCommentDepth}, // Prevent excessive nesting
 		"is_deleted": false,
 		"status":     "approved",
 	}
@@ -497,22 +949,25 @@ func (r *CommentRepository) getRepliesRecursively(ctx context.Context, parentID
 		return nil, fmt.Errorf("failed to decode replies: %w", err)
 	}
 
-	var threads []*entity.CommentThread
+	children := make(map[string][]*entity.Comment, len(replies))
 	for _, reply := range replies {
-		thread := &entity.CommentThread{
-			Comment: reply,
-			Depth:   depth,
+		if reply.ParentID != nil {
+			children[*reply.ParentID] = append(children[*reply.ParentID], reply)
 		}
+	}
 
-		// Get nested replies
-		nestedReplies, err := r.getRepliesRecursively(ctx, reply.ID, depth+1)
-		if err != nil {
-			return nil, err
+	var build func(parentID string, depth int) []*entity.CommentThread
+	build = func(parentID string, depth int) []*entity.CommentThread {
+		var threads []*entity.CommentThread
+		for _, reply := range children[parentID] {
+			threads = append(threads, &entity.CommentThread{
+				Comment: reply,
+				Depth:   depth,
+				Replies: build(reply.ID, depth+1),
+			})
 		}
-		thread.Replies = nestedReplies
-
-		threads = append(threads, thread)
+		return threads
 	}
 
-	return threads, nil
+	return build(root.ID, 1), nil
 }
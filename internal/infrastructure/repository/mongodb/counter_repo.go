@@ -0,0 +1,285 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CounterRepository is the MongoDB implementation of ICounterRepository. It reads the
+// blogs and comments collections and recounts from the reactions (blog_likes) and
+// comments collections.
+type CounterRepository struct {
+	blogs     *mongo.Collection
+	comments  *mongo.Collection
+	reactions *mongo.Collection
+}
+
+var _ contract.ICounterRepository = (*CounterRepository)(nil)
+
+// NewCounterRepository creates and returns a new CounterRepository instance.
+func NewCounterRepository(db *mongo.Database) *CounterRepository {
+	return &CounterRepository{
+		blogs:     db.Collection("blogs"),
+		comments:  db.Collection("comments"),
+		reactions: db.Collection("blog_likes"),
+	}
+}
+
+// ListBlogCounters returns the stored counters of the next batch of live blogs.
+func (r *CounterRepository) ListBlogCounters(ctx context.Context, afterID string, limit int) ([]contract.BlogCounters, error) {
+	filter := bson.M{"_id": bson.M{"$gt": afterID}, "is_deleted": bson.M{"$ne": true}}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{
+			"view_count": 1, "like_count": 1, "dislike_count": 1,
+			"comment_count": 1, "reaction_counts": 1, "popularity": 1,
+		})
+
+	cursor, err := r.blogs.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog counters: %w", err)
+	}
+	var blogs []entity.Blog
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, fmt.Errorf("failed to decode blog counters: %w", err)
+	}
+
+	counters := make([]contract.BlogCounters, len(blogs))
+	for i, b := range blogs {
+		counters[i] = contract.BlogCounters{
+			BlogID:         b.ID,
+			ViewCount:      b.ViewCount,
+			LikeCount:      b.LikeCount,
+			DislikeCount:   b.DislikeCount,
+			CommentCount:   b.CommentCount,
+			ReactionCounts: b.ReactionCounts,
+			Popularity:     b.Popularity,
+		}
+	}
+	return counters, nil
+}
+
+// ComputeBlogCounters recounts the reactions and approved comments of the given blogs.
+func (r *CounterRepository) ComputeBlogCounters(ctx context.Context, blogIDs []string) (map[string]contract.BlogCounters, error) {
+	reactions, err := r.countReactions(ctx, entity.TargetTypeBlog, blogIDs)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := r.countComments(ctx, "blog_id", blogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]contract.BlogCounters, len(blogIDs))
+	for _, id := range blogIDs {
+		counts, hasReactions := reactions[id]
+		n, hasComments := comments[id]
+		if !hasReactions && !hasComments {
+			continue
+		}
+		result[id] = contract.BlogCounters{
+			BlogID:         id,
+			LikeCount:      int(counts[string(entity.LIKE_TYPE_LIKE)]),
+			DislikeCount:   int(counts[string(entity.LIKE_TYPE_DISLIKE)]),
+			CommentCount:   int(n),
+			ReactionCounts: counts,
+		}
+	}
+	return result, nil
+}
+
+// SetBlogCounters overwrites a blog's counters and popularity.
+func (r *CounterRepository) SetBlogCounters(ctx context.Context, stored, counters contract.BlogCounters) (bool, error) {
+	filter := unchangedCountersFilter(counters.BlogID, bson.M{
+		"view_count":    stored.ViewCount,
+		"like_count":    stored.LikeCount,
+		"dislike_count": stored.DislikeCount,
+		"comment_count": stored.CommentCount,
+	}, stored.ReactionCounts)
+	update := bson.M{"$set": bson.M{
+		"like_count":      counters.LikeCount,
+		"dislike_count":   counters.DislikeCount,
+		"comment_count":   counters.CommentCount,
+		"reaction_counts": nonNilCounts(counters.ReactionCounts),
+		"popularity":      counters.Popularity,
+	}}
+	result, err := r.blogs.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to set counters for blog %s: %w", counters.BlogID, err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ListCommentCounters returns the stored counters of the next batch of live comments.
+func (r *CounterRepository) ListCommentCounters(ctx context.Context, afterID string, limit int) ([]contract.CommentCounters, error) {
+	filter := bson.M{"_id": bson.M{"$gt": afterID}, "is_deleted": false}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"like_count": 1, "dislike_count": 1, "reply_count": 1, "reaction_counts": 1})
+
+	cursor, err := r.comments.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment counters: %w", err)
+	}
+	var comments []entity.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, fmt.Errorf("failed to decode comment counters: %w", err)
+	}
+
+	counters := make([]contract.CommentCounters, len(comments))
+	for i, c := range comments {
+		counters[i] = contract.CommentCounters{
+			CommentID:      c.ID,
+			LikeCount:      c.LikeCount,
+			DislikeCount:   c.DislikeCount,
+			ReplyCount:     c.ReplyCount,
+			ReactionCounts: c.ReactionCounts,
+		}
+	}
+	return counters, nil
+}
+
+// ComputeCommentCounters recounts the reactions and approved direct replies of the given comments.
+func (r *CounterRepository) ComputeCommentCounters(ctx context.Context, commentIDs []string) (map[string]contract.CommentCounters, error) {
+	reactions, err := r.countReactions(ctx, entity.TargetTypeComment, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := r.countComments(ctx, "parent_id", commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]contract.CommentCounters, len(commentIDs))
+	for _, id := range commentIDs {
+		counts, hasReactions := reactions[id]
+		n, hasReplies := replies[id]
+		if !hasReactions && !hasReplies {
+			continue
+		}
+		result[id] = contract.CommentCounters{
+			CommentID:      id,
+			LikeCount:      int(counts[string(entity.LIKE_TYPE_LIKE)]),
+			DislikeCount:   int(counts[string(entity.LIKE_TYPE_DISLIKE)]),
+			ReplyCount:     int(n),
+			ReactionCounts: counts,
+		}
+	}
+	return result, nil
+}
+
+// SetCommentCounters overwrites a comment's counters.
+func (r *CounterRepository) SetCommentCounters(ctx context.Context, stored, counters contract.CommentCounters) (bool, error) {
+	filter := unchangedCountersFilter(counters.CommentID, bson.M{
+		"like_count":    stored.LikeCount,
+		"dislike_count": stored.DislikeCount,
+		"reply_count":   stored.ReplyCount,
+	}, stored.ReactionCounts)
+	update := bson.M{"$set": bson.M{
+		"like_count":      counters.LikeCount,
+		"dislike_count":   counters.DislikeCount,
+		"reply_count":     counters.ReplyCount,
+		"reaction_counts": nonNilCounts(counters.ReactionCounts),
+	}}
+	result, err := r.comments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to set counters for comment %s: %w", counters.CommentID, err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// unchangedCountersFilter matches the document with the given ID while its counters still
+// hold the stored values. Reaction counts are compared type by type, together with the
+// number of types, since embedded document equality depends on key order. A zero counter
+// also matches a missing field, which is how it was read.
+func unchangedCountersFilter(id string, counters bson.M, reactionCounts map[string]int64) bson.M {
+	filter := bson.M{"_id": id}
+	for field, n := range counters {
+		if n == 0 {
+			filter[field] = bson.M{"$in": bson.A{0, nil}}
+		} else {
+			filter[field] = n
+		}
+	}
+	for reactionType, n := range reactionCounts {
+		filter["reaction_counts."+reactionType] = n
+	}
+	filter["$expr"] = bson.M{"$eq": bson.A{
+		bson.M{"$size": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$reaction_counts", bson.M{}}}}},
+		len(reactionCounts),
+	}}
+	return filter
+}
+
+// countReactions returns the active reactions per type for each of the given targets.
+func (r *CounterRepository) countReactions(ctx context.Context, targetType entity.TargetType, targetIDs []string) (map[string]map[string]int64, error) {
+	cursor, err := r.reactions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_type": targetType, "target_id": bson.M{"$in": targetIDs}, "is_deleted": false}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"target_id": "$target_id", "type": "$type"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s reactions: %w", targetType, err)
+	}
+	var rows []struct {
+		ID struct {
+			TargetID string `bson:"target_id"`
+			Type     string `bson:"type"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode %s reaction counts: %w", targetType, err)
+	}
+
+	counts := make(map[string]map[string]int64)
+	for _, row := range rows {
+		if counts[row.ID.TargetID] == nil {
+			counts[row.ID.TargetID] = make(map[string]int64)
+		}
+		counts[row.ID.TargetID][row.ID.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// countComments counts approved, non-deleted comments grouped by the given field
+// (blog_id for a blog's comments, parent_id for a comment's direct replies).
+func (r *CounterRepository) countComments(ctx context.Context, field string, ids []string) (map[string]int64, error) {
+	cursor, err := r.comments.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{field: bson.M{"$in": ids}, "is_deleted": false, "status": entity.CommentStatusApproved}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments by %s: %w", field, err)
+	}
+	var rows []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode comment counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+	return counts, nil
+}
+
+func nonNilCounts(counts map[string]int64) map[string]int64 {
+	if counts == nil {
+		return map[string]int64{}
+	}
+	return counts
+}
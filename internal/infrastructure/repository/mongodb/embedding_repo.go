@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EmbeddingRepository is the MongoDB implementation of IEmbeddingRepository.
+type EmbeddingRepository struct {
+	embeddings *mongo.Collection
+}
+
+var _ contract.IEmbeddingRepository = (*EmbeddingRepository)(nil)
+
+// NewEmbeddingRepository creates and returns a new EmbeddingRepository instance.
+func NewEmbeddingRepository(db *mongo.Database) *EmbeddingRepository {
+	return &EmbeddingRepository{
+		embeddings: db.Collection("blog_embeddings"),
+	}
+}
+
+// ListEmbeddings returns the stored embeddings of one model.
+func (r *EmbeddingRepository) ListEmbeddings(ctx context.Context, model string) ([]*entity.BlogEmbedding, error) {
+	cursor, err := r.embeddings.Find(ctx, bson.M{"model": model})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embeddings: %w", err)
+	}
+	embeddings := []*entity.BlogEmbedding{}
+	if err := cursor.All(ctx, &embeddings); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings: %w", err)
+	}
+	return embeddings, nil
+}
+
+// UpsertEmbeddings replaces the stored embedding of each blog.
+func (r *EmbeddingRepository) UpsertEmbeddings(ctx context.Context, embeddings []*entity.BlogEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(embeddings))
+	for _, e := range embeddings {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": e.BlogID}).
+			SetReplacement(e).
+			SetUpsert(true))
+	}
+	if _, err := r.embeddings.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store embeddings: %w", err)
+	}
+	return nil
+}
+
+// DeleteEmbeddings removes the embeddings of the given blogs.
+func (r *EmbeddingRepository) DeleteEmbeddings(ctx context.Context, blogIDs []string) error {
+	if len(blogIDs) == 0 {
+		return nil
+	}
+	if _, err := r.embeddings.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": blogIDs}}); err != nil {
+		return fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FollowRepository is the MongoDB implementation of IFollowRepository.
+type FollowRepository struct {
+	collection *mongo.Collection
+}
+
+var _ contract.IFollowRepository = (*FollowRepository)(nil)
+
+// NewFollowRepository creates and returns a new FollowRepository instance.
+func NewFollowRepository(db *mongo.Database) *FollowRepository {
+	return &FollowRepository{collection: db.Collection("follows")}
+}
+
+// Follow upserts the follow so repeated requests keep the original follow date.
+func (r *FollowRepository) Follow(ctx context.Context, follow *entity.Follow) (bool, error) {
+	if follow.ID == "" {
+		follow.ID = uuidgen.NewGenerator().NewUUID()
+	}
+	if follow.CreatedAt.IsZero() {
+		follow.CreatedAt = time.Now()
+	}
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"follower_id": follow.FollowerID, "target_type": follow.TargetType, "target_id": follow.TargetID},
+		bson.M{"$setOnInsert": bson.M{"_id": follow.ID, "created_at": follow.CreatedAt}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow: %w", err)
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// Unfollow deletes the follow if it exists.
+func (r *FollowRepository) Unfollow(ctx context.Context, followerID string, targetType entity.FollowTargetType, targetID string) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"follower_id": followerID, "target_type": targetType, "target_id": targetID})
+	if err != nil {
+		return false, fmt.Errorf("failed to unfollow: %w", err)
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// IsFollowing reports whether the follow exists.
+func (r *FollowRepository) IsFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType, targetID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx,
+		bson.M{"follower_id": followerID, "target_type": targetType, "target_id": targetID},
+		options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListFollowing returns a page of the user's follows of one type, newest first.
+func (r *FollowRepository) ListFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType, pagination contract.Pagination) ([]*entity.Follow, int64, error) {
+	return r.list(ctx, bson.M{"follower_id": followerID, "target_type": targetType}, pagination)
+}
+
+// ListFollowers returns a page of the target's followers, newest first.
+func (r *FollowRepository) ListFollowers(ctx context.Context, targetType entity.FollowTargetType, targetID string, pagination contract.Pagination) ([]*entity.Follow, int64, error) {
+	return r.list(ctx, bson.M{"target_type": targetType, "target_id": targetID}, pagination)
+}
+
+func (r *FollowRepository) list(ctx context.Context, query bson.M, pagination contract.Pagination) ([]*entity.Follow, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count follows: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find follows: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	follows := []*entity.Follow{}
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode follows: %w", err)
+	}
+	return follows, total, nil
+}
+
+// CountFollowers counts the target's followers.
+func (r *FollowRepository) CountFollowers(ctx context.Context, targetType entity.FollowTargetType, targetID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"target_type": targetType, "target_id": targetID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count followers: %w", err)
+	}
+	return count, nil
+}
+
+// CountFollowing counts the user's follows of one type.
+func (r *FollowRepository) CountFollowing(ctx context.Context, followerID string, targetType entity.FollowTargetType) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"follower_id": followerID, "target_type": targetType})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count follows: %w", err)
+	}
+	return count, nil
+}
+
+// FollowedTargets returns every target ID of one type the user follows.
+func (r *FollowRepository) FollowedTargets(ctx context.Context, followerID string, targetType entity.FollowTargetType) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "target_id", bson.M{"follower_id": followerID, "target_type": targetType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed targets: %w", err)
+	}
+	targets := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			targets = append(targets, id)
+		}
+	}
+	return targets, nil
+}
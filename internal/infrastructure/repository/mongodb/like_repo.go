@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,6 +22,8 @@ type LikeRepository struct {
 	collection *mongo.Collection
 }
 
+var _ contract.ILikeRepository = (*LikeRepository)(nil)
+
 // NewLikeRepository creates and returns a new LikeRepository instance.
 func NewLikeRepository(db *mongo.Database) *LikeRepository {
 	return &LikeRepository{
@@ -28,7 +31,8 @@ func NewLikeRepository(db *mongo.Database) *LikeRepository {
 	}
 }
 
-// CreateReaction creates or updates a user's reaction (like/dislike) on a target.
+// CreateReaction creates or updates a user's reaction (like/dislike) on a target, and fills
+// like in from the stored reaction, including the ID and creation time of one that existed.
 func (r *LikeRepository) CreateReaction(ctx context.Context, like *entity.Like) error {
 	// Filter to find an existing reaction by this user on this target.
 	filter := bson.M{
@@ -55,22 +59,12 @@ func (r *LikeRepository) CreateReaction(ctx context.Context, like *entity.Like)
 		"$setOnInsert": setOnInsertFields,
 	}
 
-	opts := options.Update().SetUpsert(true)
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
 
-	res, err := r.collection.UpdateOne(ctx, filter, updateDoc, opts)
-	if err != nil {
+	if err := r.collection.FindOneAndUpdate(ctx, filter, updateDoc, opts).Decode(like); err != nil {
 		return fmt.Errorf("failed to create or update reaction record: %w", err)
 	}
 
-	if res.UpsertedID != nil {
-		if id, ok := res.UpsertedID.(string); ok {
-			like.ID = id
-		} else {
-			return fmt.Errorf("upserted ID is not a string, got type %T", res.UpsertedID)
-		}
-		like.CreatedAt = setOnInsertFields["created_at"].(time.Time)
-	}
-
 	return nil
 }
 
@@ -146,3 +140,144 @@ func (r *LikeRepository) CountDislikesByTargetID(ctx context.Context, targetID s
 	}
 	return count, nil
 }
+
+// CountReactionsByTargetID counts the active reactions of every type for a specific target.
+func (r *LikeRepository) CountReactionsByTargetID(ctx context.Context, targetID string) (map[entity.LikeType]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_id": targetID, "is_deleted": false}}},
+		{{Key: "$group", Value: bson.M{"_id": "$type", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Type  entity.LikeType `bson:"_id"`
+		Count int64           `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode reaction counts: %w", err)
+	}
+
+	counts := make(map[entity.LikeType]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// ListReactors pages through the users who reacted to a target, joined with their profiles.
+// Users who chose to hide their reactions are filtered out before paginating.
+func (r *LikeRepository) ListReactors(ctx context.Context, targetID string, reactionType entity.LikeType, pagination contract.Pagination) ([]*contract.Reactor, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	match := bson.M{"target_id": targetID, "is_deleted": false}
+	if reactionType != "" {
+		match["type"] = reactionType
+	}
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{"from": "users", "localField": "user_id", "foreignField": "_id", "as": "user"}}},
+		{{Key: "$unwind", Value: "$user"}},
+		{{Key: "$match", Value: bson.M{"user.hide_reactions": bson.M{"$ne": true}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "n"}},
+			"items": bson.A{bson.M{"$skip": skip}, bson.M{"$limit": int64(pagination.PageSize)}},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reactors: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+		Items []struct {
+			UserID    string          `bson:"user_id"`
+			Type      entity.LikeType `bson:"type"`
+			UpdatedAt time.Time       `bson:"updated_at"`
+			User      entity.User     `bson:"user"`
+		} `bson:"items"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode reactors: %w", err)
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return []*contract.Reactor{}, 0, nil
+	}
+
+	reactors := make([]*contract.Reactor, len(result[0].Items))
+	for i, item := range result[0].Items {
+		reactors[i] = &contract.Reactor{
+			UserID:    item.UserID,
+			Username:  item.User.Username,
+			FirstName: item.User.FirstName,
+			LastName:  item.User.LastName,
+			AvatarURL: item.User.AvatarURL,
+			Type:      item.Type,
+			ReactedAt: item.UpdatedAt,
+		}
+	}
+	return reactors, result[0].Total[0].N, nil
+}
+
+// ListReactionsByUser pages through a user's active reactions, most recent first.
+func (r *LikeRepository) ListReactionsByUser(ctx context.Context, userID string, pagination contract.Pagination) ([]*entity.Like, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	filter := bson.M{"user_id": userID, "is_deleted": false}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find reactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var likes []*entity.Like
+	if err := cursor.All(ctx, &likes); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode reactions: %w", err)
+	}
+	return likes, total, nil
+}
+
+// GetReactionsByUserIDAndTargetIDs returns a user's active reactions on any of the targets.
+func (r *LikeRepository) GetReactionsByUserIDAndTargetIDs(ctx context.Context, userID string, targetIDs []string) ([]*entity.Like, error) {
+	if len(targetIDs) == 0 {
+		return []*entity.Like{}, nil
+	}
+
+	filter := bson.M{"user_id": userID, "target_id": bson.M{"$in": targetIDs}, "is_deleted": false}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	likes := []*entity.Like{}
+	if err := cursor.All(ctx, &likes); err != nil {
+		return nil, fmt.Errorf("failed to decode reactions: %w", err)
+	}
+	return likes, nil
+}
@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ModerationLogRepository is the MongoDB implementation of IModerationLogRepository.
+type ModerationLogRepository struct {
+	collection *mongo.Collection
+}
+
+// NewModerationLogRepository creates and returns a new ModerationLogRepository instance.
+func NewModerationLogRepository(db *mongo.Database) *ModerationLogRepository {
+	return &ModerationLogRepository{
+		collection: db.Collection("moderation_actions"),
+	}
+}
+
+// Record appends one or more moderator actions to the audit trail.
+func (r *ModerationLogRepository) Record(ctx context.Context, actions ...*entity.ModerationAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(actions))
+	for i, action := range actions {
+		if action.ID == "" {
+			action.ID = uuidgen.NewGenerator().NewUUID()
+		}
+		if action.CreatedAt.IsZero() {
+			action.CreatedAt = time.Now()
+		}
+		docs[i] = action
+	}
+
+	if _, err := r.collection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to record moderation actions: %w", err)
+	}
+	return nil
+}
+
+// List returns audit trail entries matching the filter, newest first.
+func (r *ModerationLogRepository) List(ctx context.Context, filter contract.ModerationLogFilter, pagination contract.Pagination) ([]*entity.ModerationAction, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	query := bson.M{}
+	if filter.ModeratorID != "" {
+		query["moderator_id"] = filter.ModeratorID
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count moderation actions: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find moderation actions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var actions []*entity.ModerationAction
+	if err := cursor.All(ctx, &actions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode moderation actions: %w", err)
+	}
+
+	return actions, total, nil
+}
@@ -0,0 +1,110 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationRepository is the MongoDB implementation of INotificationRepository.
+type NotificationRepository struct {
+	collection *mongo.Collection
+}
+
+var _ contract.INotificationRepository = (*NotificationRepository)(nil)
+
+// NewNotificationRepository creates and returns a new NotificationRepository instance.
+func NewNotificationRepository(db *mongo.Database) *NotificationRepository {
+	return &NotificationRepository{
+		collection: db.Collection("notifications"),
+	}
+}
+
+// Create stores one or more notifications.
+func (r *NotificationRepository) Create(ctx context.Context, notifications ...*entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(notifications))
+	for i, n := range notifications {
+		if n.ID == "" {
+			n.ID = uuidgen.NewGenerator().NewUUID()
+		}
+		if n.CreatedAt.IsZero() {
+			n.CreatedAt = time.Now()
+		}
+		docs[i] = n
+	}
+
+	if _, err := r.collection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+	return nil
+}
+
+// ListByRecipient returns a user's notifications, newest first.
+func (r *NotificationRepository) ListByRecipient(ctx context.Context, userID string, unreadOnly bool, pagination contract.Pagination) ([]*entity.Notification, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	query := bson.M{"recipient_user_id": userID}
+	if unreadOnly {
+		query["is_read"] = false
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []*entity.Notification
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread returns how many unread notifications a user has.
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"recipient_user_id": userID, "is_read": false})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks the given notifications as read, or every unread one when ids is empty.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID string, ids []string) (int64, error) {
+	filter := bson.M{"recipient_user_id": userID, "is_read": false}
+	if len(ids) > 0 {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"is_read": true}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
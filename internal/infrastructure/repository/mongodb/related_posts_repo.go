@@ -0,0 +1,206 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// coReadStateID is the analytics_state document that tracks co-readership progress.
+const coReadStateID = "related_posts_coreads"
+
+// RelatedPostsRepository is the MongoDB implementation of IRelatedPostsRepository.
+type RelatedPostsRepository struct {
+	blogs   *mongo.Collection
+	views   *mongo.Collection
+	coReads *mongo.Collection
+	related *mongo.Collection
+	state   *mongo.Collection
+}
+
+var _ contract.IRelatedPostsRepository = (*RelatedPostsRepository)(nil)
+
+// NewRelatedPostsRepository creates and returns a new RelatedPostsRepository instance.
+func NewRelatedPostsRepository(db *mongo.Database) *RelatedPostsRepository {
+	return &RelatedPostsRepository{
+		blogs:   db.Collection("blogs"),
+		views:   db.Collection("blog_views"),
+		coReads: db.Collection("blog_coreads"),
+		related: db.Collection("related_posts"),
+		state:   db.Collection("analytics_state"),
+	}
+}
+
+// ListCorpus returns the most recently published blogs with their text and tags.
+func (r *RelatedPostsRepository) ListCorpus(ctx context.Context, limit int) ([]*entity.Blog, error) {
+	findOptions := options.Find().
+		SetProjection(bson.M{"title": 1, "content": 1, "tags": 1, "author_id": 1, "published_at": 1, "status": 1}).
+		SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.blogs.Find(ctx, bson.M{"status": entity.BlogStatusPublished, "is_deleted": false}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blogs for related posts: %w", err)
+	}
+	blogs := []*entity.Blog{}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, fmt.Errorf("failed to decode blogs for related posts: %w", err)
+	}
+	return blogs, nil
+}
+
+// EachReaderPosts groups the views in [from, to) by reader in the database and streams each
+// reader's posts, with the earliest view of each, to fn.
+func (r *RelatedPostsRepository) EachReaderPosts(ctx context.Context, from, to time.Time, maxPosts int, fn func(posts map[string]time.Time) error) error {
+	reader := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$user_id", ""}}, ""}},
+		"$user_id",
+		bson.M{"$concat": bson.A{"ip:", "$ip_hash"}},
+	}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"viewed_at": bson.M{"$gte": from, "$lt": to},
+			"$or": bson.A{
+				bson.M{"user_id": bson.M{"$nin": bson.A{nil, ""}}},
+				bson.M{"ip_hash": bson.M{"$nin": bson.A{nil, ""}}},
+			},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"reader": reader, "blog_id": "$blog_id"},
+			"viewed_at": bson.M{"$min": "$viewed_at"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$_id.reader",
+			"posts": bson.M{"$push": bson.M{"blog_id": "$_id.blog_id", "viewed_at": "$viewed_at"}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gte": 2, "$lte": maxPosts}}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "posts": 1}}},
+	}
+	cursor, err := r.views.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return fmt.Errorf("failed to group blog views by reader: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			Posts []struct {
+				BlogID   string    `bson:"blog_id"`
+				ViewedAt time.Time `bson:"viewed_at"`
+			} `bson:"posts"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return fmt.Errorf("failed to decode reader posts: %w", err)
+		}
+		posts := make(map[string]time.Time, len(row.Posts))
+		for _, p := range row.Posts {
+			posts[p.BlogID] = p.ViewedAt
+		}
+		if err := fn(posts); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to group blog views by reader: %w", err)
+	}
+	return nil
+}
+
+// GetCoReadWatermark returns the time up to which views were counted, or the zero time.
+func (r *RelatedPostsRepository) GetCoReadWatermark(ctx context.Context) (time.Time, error) {
+	var state struct {
+		CountedUntil time.Time `bson:"counted_until"`
+	}
+	err := r.state.FindOne(ctx, bson.M{"_id": coReadStateID}).Decode(&state)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to read co-read watermark: %w", err)
+	}
+	return state.CountedUntil, nil
+}
+
+// SetCoReadWatermark records the time up to which views were counted.
+func (r *RelatedPostsRepository) SetCoReadWatermark(ctx context.Context, at time.Time) error {
+	_, err := r.state.UpdateByID(ctx, coReadStateID,
+		bson.M{"$set": bson.M{"counted_until": at}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store co-read watermark: %w", err)
+	}
+	return nil
+}
+
+// AddCoReads increments the co-readership of each pair, creating pairs as needed.
+func (r *RelatedPostsRepository) AddCoReads(ctx context.Context, pairs []entity.CoReadPair) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(pairs))
+	for _, p := range pairs {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": p.BlogA + "|" + p.BlogB}).
+			SetUpdate(bson.M{
+				"$set": bson.M{"blog_a": p.BlogA, "blog_b": p.BlogB, "updated_at": now},
+				"$inc": bson.M{"count": p.Count},
+			}).
+			SetUpsert(true))
+	}
+	if _, err := r.coReads.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store co-reads: %w", err)
+	}
+	return nil
+}
+
+// ListCoReads returns the pairs read together by at least minCount readers.
+func (r *RelatedPostsRepository) ListCoReads(ctx context.Context, minCount int64) ([]entity.CoReadPair, error) {
+	cursor, err := r.coReads.Find(ctx, bson.M{"count": bson.M{"$gte": minCount}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list co-reads: %w", err)
+	}
+	var pairs []entity.CoReadPair
+	if err := cursor.All(ctx, &pairs); err != nil {
+		return nil, fmt.Errorf("failed to decode co-reads: %w", err)
+	}
+	return pairs, nil
+}
+
+// SaveRelatedPosts replaces the stored related posts of each blog.
+func (r *RelatedPostsRepository) SaveRelatedPosts(ctx context.Context, related []*entity.RelatedPosts) error {
+	if len(related) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(related))
+	for _, rp := range related {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": rp.BlogID}).
+			SetReplacement(rp).
+			SetUpsert(true))
+	}
+	if _, err := r.related.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store related posts: %w", err)
+	}
+	return nil
+}
+
+// GetRelatedPosts returns a blog's related posts, or nil when none were computed.
+func (r *RelatedPostsRepository) GetRelatedPosts(ctx context.Context, blogID string) (*entity.RelatedPosts, error) {
+	var related entity.RelatedPosts
+	if err := r.related.FindOne(ctx, bson.M{"_id": blogID}).Decode(&related); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to retrieve related posts: %w", err)
+	}
+	return &related, nil
+}
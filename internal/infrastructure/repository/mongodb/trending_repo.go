@@ -0,0 +1,212 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TrendingRepository is the MongoDB implementation of ITrendingRepository. Activity is read
+// from the hourly view totals, the reactions collection and the comments collection.
+type TrendingRepository struct {
+	blogs     *mongo.Collection
+	viewStats *mongo.Collection
+	reactions *mongo.Collection
+	comments  *mongo.Collection
+}
+
+var _ contract.ITrendingRepository = (*TrendingRepository)(nil)
+
+// NewTrendingRepository creates and returns a new TrendingRepository instance.
+func NewTrendingRepository(db *mongo.Database) *TrendingRepository {
+	return &TrendingRepository{
+		blogs:     db.Collection("blogs"),
+		viewStats: db.Collection("blog_view_stats"),
+		reactions: db.Collection("blog_likes"),
+		comments:  db.Collection("comments"),
+	}
+}
+
+// CollectSignals sums views, reactions and approved comments since the given time per blog,
+// keeping only blogs that are published.
+func (r *TrendingRepository) CollectSignals(ctx context.Context, since time.Time) ([]contract.TrendingSignals, error) {
+	signals := make(map[string]*contract.TrendingSignals)
+	get := func(blogID string) *contract.TrendingSignals {
+		s, ok := signals[blogID]
+		if !ok {
+			s = &contract.TrendingSignals{BlogID: blogID}
+			signals[blogID] = s
+		}
+		return s
+	}
+
+	views, err := r.sumByBlog(ctx, r.viewStats, bson.M{"hour": bson.M{"$gte": since.UTC().Truncate(time.Hour)}}, "$blog_id", "$views")
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum recent views: %w", err)
+	}
+	for blogID, n := range views {
+		get(blogID).Views = n
+	}
+
+	comments, err := r.sumByBlog(ctx, r.comments, bson.M{
+		"created_at": bson.M{"$gte": since},
+		"is_deleted": false,
+		"status":     entity.CommentStatusApproved,
+	}, "$blog_id", 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent comments: %w", err)
+	}
+	for blogID, n := range comments {
+		get(blogID).Comments = n
+	}
+
+	cursor, err := r.reactions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"target_type": entity.TargetTypeBlog, "is_deleted": false, "updated_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"blog_id": "$target_id", "type": "$type"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent reactions: %w", err)
+	}
+	var reactions []struct {
+		ID struct {
+			BlogID string          `bson:"blog_id"`
+			Type   entity.LikeType `bson:"type"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &reactions); err != nil {
+		return nil, fmt.Errorf("failed to decode recent reactions: %w", err)
+	}
+	for _, row := range reactions {
+		s := get(row.ID.BlogID)
+		switch row.ID.Type {
+		case entity.LIKE_TYPE_LIKE:
+			s.Likes += row.Count
+		case entity.LIKE_TYPE_DISLIKE:
+			s.Dislikes += row.Count
+		default:
+			s.Reactions += row.Count
+		}
+	}
+
+	if len(signals) == 0 {
+		return []contract.TrendingSignals{}, nil
+	}
+
+	// Only published blogs can trend; their publication time drives the decay
+	ids := make([]string, 0, len(signals))
+	for id := range signals {
+		ids = append(ids, id)
+	}
+	cursor, err = r.blogs.Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "status": entity.BlogStatusPublished, "is_deleted": false},
+		options.Find().SetProjection(bson.M{"published_at": 1, "created_at": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blogs for trending: %w", err)
+	}
+	var blogs []entity.Blog
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, fmt.Errorf("failed to decode blogs for trending: %w", err)
+	}
+
+	result := make([]contract.TrendingSignals, 0, len(blogs))
+	for _, b := range blogs {
+		s := signals[b.ID]
+		s.PublishedAt = b.CreatedAt
+		if b.PublishedAt != nil {
+			s.PublishedAt = *b.PublishedAt
+		}
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+// SetTrendingScores stores the scores of one window and clears it from every other blog.
+func (r *TrendingRepository) SetTrendingScores(ctx context.Context, window entity.TrendingWindow, scores map[string]float64) error {
+	field := "trending_scores." + string(window)
+
+	ids := make([]string, 0, len(scores))
+	models := make([]mongo.WriteModel, 0, len(scores)+1)
+	for id, score := range scores {
+		ids = append(ids, id)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{field: score}}))
+	}
+	models = append(models, mongo.NewUpdateManyModel().
+		SetFilter(bson.M{field: bson.M{"$exists": true}, "_id": bson.M{"$nin": ids}}).
+		SetUpdate(bson.M{"$unset": bson.M{field: ""}}))
+
+	if _, err := r.blogs.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store %s trending scores: %w", window, err)
+	}
+	return nil
+}
+
+// GetTrendingBlogs returns published blogs ranked by their score in the window.
+func (r *TrendingRepository) GetTrendingBlogs(ctx context.Context, window entity.TrendingWindow, pagination contract.Pagination) ([]*entity.Blog, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	field := "trending_scores." + string(window)
+	filter := bson.M{
+		field:        bson.M{"$gt": 0},
+		"status":     entity.BlogStatusPublished,
+		"is_deleted": false,
+	}
+	total, err := r.blogs.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count trending blogs: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: field, Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.blogs.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find trending blogs: %w", err)
+	}
+	var blogs []*entity.Blog
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode trending blogs: %w", err)
+	}
+	return blogs, total, nil
+}
+
+// sumByBlog groups the matching documents by blog and sums the given expression.
+func (r *TrendingRepository) sumByBlog(ctx context.Context, collection *mongo.Collection, match bson.M, blogField string, sum interface{}) (map[string]int64, error) {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": blogField, "total": bson.M{"$sum": sum}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		ID    string `bson:"_id"`
+		Total int64  `bson:"total"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.ID] = row.Total
+	}
+	return totals, nil
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UnitOfWork runs work inside a MongoDB multi-document transaction. Transactions need a
+// replica set or sharded cluster; on a standalone server the work runs without one and
+// counters may drift until the counter reconciler repairs them.
+type UnitOfWork struct {
+	client        *mongo.Client
+	transactional bool
+}
+
+var _ contract.IUnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a UnitOfWork, checking once whether the deployment supports transactions.
+func NewUnitOfWork(ctx context.Context, client *mongo.Client) *UnitOfWork {
+	transactional := supportsTransactions(ctx, client)
+	if !transactional {
+		log.Println("MongoDB deployment does not support transactions; writes will not be grouped atomically")
+	}
+	return &UnitOfWork{client: client, transactional: transactional}
+}
+
+// Do runs fn in a transaction, or directly when transactions are unavailable. Calls made
+// while a transaction is already open join it instead of starting a new one.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !u.transactional || mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// supportsTransactions reports whether the server is a replica set member or a mongos router.
+func supportsTransactions(ctx context.Context, client *mongo.Client) bool {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid"
+}
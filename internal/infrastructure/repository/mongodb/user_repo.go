@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoUserRepository struct {
@@ -35,6 +37,25 @@ func (r *MongoUserRepository) GetUserByID(ctx context.Context, id string) (*enti
 	return &user, nil
 }
 
+// GetUsersByIDs retrieves the users among the given IDs, in no particular order.
+func (r *MongoUserRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+	if len(ids) == 0 {
+		return []*entity.User{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	users := []*entity.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+	return users, nil
+}
+
 func (r *MongoUserRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
 	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
@@ -59,6 +80,30 @@ func (r *MongoUserRepository) GetUserByUsername(ctx context.Context, username st
 	return &user, nil
 }
 
+// SearchUsersByUsernamePrefix returns active users whose username starts with prefix, in alphabetical order.
+func (r *MongoUserRepository) SearchUsersByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]*entity.User, error) {
+	filter := bson.M{
+		"username":  bson.M{"$regex": "^" + regexp.QuoteMeta(prefix), "$options": "i"},
+		"is_active": true,
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "username": 1, "firstname": 1, "lastname": 1, "avatar_url": 1}).
+		SetSort(bson.D{{Key: "username", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+	return users, nil
+}
+
 func (r *MongoUserRepository) GetByUserName(ctx context.Context, username string) (*entity.User, error) {
 	var user entity.User
 	err := r.collection.FindOne(ctx, bson.M{"username": username}).Decode(&user)
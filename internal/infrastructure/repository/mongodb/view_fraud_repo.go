@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/uuidgen"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ViewFraudRepository is the MongoDB implementation of IViewFraudRepository.
+type ViewFraudRepository struct {
+	collection *mongo.Collection
+}
+
+var _ contract.IViewFraudRepository = (*ViewFraudRepository)(nil)
+
+// NewViewFraudRepository creates and returns a new ViewFraudRepository instance.
+func NewViewFraudRepository(db *mongo.Database) *ViewFraudRepository {
+	return &ViewFraudRepository{
+		collection: db.Collection("flagged_views"),
+	}
+}
+
+// RecordFlaggedView stores a view that matched fraud rules.
+func (r *ViewFraudRepository) RecordFlaggedView(ctx context.Context, view *entity.FlaggedView) error {
+	if view.ID == "" {
+		view.ID = uuidgen.NewGenerator().NewUUID()
+	}
+	if view.CreatedAt.IsZero() {
+		view.CreatedAt = time.Now()
+	}
+	if _, err := r.collection.InsertOne(ctx, view); err != nil {
+		return fmt.Errorf("failed to record flagged view: %w", err)
+	}
+	return nil
+}
+
+// ListFlaggedViews returns matching flagged views, newest first.
+func (r *ViewFraudRepository) ListFlaggedViews(ctx context.Context, filter contract.FlaggedViewFilter, pagination contract.Pagination) ([]*entity.FlaggedView, int64, error) {
+	if pagination.Page < 1 || pagination.PageSize < 1 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	query := bson.M{}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.Rule != "" {
+		query["rules"] = filter.Rule
+	}
+	if filter.BlogID != "" {
+		query["blog_id"] = filter.BlogID
+	}
+	if !filter.Since.IsZero() {
+		query["created_at"] = bson.M{"$gte": filter.Since}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count flagged views: %w", err)
+	}
+
+	skip := int64((pagination.Page - 1) * pagination.PageSize)
+	findOptions := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pagination.PageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find flagged views: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	views := []*entity.FlaggedView{}
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode flagged views: %w", err)
+	}
+	return views, total, nil
+}
+
+// TopOffenders returns the keys of field with the most flagged views since the given time.
+func (r *ViewFraudRepository) TopOffenders(ctx context.Context, field string, since time.Time, limit int) ([]contract.ViewOffender, error) {
+	switch field {
+	case "ip_hash", "user_id", "fingerprint":
+	default:
+		return nil, fmt.Errorf("invalid offender field %q", field)
+	}
+
+	countAction := func(action entity.ViewFraudAction) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$action", action}}, 1, 0}}}
+	}
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}, field: bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$" + field,
+			"count":     bson.M{"$sum": 1},
+			"blocked":   countAction(entity.ViewFraudActionBlock),
+			"dropped":   countAction(entity.ViewFraudActionDrop),
+			"rules":     bson.M{"$push": "$rules"},
+			"last_seen": bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "last_seen", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		// Flatten the per-view rule lists into one distinct list
+		{{Key: "$set", Value: bson.M{"rules": bson.M{"$reduce": bson.M{
+			"input":        "$rules",
+			"initialValue": bson.A{},
+			"in":           bson.M{"$setUnion": bson.A{"$$value", "$$this"}},
+		}}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate view offenders: %w", err)
+	}
+
+	offenders := []contract.ViewOffender{}
+	if err := cursor.All(ctx, &offenders); err != nil {
+		return nil, fmt.Errorf("failed to decode view offenders: %w", err)
+	}
+	return offenders, nil
+}
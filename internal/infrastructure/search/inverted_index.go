@@ -0,0 +1,372 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// BM25 ranking parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// titleWeight is how many times a title word counts compared to a content word
+	titleWeight = 2
+)
+
+// BlogSource is what the index needs from the blog store: the text of every blog to build
+// from, and the blogs, filters and facets to turn matches into results.
+type BlogSource interface {
+	ListSearchableBlogs(ctx context.Context, statuses ...entity.BlogStatus) ([]*entity.Blog, error)
+	FilterBlogIDs(ctx context.Context, filterOptions *contract.BlogFilterOptions) ([]string, error)
+	GetBlogsByIDs(ctx context.Context, blogIDs []string) ([]*entity.Blog, error)
+	GetBlogFacets(ctx context.Context, filterOptions *contract.BlogFilterOptions, includeStatus bool) (*contract.BlogFacets, error)
+}
+
+// InvertedIndex is an in-process blog search index. It holds the words of every blog's title
+// and content in memory and ranks matches with BM25. Filters are applied by the blog source
+// over the matching IDs, so counters and statuses are always current; only the text has to
+// be kept in sync through Index and Remove. It suits tests and small deployments.
+type InvertedIndex struct {
+	source     BlogSource
+	rebuilding sync.Mutex
+
+	mu          sync.RWMutex
+	postings    map[string]map[string]int // term -> blog ID -> weighted term frequency
+	docs        map[string]*indexedBlog
+	totalLength int
+	pending     []pendingChange // changes made while a rebuild is loading blogs
+	loading     bool
+}
+
+// indexedBlog is what the index keeps per blog.
+type indexedBlog struct {
+	terms  map[string]int // weighted term frequencies, to undo the postings
+	length int
+	words  string // every word of the title and content, space separated and padded, for phrases
+}
+
+// pendingChange is an Index (blog set) or Remove (blog nil) to replay after a rebuild.
+type pendingChange struct {
+	blogID string
+	blog   *indexedBlog
+}
+
+// NewInvertedIndex creates an empty InvertedIndex; call Rebuild to load the existing blogs.
+func NewInvertedIndex(source BlogSource) *InvertedIndex {
+	return &InvertedIndex{
+		source:   source,
+		postings: make(map[string]map[string]int),
+		docs:     make(map[string]*indexedBlog),
+	}
+}
+
+var _ contract.IBlogSearchIndex = (*InvertedIndex)(nil)
+
+// Search matches the query once, pages through the matches that pass the filters, ordered
+// by score or the requested sort, and loads only the blogs on the page.
+func (x *InvertedIndex) Search(ctx context.Context, query string, filterOptions *contract.BlogFilterOptions, includeStatus bool) (*contract.BlogSearchPage, error) {
+	scores := x.match(query)
+	opts := restrictTo(scores, filterOptions)
+	if opts == nil {
+		return &contract.BlogSearchPage{Blogs: []contract.ScoredBlog{}, Facets: utils.CountBlogFacets(nil, includeStatus)}, nil
+	}
+	ids, err := x.source.FilterBlogIDs(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if filterOptions.SortBy == "relevance" {
+		rank(ids, scores)
+	}
+
+	start := min(max(filterOptions.Page-1, 0)*filterOptions.PageSize, len(ids))
+	end := min(start+filterOptions.PageSize, len(ids))
+	blogs, err := x.loadScored(ctx, ids[start:end], scores)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := x.source.GetBlogFacets(ctx, opts, includeStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &contract.BlogSearchPage{Blogs: blogs, Total: int64(len(ids)), Facets: facets}, nil
+}
+
+// SearchRanked returns up to limit matches that pass the filters, best first.
+func (x *InvertedIndex) SearchRanked(ctx context.Context, query string, filterOptions *contract.BlogFilterOptions, limit int) ([]contract.ScoredBlog, error) {
+	scores := x.match(query)
+	opts := restrictTo(scores, filterOptions)
+	if opts == nil {
+		return []contract.ScoredBlog{}, nil
+	}
+	ids, err := x.source.FilterBlogIDs(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	rank(ids, scores)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return x.loadScored(ctx, ids, scores)
+}
+
+// Index adds or replaces the blog's title and content.
+func (x *InvertedIndex) Index(ctx context.Context, blog *entity.Blog) error {
+	doc := newIndexedBlog(blog)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.put(blog.ID, doc)
+	if x.loading {
+		x.pending = append(x.pending, pendingChange{blogID: blog.ID, blog: doc})
+	}
+	return nil
+}
+
+// Remove drops the blog from the index.
+func (x *InvertedIndex) Remove(ctx context.Context, blogID string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.drop(blogID)
+	if x.loading {
+		x.pending = append(x.pending, pendingChange{blogID: blogID})
+	}
+	return nil
+}
+
+// Rebuild reloads every blog from the repository and swaps in a fresh index. Changes
+// indexed while the blogs are loading are replayed on top, so none are lost.
+func (x *InvertedIndex) Rebuild(ctx context.Context) error {
+	x.rebuilding.Lock()
+	defer x.rebuilding.Unlock()
+
+	x.mu.Lock()
+	x.loading, x.pending = true, nil
+	x.mu.Unlock()
+	blogs, err := x.source.ListSearchableBlogs(ctx)
+	if err != nil {
+		x.mu.Lock()
+		x.loading, x.pending = false, nil
+		x.mu.Unlock()
+		return err
+	}
+
+	fresh := NewInvertedIndex(x.source)
+	for _, blog := range blogs {
+		fresh.put(blog.ID, newIndexedBlog(blog))
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, change := range x.pending {
+		if change.blog != nil {
+			fresh.put(change.blogID, change.blog)
+		} else {
+			fresh.drop(change.blogID)
+		}
+	}
+	x.postings, x.docs, x.totalLength = fresh.postings, fresh.docs, fresh.totalLength
+	x.loading, x.pending = false, nil
+	return nil
+}
+
+// match scores every blog matching the query with BM25. Any term may match; every phrase
+// must appear and no excluded term may. A query whose phrases hold nothing but stop words
+// is scored by how often the phrases appear.
+func (x *InvertedIndex) match(query string) map[string]float64 {
+	q := utils.ParseSearchQuery(query)
+	var terms []string
+	for _, w := range strings.Fields(q.Text()) {
+		if t := indexTerm(w); t != "" {
+			terms = append(terms, t)
+		}
+	}
+	sort.Strings(terms)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	scores := make(map[string]float64)
+	n := float64(len(x.docs))
+	if n == 0 {
+		return scores
+	}
+	avgLength := float64(x.totalLength) / n
+	for i, t := range terms {
+		if i > 0 && terms[i-1] == t {
+			continue
+		}
+		posting := x.postings[t]
+		df := float64(len(posting))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range posting {
+			doc := x.docs[id]
+			norm := bm25K1 * (1 - bm25B + bm25B*float64(doc.length)/avgLength)
+			scores[id] += idf * float64(tf) * (bm25K1 + 1) / (float64(tf) + norm)
+		}
+	}
+
+	// Phrases made only of stop words have no postings; look for them in every blog instead
+	if len(terms) == 0 && len(q.Phrases) > 0 {
+		for id, doc := range x.docs {
+			for _, p := range q.Phrases {
+				scores[id] += float64(strings.Count(doc.words, " "+p+" "))
+			}
+		}
+	}
+
+	for id := range scores {
+		doc := x.docs[id]
+		for _, p := range q.Phrases {
+			if !strings.Contains(doc.words, " "+p+" ") {
+				delete(scores, id)
+			}
+		}
+		for _, e := range q.Excluded {
+			if _, ok := doc.terms[indexTerm(e)]; ok {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// restrictTo narrows the filters to the scored blogs, keeping any IDs the filters already
+// restrict to. It returns nil when no scored blog is left.
+func restrictTo(scores map[string]float64, filterOptions *contract.BlogFilterOptions) *contract.BlogFilterOptions {
+	var restrict map[string]bool
+	if len(filterOptions.IDs) > 0 {
+		restrict = make(map[string]bool, len(filterOptions.IDs))
+		for _, id := range filterOptions.IDs {
+			restrict[id] = true
+		}
+	}
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		if restrict == nil || restrict[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	opts := *filterOptions
+	opts.IDs = ids
+	return &opts
+}
+
+// rank orders ids by score, best first, with ties in ID order.
+func rank(ids []string, scores map[string]float64) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// loadScored fetches the blogs with the given IDs and returns them in that order with their
+// scores. Blogs deleted since they were filtered are left out.
+func (x *InvertedIndex) loadScored(ctx context.Context, ids []string, scores map[string]float64) ([]contract.ScoredBlog, error) {
+	if len(ids) == 0 {
+		return []contract.ScoredBlog{}, nil
+	}
+	blogs, err := x.source.GetBlogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Blog, len(blogs))
+	for _, b := range blogs {
+		byID[b.ID] = b
+	}
+	scored := make([]contract.ScoredBlog, 0, len(ids))
+	for _, id := range ids {
+		if b, ok := byID[id]; ok {
+			scored = append(scored, contract.ScoredBlog{Blog: b, Score: scores[id]})
+		}
+	}
+	return scored, nil
+}
+
+// put indexes doc under id, replacing any earlier version. The caller holds the write lock.
+func (x *InvertedIndex) put(id string, doc *indexedBlog) {
+	x.drop(id)
+	x.docs[id] = doc
+	x.totalLength += doc.length
+	for t, tf := range doc.terms {
+		posting := x.postings[t]
+		if posting == nil {
+			posting = make(map[string]int)
+			x.postings[t] = posting
+		}
+		posting[id] = tf
+	}
+}
+
+// drop removes id from the index. The caller holds the write lock.
+func (x *InvertedIndex) drop(id string) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	for t := range doc.terms {
+		delete(x.postings[t], id)
+		if len(x.postings[t]) == 0 {
+			delete(x.postings, t)
+		}
+	}
+	x.totalLength -= doc.length
+	delete(x.docs, id)
+}
+
+func newIndexedBlog(blog *entity.Blog) *indexedBlog {
+	doc := &indexedBlog{terms: make(map[string]int)}
+	title := words(blog.Title)
+	content := words(utils.PlainText(blog.Content))
+	for _, w := range title {
+		if t := indexTerm(w); t != "" {
+			doc.terms[t] += titleWeight
+			doc.length += titleWeight
+		}
+	}
+	for _, w := range content {
+		if t := indexTerm(w); t != "" {
+			doc.terms[t]++
+			doc.length++
+		}
+	}
+	// The separator keeps phrases from spanning the end of the title and start of the content
+	doc.words = " " + strings.Join(title, " ") + " | " + strings.Join(content, " ") + " "
+	return doc
+}
+
+// words splits text into lowercase words the way search queries are split.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// indexTerm turns a lowercase word into the term it is indexed under: stop words are
+// dropped and plurals are reduced to their singular, so "posts" matches "post".
+func indexTerm(word string) string {
+	if utils.IsStopWord(word) {
+		return ""
+	}
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 4 && (strings.HasSuffix(word, "sses") || strings.HasSuffix(word, "xes") ||
+		strings.HasSuffix(word, "ches") || strings.HasSuffix(word, "shes")):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
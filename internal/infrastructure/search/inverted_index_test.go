@@ -0,0 +1,351 @@
+package search_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/search"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource is an in-memory BlogSource. Blogs are filtered by IDs and status and ordered by
+// created_at, newest first.
+type fakeSource struct {
+	blogs map[string]*entity.Blog
+	// onList runs while Rebuild is loading the blogs, after the list is taken
+	onList func()
+	// loaded records the IDs passed to each GetBlogsByIDs call
+	loaded [][]string
+}
+
+func newFakeSource(blogs ...*entity.Blog) *fakeSource {
+	s := &fakeSource{blogs: make(map[string]*entity.Blog)}
+	for _, b := range blogs {
+		s.blogs[b.ID] = b
+	}
+	return s
+}
+
+func (s *fakeSource) ListSearchableBlogs(ctx context.Context, statuses ...entity.BlogStatus) ([]*entity.Blog, error) {
+	blogs := make([]*entity.Blog, 0, len(s.blogs))
+	for _, b := range s.blogs {
+		blogs = append(blogs, b)
+	}
+	if s.onList != nil {
+		s.onList()
+	}
+	return blogs, nil
+}
+
+func (s *fakeSource) FilterBlogIDs(ctx context.Context, filterOptions *contract.BlogFilterOptions) ([]string, error) {
+	blogs := s.filter(filterOptions)
+	sort.Slice(blogs, func(i, j int) bool {
+		if !blogs[i].CreatedAt.Equal(blogs[j].CreatedAt) {
+			return blogs[i].CreatedAt.After(blogs[j].CreatedAt)
+		}
+		return blogs[i].ID < blogs[j].ID
+	})
+	ids := make([]string, len(blogs))
+	for i, b := range blogs {
+		ids[i] = b.ID
+	}
+	return ids, nil
+}
+
+func (s *fakeSource) GetBlogsByIDs(ctx context.Context, blogIDs []string) ([]*entity.Blog, error) {
+	s.loaded = append(s.loaded, blogIDs)
+	var blogs []*entity.Blog
+	for _, id := range blogIDs {
+		if b, ok := s.blogs[id]; ok {
+			blogs = append(blogs, b)
+		}
+	}
+	return blogs, nil
+}
+
+func (s *fakeSource) GetBlogFacets(ctx context.Context, filterOptions *contract.BlogFilterOptions, includeStatus bool) (*contract.BlogFacets, error) {
+	return utils.CountBlogFacets(s.filter(filterOptions), includeStatus), nil
+}
+
+func (s *fakeSource) filter(filterOptions *contract.BlogFilterOptions) []*entity.Blog {
+	var blogs []*entity.Blog
+	for _, b := range s.blogs {
+		if len(filterOptions.IDs) > 0 && !contains(filterOptions.IDs, b.ID) {
+			continue
+		}
+		if len(filterOptions.Statuses) > 0 && !contains(filterOptions.Statuses, b.Status) {
+			continue
+		}
+		blogs = append(blogs, b)
+	}
+	return blogs
+}
+
+func contains[T comparable](list []T, value T) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func newBlog(id, title, content string, age time.Duration) *entity.Blog {
+	return &entity.Blog{
+		ID:        id,
+		Title:     title,
+		Content:   content,
+		AuthorID:  "author-" + id,
+		Status:    entity.BlogStatusPublished,
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age),
+	}
+}
+
+func newIndex(t *testing.T, source *fakeSource) *search.InvertedIndex {
+	t.Helper()
+	x := search.NewInvertedIndex(source)
+	require.NoError(t, x.Rebuild(context.Background()))
+	return x
+}
+
+func rankedIDs(t *testing.T, x *search.InvertedIndex, query string) []string {
+	t.Helper()
+	scored, err := x.SearchRanked(context.Background(), query, &contract.BlogFilterOptions{}, 100)
+	require.NoError(t, err)
+	ids := make([]string, len(scored))
+	for i, s := range scored {
+		ids[i] = s.Blog.ID
+	}
+	return ids
+}
+
+func TestInvertedIndex_SearchRanked(t *testing.T) {
+	source := newFakeSource(
+		newBlog("kube-title", "Kubernetes networking", "How services route traffic between pods.", 0),
+		newBlog("kube-body", "Deploying services", "A short note on kubernetes rollouts and traffic.", time.Hour),
+		newBlog("errors", "Error handling in practice", "Wrap errors with context before handling them upstream.", 2*time.Hour),
+		newBlog("handling-error", "Notes", "Handling error values early keeps stories short.", 3*time.Hour),
+		newBlog("story", "A story about queues", "Every queue tells a story.", 4*time.Hour),
+		newBlog("hamlet", "Soliloquy", "To be or not to be, that is the question. To be sure.", 5*time.Hour),
+	)
+	x := newIndex(t, source)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "title words outrank content words", query: "kubernetes", want: []string{"kube-title", "kube-body"}},
+		{name: "any term may match", query: "traffic queue", want: []string{"story", "kube-title", "kube-body"}},
+		{name: "phrase must appear in order", query: `"error handling"`, want: []string{"errors"}},
+		{name: "phrase plus term", query: `"handling error" values`, want: []string{"handling-error"}},
+		{name: "excluded term drops matches", query: "services -pods", want: []string{"kube-body"}},
+		{name: "excluded phrase drops each word", query: `traffic -"route pods"`, want: []string{"kube-body"}},
+		{name: "plural query finds singular", query: "stories", want: []string{"story", "handling-error"}},
+		{name: "singular query finds plural", query: "error", want: []string{"errors", "handling-error"}},
+		{name: "unknown term", query: "haskell", want: []string{}},
+		{name: "stop words alone match nothing", query: "to be", want: []string{}},
+		{name: "phrase of stop words", query: `"to be"`, want: []string{"hamlet"}},
+		{name: "phrase of stop words keeps its order", query: `"be not"`, want: []string{}},
+		{name: "phrase of stop words with exclusion", query: `"not to be" -question`, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rankedIDs(t, x, tt.query))
+		})
+	}
+}
+
+func TestInvertedIndex_BM25Ordering(t *testing.T) {
+	tests := []struct {
+		name  string
+		blogs []*entity.Blog
+		query string
+		want  []string
+	}{
+		{
+			name: "more occurrences rank higher",
+			blogs: []*entity.Blog{
+				newBlog("once", "Caching", "Redis helps with latency and throughput today.", 0),
+				newBlog("twice", "Caching", "Redis helps with latency; redis also helps throughput.", 0),
+			},
+			query: "redis",
+			want:  []string{"twice", "once"},
+		},
+		{
+			name: "shorter blogs rank higher for the same count",
+			blogs: []*entity.Blog{
+				newBlog("long", "Databases", "Postgres indexes, vacuum tuning, replication, partitioning and backups explained.", 0),
+				newBlog("short", "Databases", "Postgres indexes.", 0),
+			},
+			query: "postgres",
+			want:  []string{"short", "long"},
+		},
+		{
+			name: "rare terms weigh more than common ones",
+			blogs: []*entity.Blog{
+				newBlog("common", "Testing", "Golang testing tips.", 0),
+				newBlog("rare", "Testing", "Fuzzing tips.", 0),
+				newBlog("filler-1", "Testing", "Golang tooling tips.", 0),
+				newBlog("filler-2", "Testing", "Golang modules tips.", 0),
+			},
+			query: "golang fuzzing",
+			want:  []string{"rare", "common", "filler-1", "filler-2"},
+		},
+		{
+			name: "equal scores fall back to ID order",
+			blogs: []*entity.Blog{
+				newBlog("b", "Same", "Identical words here.", 0),
+				newBlog("a", "Same", "Identical words here.", time.Hour),
+			},
+			query: "identical",
+			want:  []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := newIndex(t, newFakeSource(tt.blogs...))
+			assert.Equal(t, tt.want, rankedIDs(t, x, tt.query))
+		})
+	}
+}
+
+func TestInvertedIndex_IndexAndRemove(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource()
+	x := newIndex(t, source)
+
+	blog := newBlog("post", "Graph databases", "Neo4j stores nodes and edges.", 0)
+	source.blogs[blog.ID] = blog
+	require.NoError(t, x.Index(ctx, blog))
+	assert.Equal(t, []string{"post"}, rankedIDs(t, x, "neo4j"))
+
+	edited := newBlog("post", "Graph databases", "Dgraph stores nodes and edges.", 0)
+	source.blogs[edited.ID] = edited
+	require.NoError(t, x.Index(ctx, edited))
+	assert.Empty(t, rankedIDs(t, x, "neo4j"), "the old content is no longer indexed")
+	assert.Equal(t, []string{"post"}, rankedIDs(t, x, "dgraph"))
+	assert.Equal(t, []string{"post"}, rankedIDs(t, x, "nodes"), "re-indexing does not duplicate the blog")
+
+	require.NoError(t, x.Remove(ctx, "post"))
+	assert.Empty(t, rankedIDs(t, x, "dgraph"))
+	require.NoError(t, x.Remove(ctx, "post"), "removing twice is harmless")
+}
+
+func TestInvertedIndex_RebuildKeepsConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource(
+		newBlog("kept", "Rust ownership", "Borrowing rules.", 0),
+		newBlog("removed", "Rust lifetimes", "Borrowing across functions.", 0),
+		newBlog("edited", "Rust traits", "Generic bounds.", 0),
+	)
+	x := newIndex(t, source)
+
+	added := newBlog("added", "Rust macros", "Borrowing inside macros.", 0)
+	edited := newBlog("edited", "Rust traits", "Dynamic dispatch.", 0)
+	source.onList = func() {
+		// These land after the rebuild took its list, so it loads the stale versions
+		source.onList = nil
+		source.blogs[added.ID], source.blogs[edited.ID] = added, edited
+		delete(source.blogs, "removed")
+		require.NoError(t, x.Index(ctx, added))
+		require.NoError(t, x.Index(ctx, edited))
+		require.NoError(t, x.Remove(ctx, "removed"))
+	}
+	require.NoError(t, x.Rebuild(ctx))
+
+	assert.ElementsMatch(t, []string{"added", "kept"}, rankedIDs(t, x, "borrowing"))
+	assert.Equal(t, []string{"edited"}, rankedIDs(t, x, "dispatch"))
+	assert.Empty(t, rankedIDs(t, x, "generic"))
+}
+
+func TestInvertedIndex_SearchPagesInsideTheIndex(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource(
+		newBlog("p1", "Svelte basics", "Svelte components.", 0),
+		newBlog("p2", "Svelte stores", "Svelte state.", time.Hour),
+		newBlog("p3", "Svelte routing", "Svelte pages.", 2*time.Hour),
+		newBlog("p4", "Svelte testing", "Svelte specs.", 3*time.Hour),
+		newBlog("other", "React hooks", "Hooks and state.", 0),
+	)
+	draft := newBlog("draft", "Svelte drafts", "Svelte unpublished.", 0)
+	draft.Status = entity.BlogStatusDraft
+	source.blogs[draft.ID] = draft
+	x := newIndex(t, source)
+
+	tests := []struct {
+		name      string
+		opts      contract.BlogFilterOptions
+		wantIDs   []string
+		wantTotal int64
+	}{
+		{
+			name:      "first page in source order",
+			opts:      contract.BlogFilterOptions{Page: 1, PageSize: 2, Statuses: []entity.BlogStatus{entity.BlogStatusPublished}},
+			wantIDs:   []string{"p1", "p2"},
+			wantTotal: 4,
+		},
+		{
+			name:      "last page is short",
+			opts:      contract.BlogFilterOptions{Page: 2, PageSize: 3, Statuses: []entity.BlogStatus{entity.BlogStatusPublished}},
+			wantIDs:   []string{"p4"},
+			wantTotal: 4,
+		},
+		{
+			name:      "page past the end",
+			opts:      contract.BlogFilterOptions{Page: 5, PageSize: 2, Statuses: []entity.BlogStatus{entity.BlogStatusPublished}},
+			wantIDs:   []string{},
+			wantTotal: 4,
+		},
+		{
+			name:      "restricted to given IDs",
+			opts:      contract.BlogFilterOptions{Page: 1, PageSize: 10, IDs: []string{"p3", "draft", "other"}},
+			wantIDs:   []string{"draft", "p3"},
+			wantTotal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source.loaded = nil
+			page, err := x.Search(ctx, "svelte", &tt.opts, false)
+			require.NoError(t, err)
+
+			ids := make([]string, len(page.Blogs))
+			for i, s := range page.Blogs {
+				ids[i] = s.Blog.ID
+				assert.Positive(t, s.Score)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantTotal, page.Total)
+
+			var authors int64
+			for _, f := range page.Facets.Authors {
+				authors += f.Count
+			}
+			assert.Equal(t, tt.wantTotal, authors, "facets count every match, not just the page")
+			if len(tt.wantIDs) == 0 {
+				assert.Empty(t, source.loaded)
+			} else {
+				assert.Equal(t, [][]string{tt.wantIDs}, source.loaded, "only the page is loaded")
+			}
+		})
+	}
+}
+
+func TestInvertedIndex_SearchWithoutMatches(t *testing.T) {
+	source := newFakeSource(newBlog("p1", "Elixir", "Processes.", 0))
+	x := newIndex(t, source)
+
+	page, err := x.Search(context.Background(), "erlang", &contract.BlogFilterOptions{Page: 1, PageSize: 10}, true)
+	require.NoError(t, err)
+	assert.Empty(t, page.Blogs)
+	assert.Zero(t, page.Total)
+	assert.Empty(t, page.Facets.Statuses)
+	assert.Empty(t, source.loaded)
+}
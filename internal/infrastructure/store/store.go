@@ -13,16 +13,18 @@ import (
 )
 
 type BlogCacheStore struct {
-	rdb       *redis.Client
-	detailTTL time.Duration
-	listTTL   time.Duration
+	rdb        *redis.Client
+	detailTTL  time.Duration
+	listTTL    time.Duration
+	relatedTTL time.Duration
 }
 
 func NewBlogCacheStore(rdb *redis.Client) *BlogCacheStore {
 	return &BlogCacheStore{
-		rdb:       rdb,
-		detailTTL: 60 * time.Minute, // 60 minutes
-		listTTL:   30 * time.Minute, // 30 minutes
+		rdb:        rdb,
+		detailTTL:  60 * time.Minute, // 60 minutes
+		listTTL:    30 * time.Minute, // 30 minutes
+		relatedTTL: 6 * time.Hour,    // refreshed by the related posts job
 	}
 }
 
@@ -98,6 +100,32 @@ func (c *BlogCacheStore) InvalidateBlogLists(ctx context.Context) error {
 	return nil
 }
 
+// --- Related Posts ---
+func relatedPostsKey(blogID string) string { return fmt.Sprintf("blog:related:%s", blogID) }
+
+func (c *BlogCacheStore) GetRelatedPosts(ctx context.Context, blogID string) ([]entity.RelatedPost, bool, error) {
+	b, err := c.rdb.Get(ctx, relatedPostsKey(blogID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var related []entity.RelatedPost
+	if err := json.Unmarshal(b, &related); err != nil {
+		return nil, false, nil
+	}
+	return related, true, nil
+}
+
+func (c *BlogCacheStore) SetRelatedPosts(ctx context.Context, blogID string, related []entity.RelatedPost) error {
+	data, err := json.Marshal(related)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, relatedPostsKey(blogID), data, c.relatedTTL).Err()
+}
+
 // --- Fraud Detection Caching ---
 // Use Redis sets with TTL for recent views by IP and by User
 func recentViewsByIPKey(ip string) string { return fmt.Sprintf("blog:recentviews:ip:%s", ip) }
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+)
+
+const (
+	pendingViewsKey = "blog:views:pending"
+	claimedViewsKey = "blog:views:claimed"
+	claimedBatchKey = "blog:views:claimed-batch"
+	viewFlushLock   = "blog:views:flush-lock"
+	// viewFlushLockTTL bounds how long a crashed flusher can hold up the others.
+	viewFlushLockTTL = time.Minute
+)
+
+// claimViewsScript moves the pending hash aside and names the batch, unless a batch left over
+// from a failed flush is still waiting, which is then retried under its old name. It returns
+// the batch ID, or false when there is nothing to flush.
+var claimViewsScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 0 then
+	if redis.call("EXISTS", KEYS[1]) == 0 then
+		return false
+	end
+	redis.call("RENAME", KEYS[1], KEYS[2])
+	redis.call("SET", KEYS[3], ARGV[1])
+end
+return redis.call("GET", KEYS[3]) or ARGV[1]
+`)
+
+// releaseViewsScript drops the claimed batch and the lock, but only while the lock still
+// holds the caller's token.
+var releaseViewsScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[1], KEYS[2], KEYS[3])
+return 1
+`)
+
+// errViewClaimLost is returned by Release when the flush lock expired and may now belong to
+// another instance. The batch is left for that instance, which skips it once it sees the
+// batch was applied.
+var errViewClaimLost = errors.New("view flush lock expired before the batch was released")
+
+// RedisViewBuffer buffers view increments in a Redis hash shared by every API instance.
+type RedisViewBuffer struct {
+	rdb *redis.Client
+}
+
+var _ contract.IViewCountBuffer = (*RedisViewBuffer)(nil)
+
+func NewRedisViewBuffer(rdb *redis.Client) *RedisViewBuffer {
+	return &RedisViewBuffer{rdb: rdb}
+}
+
+func (b *RedisViewBuffer) Add(ctx context.Context, blogID string) error {
+	return b.rdb.HIncrBy(ctx, pendingViewsKey, blogID, 1).Err()
+}
+
+// Claim moves the pending hash aside under a short-lived lock, so only one instance flushes
+// a batch. It returns nil when there is nothing to flush or another instance is flushing.
+func (b *RedisViewBuffer) Claim(ctx context.Context) (*contract.ViewBatch, error) {
+	token := uuid.NewString()
+	locked, err := b.rdb.SetNX(ctx, viewFlushLock, token, viewFlushLockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	batchID, err := claimViewsScript.Run(ctx, b.rdb,
+		[]string{pendingViewsKey, claimedViewsKey, claimedBatchKey}, uuid.NewString()).Text()
+	if err == redis.Nil {
+		return nil, releaseViewsScript.Run(ctx, b.rdb, []string{viewFlushLock}, token).Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := b.rdb.HGetAll(ctx, claimedViewsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(raw))
+	for blogID, v := range raw {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			counts[blogID] = n
+		}
+	}
+	return &contract.ViewBatch{ID: batchID, Token: token, Counts: counts}, nil
+}
+
+func (b *RedisViewBuffer) Release(ctx context.Context, batch *contract.ViewBatch) error {
+	released, err := releaseViewsScript.Run(ctx, b.rdb,
+		[]string{viewFlushLock, claimedViewsKey, claimedBatchKey}, batch.Token).Int()
+	if err != nil {
+		return err
+	}
+	if released == 0 {
+		return errViewClaimLost
+	}
+	return nil
+}
+
+// MemoryViewBuffer buffers view increments in process memory. It is used when Redis is not
+// configured; buffered views are lost if the process dies before a flush.
+type MemoryViewBuffer struct {
+	mu      sync.Mutex
+	pending map[string]int64
+	claimed *contract.ViewBatch
+}
+
+var _ contract.IViewCountBuffer = (*MemoryViewBuffer)(nil)
+
+func NewMemoryViewBuffer() *MemoryViewBuffer {
+	return &MemoryViewBuffer{pending: make(map[string]int64)}
+}
+
+func (b *MemoryViewBuffer) Add(ctx context.Context, blogID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[blogID]++
+	return nil
+}
+
+func (b *MemoryViewBuffer) Claim(ctx context.Context) (*contract.ViewBatch, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.claimed == nil {
+		if len(b.pending) == 0 {
+			return nil, nil
+		}
+		b.claimed = &contract.ViewBatch{ID: uuid.NewString(), Counts: b.pending}
+		b.pending = make(map[string]int64)
+	}
+	counts := make(map[string]int64, len(b.claimed.Counts))
+	for blogID, n := range b.claimed.Counts {
+		counts[blogID] = n
+	}
+	return &contract.ViewBatch{ID: b.claimed.ID, Counts: counts}, nil
+}
+
+func (b *MemoryViewBuffer) Release(ctx context.Context, batch *contract.ViewBatch) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.claimed != nil && b.claimed.ID == batch.ID {
+		b.claimed = nil
+	}
+	return nil
+}
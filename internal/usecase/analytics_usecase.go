@@ -0,0 +1,414 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// maxRollupCatchUp is how far back a rollup run reaches. Raw views expire after 24 hours,
+	// so older hours can no longer be rolled up completely.
+	maxRollupCatchUp = 22 * time.Hour
+	// maxHourlyAnalyticsRange and maxDailyAnalyticsRange cap the period of one analytics query.
+	maxHourlyAnalyticsRange = 7 * 24 * time.Hour
+	maxDailyAnalyticsRange  = 366 * 24 * time.Hour
+	defaultAnalyticsRange   = 30 * 24 * time.Hour
+	topPostsLimit           = 10
+	maxBreakdownListed      = 20
+)
+
+// AnalyticsQuery selects the period and blogs of an author analytics request. Zero values
+// fall back to the last 30 days (24 hours for hourly data) across all of the author's blogs.
+type AnalyticsQuery struct {
+	BlogID      string
+	Granularity string
+	From        *time.Time
+	To          *time.Time
+}
+
+// AnalyticsUsecase rolls raw blog activity up into hourly and daily buckets before it
+// expires, and serves author analytics from those rollups.
+type AnalyticsUsecase struct {
+	analyticsRepo contract.IAnalyticsRepository
+	blogRepo      contract.IBlogRepository
+	logger        usecasecontract.IAppLogger
+	running       sync.Mutex
+}
+
+// NewAnalyticsUsecase creates a new AnalyticsUsecase.
+func NewAnalyticsUsecase(analyticsRepo contract.IAnalyticsRepository, blogRepo contract.IBlogRepository, logger usecasecontract.IAppLogger) *AnalyticsUsecase {
+	return &AnalyticsUsecase{analyticsRepo: analyticsRepo, blogRepo: blogRepo, logger: logger}
+}
+
+// Start rolls up now and then every interval until ctx is cancelled. A non-positive
+// interval disables the schedule.
+func (u *AnalyticsUsecase) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	runPeriodically(ctx, interval, u.logger, "analytics rollup", u.RollUp)
+}
+
+// RollUp processes every complete hour since the last run, oldest first. Each hour updates
+// its hourly buckets and then rebuilds the daily buckets of the day it belongs to.
+func (u *AnalyticsUsecase) RollUp(ctx context.Context) error {
+	if !u.running.TryLock() {
+		return nil
+	}
+	defer u.running.Unlock()
+
+	lastComplete := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	watermark, err := u.analyticsRepo.GetRollupWatermark(ctx)
+	if err != nil {
+		return err
+	}
+	next := watermark.UTC().Add(time.Hour)
+	if earliest := lastComplete.Add(-maxRollupCatchUp); next.Before(earliest) {
+		next = earliest
+	}
+
+	for hour := next; !hour.After(lastComplete); hour = hour.Add(time.Hour) {
+		if err := u.rollUpHour(ctx, hour); err != nil {
+			return fmt.Errorf("failed to roll up %s: %w", hour.Format(time.RFC3339), err)
+		}
+		if err := u.analyticsRepo.SetRollupWatermark(ctx, hour); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *AnalyticsUsecase) rollUpHour(ctx context.Context, hour time.Time) error {
+	end := hour.Add(time.Hour)
+	views, err := u.analyticsRepo.GroupViews(ctx, hour, end)
+	if err != nil {
+		return err
+	}
+	reactions, err := u.analyticsRepo.CountReactions(ctx, hour, end)
+	if err != nil {
+		return err
+	}
+	comments, err := u.analyticsRepo.CountComments(ctx, hour, end)
+	if err != nil {
+		return err
+	}
+
+	buckets := make(map[string]*entity.BlogAnalyticsBucket)
+	bucket := func(blogID string) *entity.BlogAnalyticsBucket {
+		b, ok := buckets[blogID]
+		if !ok {
+			b = &entity.BlogAnalyticsBucket{BlogID: blogID, Start: hour}
+			buckets[blogID] = b
+		}
+		return b
+	}
+
+	viewers := make(map[string]map[string]struct{})
+	referrers := make(map[string]map[string]int64)
+	userAgents := make(map[string]map[string]int64)
+	networks := make(map[string]map[string]int64)
+	for _, v := range views {
+		bucket(v.BlogID).Views += v.Views
+		if viewers[v.BlogID] == nil {
+			viewers[v.BlogID] = make(map[string]struct{})
+			referrers[v.BlogID] = make(map[string]int64)
+			userAgents[v.BlogID] = make(map[string]int64)
+			networks[v.BlogID] = make(map[string]int64)
+		}
+		viewers[v.BlogID][utils.ViewerKey(v.UserID, v.IPHash)] = struct{}{}
+		referrers[v.BlogID][utils.ReferrerHost(v.Referrer)] += v.Views
+		// Views from readers who opted out of tracking carry neither
+		userAgents[v.BlogID][orUnknown(v.UserAgentFamily)] += v.Views
+		networks[v.BlogID][orUnknown(v.IPPrefix)] += v.Views
+	}
+	for blogID, byType := range reactions {
+		bucket(blogID).Reactions = byType
+	}
+	for blogID, n := range comments {
+		bucket(blogID).Comments = n
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	blogIDs := make([]string, 0, len(buckets))
+	for blogID := range buckets {
+		blogIDs = append(blogIDs, blogID)
+	}
+	blogs, err := u.blogRepo.GetBlogsByIDs(ctx, blogIDs)
+	if err != nil {
+		return err
+	}
+
+	// Blogs deleted since are left out; nobody can ask for their analytics
+	hourly := make([]*entity.BlogAnalyticsBucket, 0, len(blogs))
+	dailyViewers := make(map[string][]string, len(viewers))
+	for _, blog := range blogs {
+		blogID := blog.ID
+		b := buckets[blogID]
+		b.AuthorID = blog.AuthorID
+		b.UniqueViewers = int64(len(viewers[blogID]))
+		b.Referrers = toCountEntries(referrers[blogID])
+		b.UserAgents = toCountEntries(userAgents[blogID])
+		b.Networks = toCountEntries(networks[blogID])
+		hourly = append(hourly, b)
+		for key := range viewers[blogID] {
+			dailyViewers[blogID] = append(dailyViewers[blogID], key)
+		}
+	}
+	if err := u.analyticsRepo.SaveBuckets(ctx, entity.AnalyticsGranularityHour, hourly); err != nil {
+		return err
+	}
+
+	day := hour.Truncate(24 * time.Hour)
+	if err := u.analyticsRepo.AddDailyViewers(ctx, day, dailyViewers); err != nil {
+		return err
+	}
+	return u.rebuildDay(ctx, day, hourly)
+}
+
+// rebuildDay recomputes the daily buckets of the given blogs from their hourly buckets.
+// Unique viewers come from the per-day viewer record, since hourly uniques overlap.
+func (u *AnalyticsUsecase) rebuildDay(ctx context.Context, day time.Time, blogs []*entity.BlogAnalyticsBucket) error {
+	blogIDs := make([]string, len(blogs))
+	for i, b := range blogs {
+		blogIDs[i] = b.BlogID
+	}
+	uniques, err := u.analyticsRepo.CountDailyViewers(ctx, day, blogIDs)
+	if err != nil {
+		return err
+	}
+
+	daily := make([]*entity.BlogAnalyticsBucket, 0, len(blogs))
+	for _, b := range blogs {
+		hours, err := u.analyticsRepo.ListBuckets(ctx, entity.AnalyticsGranularityHour, contract.AnalyticsBucketFilter{
+			AuthorID: b.AuthorID,
+			BlogID:   b.BlogID,
+			From:     day,
+			To:       day.Add(24 * time.Hour),
+		})
+		if err != nil {
+			return err
+		}
+		merged := mergeBuckets(hours)
+		merged.BlogID, merged.AuthorID, merged.Start = b.BlogID, b.AuthorID, day
+		merged.UniqueViewers = uniques[b.BlogID]
+		daily = append(daily, merged)
+	}
+	return u.analyticsRepo.SaveBuckets(ctx, entity.AnalyticsGranularityDay, daily)
+}
+
+// GetAuthorAnalytics returns an author's blog activity over a period: totals, a time series
+// per blog, referrer and browser breakdowns and the top posts.
+func (u *AnalyticsUsecase) GetAuthorAnalytics(ctx context.Context, authorID string, query AnalyticsQuery) (*dto.AuthorAnalyticsResponse, error) {
+	granularity := entity.AnalyticsGranularity(query.Granularity)
+	var step, maxRange, defaultRange time.Duration
+	switch granularity {
+	case "", entity.AnalyticsGranularityDay:
+		granularity, step, maxRange, defaultRange = entity.AnalyticsGranularityDay, 24*time.Hour, maxDailyAnalyticsRange, defaultAnalyticsRange
+	case entity.AnalyticsGranularityHour:
+		step, maxRange, defaultRange = time.Hour, maxHourlyAnalyticsRange, 24*time.Hour
+	default:
+		return nil, fmt.Errorf("invalid granularity %q: use hour or day", query.Granularity)
+	}
+
+	to := time.Now().UTC()
+	if query.To != nil {
+		to = query.To.UTC()
+	}
+	from := to.Add(-defaultRange)
+	if query.From != nil {
+		from = query.From.UTC()
+	}
+	from = from.Truncate(step)
+	if !to.Equal(to.Truncate(step)) {
+		to = to.Truncate(step).Add(step)
+	}
+	if !from.Before(to) {
+		return nil, errors.New("invalid period: from must be before to")
+	}
+	if to.Sub(from) > maxRange {
+		return nil, fmt.Errorf("invalid period: at most %d days of %s data per request", int(maxRange.Hours()/24), granularity)
+	}
+
+	if query.BlogID != "" {
+		blog, err := u.blogRepo.GetBlogByID(ctx, query.BlogID)
+		if err != nil {
+			return nil, fmt.Errorf("blog not found: %w", err)
+		}
+		if blog.AuthorID != authorID {
+			return nil, errors.New("unauthorized: analytics are only available to the blog author")
+		}
+	}
+
+	buckets, err := u.analyticsRepo.ListBuckets(ctx, granularity, contract.AnalyticsBucketFilter{
+		AuthorID: authorID,
+		BlogID:   query.BlogID,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AuthorAnalyticsResponse{
+		From:        from,
+		To:          to,
+		Granularity: string(granularity),
+		Blogs:       []*dto.BlogAnalytics{},
+	}
+
+	byBlog := make(map[string][]*entity.BlogAnalyticsBucket)
+	var order []string
+	for _, b := range buckets {
+		if _, ok := byBlog[b.BlogID]; !ok {
+			order = append(order, b.BlogID)
+		}
+		byBlog[b.BlogID] = append(byBlog[b.BlogID], b)
+	}
+
+	overall := mergeBuckets(buckets)
+	for _, blogID := range order {
+		blogBuckets := byBlog[blogID]
+		analytics := &dto.BlogAnalytics{BlogID: blogID, Series: buildSeries(blogBuckets, from, to, step)}
+		if blog, err := u.blogRepo.GetBlogByID(ctx, blogID); err == nil {
+			analytics.Title, analytics.Slug = blog.Title, blog.Slug
+		}
+		for _, b := range blogBuckets {
+			addTotals(&analytics.Totals, b)
+		}
+		resp.Totals.Views += analytics.Totals.Views
+		resp.Totals.UniqueViewers += analytics.Totals.UniqueViewers
+		resp.Totals.Reactions += analytics.Totals.Reactions
+		resp.Totals.Comments += analytics.Totals.Comments
+		resp.Blogs = append(resp.Blogs, analytics)
+	}
+
+	top := make([]*dto.BlogAnalytics, len(resp.Blogs))
+	copy(top, resp.Blogs)
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Totals.Views != top[j].Totals.Views {
+			return top[i].Totals.Views > top[j].Totals.Views
+		}
+		return top[i].Totals.Reactions+top[i].Totals.Comments > top[j].Totals.Reactions+top[j].Totals.Comments
+	})
+	if len(top) > topPostsLimit {
+		top = top[:topPostsLimit]
+	}
+	resp.TopPosts = make([]*dto.BlogAnalytics, len(top))
+	for i, b := range top {
+		resp.TopPosts[i] = &dto.BlogAnalytics{BlogID: b.BlogID, Title: b.Title, Slug: b.Slug, Totals: b.Totals}
+	}
+
+	resp.Referrers = toBreakdown(overall.Referrers, maxBreakdownListed)
+	resp.UserAgents = toBreakdown(overall.UserAgents, 0)
+	resp.Networks = toBreakdown(overall.Networks, maxBreakdownListed)
+	return resp, nil
+}
+
+// buildSeries lays the buckets out on a regular grid from from to to, filling gaps with zeros.
+func buildSeries(buckets []*entity.BlogAnalyticsBucket, from, to time.Time, step time.Duration) []dto.AnalyticsPoint {
+	byStart := make(map[time.Time]*entity.BlogAnalyticsBucket, len(buckets))
+	for _, b := range buckets {
+		byStart[b.Start.UTC()] = b
+	}
+	series := make([]dto.AnalyticsPoint, 0, int(to.Sub(from)/step))
+	for t := from; t.Before(to); t = t.Add(step) {
+		point := dto.AnalyticsPoint{Start: t}
+		if b, ok := byStart[t]; ok {
+			addTotals(&point.AnalyticsTotals, b)
+		}
+		series = append(series, point)
+	}
+	return series
+}
+
+func addTotals(totals *dto.AnalyticsTotals, b *entity.BlogAnalyticsBucket) {
+	totals.Views += b.Views
+	totals.UniqueViewers += b.UniqueViewers
+	totals.Comments += b.Comments
+	for _, n := range b.Reactions {
+		totals.Reactions += n
+	}
+}
+
+// mergeBuckets sums buckets into one. Unique viewers are summed too; callers that know
+// better overwrite them.
+func mergeBuckets(buckets []*entity.BlogAnalyticsBucket) *entity.BlogAnalyticsBucket {
+	merged := &entity.BlogAnalyticsBucket{}
+	reactions := make(map[string]int64)
+	referrers := make(map[string]int64)
+	userAgents := make(map[string]int64)
+	networks := make(map[string]int64)
+	for _, b := range buckets {
+		merged.Views += b.Views
+		merged.UniqueViewers += b.UniqueViewers
+		merged.Comments += b.Comments
+		for t, n := range b.Reactions {
+			reactions[t] += n
+		}
+		for _, e := range b.Referrers {
+			referrers[e.Key] += e.Count
+		}
+		for _, e := range b.UserAgents {
+			userAgents[e.Key] += e.Count
+		}
+		for _, e := range b.Networks {
+			networks[e.Key] += e.Count
+		}
+	}
+	if len(reactions) > 0 {
+		merged.Reactions = reactions
+	}
+	merged.Referrers = toCountEntries(referrers)
+	merged.UserAgents = toCountEntries(userAgents)
+	merged.Networks = toCountEntries(networks)
+	return merged
+}
+
+func orUnknown(key string) string {
+	if key == "" {
+		return "unknown"
+	}
+	return key
+}
+
+// toCountEntries turns a count map into entries sorted by count, highest first.
+func toCountEntries(counts map[string]int64) []entity.CountEntry {
+	if len(counts) == 0 {
+		return nil
+	}
+	entries := make([]entity.CountEntry, 0, len(counts))
+	for key, n := range counts {
+		entries = append(entries, entity.CountEntry{Key: key, Count: n})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// toBreakdown converts sorted entries for a response, keeping at most limit (0 keeps all).
+func toBreakdown(entries []entity.CountEntry, limit int) []dto.BreakdownEntry {
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	breakdown := make([]dto.BreakdownEntry, len(entries))
+	for i, e := range entries {
+		breakdown[i] = dto.BreakdownEntry{Key: e.Key, Count: e.Count}
+	}
+	return breakdown
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+)
+
+// runPeriodically runs job in the background now and then every interval until ctx is
+// cancelled, logging failures under name. The interval must be positive; callers decide
+// what a zero interval means for their job.
+func runPeriodically(ctx context.Context, interval time.Duration, logger usecasecontract.IAppLogger, name string, job func(context.Context) error) {
+	go func() {
+		runJob(ctx, logger, name, job)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			runJob(ctx, logger, name, job)
+		}
+	}()
+}
+
+// runJob runs job once, logging a failure under name.
+func runJob(ctx context.Context, logger usecasecontract.IAppLogger, name string, job func(context.Context) error) {
+	if err := job(ctx); err != nil {
+		logger.Errorf("%s failed: %v", name, err)
+	}
+}
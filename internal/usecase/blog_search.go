@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// searchSortRelevance orders search results by score; it is the default with a query
+	searchSortRelevance = "relevance"
+	// blogSnippetLength is the length, in runes, of the content snippet shown per search hit
+	blogSnippetLength = 240
+)
+
+// searchHit is one blog's scores while semantic or hybrid results are being ranked.
+type searchHit struct {
+	blog     *entity.Blog
+	keyword  float64 // text score relative to the best keyword match, 0..1
+	semantic float64 // cosine similarity to the query
+	score    float64
+}
+
+// correctSearchQuery looks up each unknown term of the query in the search vocabulary. It
+// returns the query expanded with the corrections, which matches both spellings, and the
+// query with the terms replaced, to suggest back to the reader.
+func (uc *BlogUseCaseImpl) correctSearchQuery(query utils.SearchQuery) (expanded, corrected utils.SearchQuery) {
+	expanded, corrected = query, query
+	if uc.vocabulary == nil {
+		return expanded, corrected
+	}
+	expanded.Terms = append([]string{}, query.Terms...)
+	corrected.Terms = append([]string{}, query.Terms...)
+	for i, term := range query.Terms {
+		if word, ok := uc.vocabulary.Correct(term); ok {
+			expanded.Terms = append(expanded.Terms, word)
+			corrected.Terms[i] = word
+		}
+	}
+	return expanded, corrected
+}
+
+// rankedSearch ranks the blogs matching the filters by semantic similarity alone or, in
+// hybrid mode, by a weighted blend with the keyword score. It returns the requested page,
+// ordered by score or the requested sort, and the facets of every ranked match.
+func (uc *BlogUseCaseImpl) rankedSearch(ctx context.Context, query utils.SearchQuery, mode string, filterOptions *contract.BlogFilterOptions, includeStatus bool) ([]contract.ScoredBlog, int64, *contract.BlogFacets, error) {
+	matches, err := uc.semantic.Search(ctx, query.Text(), searchCandidates)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	hits := make(map[string]*searchHit)
+	if mode == SearchModeHybrid {
+		keyword, err := uc.searchIndex.SearchRanked(ctx, query.String(), filterOptions, searchCandidates)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		var best float64
+		for _, k := range keyword {
+			best = max(best, k.Score)
+		}
+		for _, k := range keyword {
+			hits[k.Blog.ID] = &searchHit{blog: k.Blog, keyword: k.Score / best}
+		}
+	}
+
+	// Load the semantic matches not already found by keyword, applying the same filters.
+	// The search index enforces phrases and exclusions for keyword matches; check them here.
+	var missing []string
+	for _, m := range matches {
+		if _, ok := hits[m.BlogID]; !ok {
+			missing = append(missing, m.BlogID)
+		}
+	}
+	if len(missing) > 0 {
+		opts := *filterOptions
+		opts.IDs, opts.Page, opts.PageSize = missing, 1, len(missing)
+		blogs, _, err := uc.blogRepo.GetBlogs(ctx, &opts)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		for _, b := range blogs {
+			if query.Matches(b.Title + " " + utils.PlainText(b.Content)) {
+				hits[b.ID] = &searchHit{blog: b}
+			}
+		}
+	}
+	for _, m := range matches {
+		if h, ok := hits[m.BlogID]; ok {
+			h.semantic = m.Similarity
+		}
+	}
+
+	keywordWeight := 0.0
+	if mode == SearchModeHybrid {
+		keywordWeight = uc.semantic.KeywordWeight()
+	}
+	ranked := make([]contract.ScoredBlog, 0, len(hits))
+	blogs := make([]*entity.Blog, 0, len(hits))
+	for _, h := range hits {
+		h.score = keywordWeight*h.keyword + (1-keywordWeight)*h.semantic
+		if h.score > 0 {
+			ranked = append(ranked, contract.ScoredBlog{Blog: h.blog, Score: h.score})
+			blogs = append(blogs, h.blog)
+		}
+	}
+	utils.SortScoredBlogs(ranked, filterOptions.SortBy, filterOptions.SortOrder)
+
+	start := min((filterOptions.Page-1)*filterOptions.PageSize, len(ranked))
+	end := min(start+filterOptions.PageSize, len(ranked))
+	return ranked[start:end], int64(len(ranked)), utils.CountBlogFacets(blogs, includeStatus), nil
+}
+
+// RebuildSearchIndex reindexes every blog in the search backend from scratch.
+func (uc *BlogUseCaseImpl) RebuildSearchIndex(ctx context.Context) error {
+	if err := uc.searchIndex.Rebuild(ctx); err != nil {
+		uc.logger.Errorf("failed to rebuild search index: %v", err)
+		return fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+	return nil
+}
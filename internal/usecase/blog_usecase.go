@@ -10,6 +10,7 @@ import (
 
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/infrastructure/metrics"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
@@ -22,20 +23,29 @@ type IBlogUseCase interface {
 	GetBlogDetail(cnt context.Context, slug string) (blog entity.Blog, err error)
 	UpdateBlog(ctx context.Context, blogID, authorID string, title *string, content *string, status *entity.BlogStatus, featuredImageID *string) (*entity.Blog, error)
 	DeleteBlog(ctx context.Context, blogID, userID string, isAdmin bool) (bool, error)
-	SearchAndFilterBlogs(ctx context.Context, query string, tags []string, dateFrom *time.Time, dateTo *time.Time, minViews *int, maxViews *int, minLikes *int, maxLikes *int, authorID *string, page int, pageSize int) ([]entity.Blog, int, int, int, error)
-	TrackBlogView(ctx context.Context, blogID, userID, ipAddress, userAgent string) error
+	SearchAndFilterBlogs(ctx context.Context, req dto.SearchBlogsRequest, viewerID string) (*usecasecontract.BlogSearchResult, error)
+	TrackBlogView(ctx context.Context, blogID string, req dto.TrackViewRequest) error
 	GetPopularBlogs(ctx context.Context, page, pageSize int) ([]entity.Blog, int, int, int, error)
+	RebuildSearchIndex(ctx context.Context) error
 }
 
 // BlogStatus is defined in entity.BlogStatus
 
 // BlogUseCaseImpl implements the BlogUseCase interface
 type BlogUseCaseImpl struct {
-	blogRepo  contract.IBlogRepository
-	uuidgen   contract.IUUIDGenerator
-	logger    usecasecontract.IAppLogger
-	aiUC      usecasecontract.IAIUseCase
-	blogCache contract.IBlogCache
+	blogRepo      contract.IBlogRepository
+	searchIndex   contract.IBlogSearchIndex
+	uuidgen       contract.IUUIDGenerator
+	logger        usecasecontract.IAppLogger
+	aiUC          usecasecontract.IAIUseCase
+	blogCache     contract.IBlogCache
+	notifications *NotificationUsecase
+	viewBuffer    contract.IViewCountBuffer // batches view counter writes when set
+	viewFraud     *ViewFraudUsecase         // screens views for bots and abuse when set
+	semantic      *SemanticSearchUsecase    // enables semantic and hybrid search when set
+	vocabulary    *SearchVocabulary         // corrects misspelled search terms when set
+	ipHashSecret  []byte                    // keys the daily IP hashes stored with views
+	uow           contract.IUnitOfWork      // applies a batch of view counts atomically
 	// simple metrics
 	detailHits uint64
 	detailMiss uint64
@@ -44,12 +54,15 @@ type BlogUseCaseImpl struct {
 }
 
 // NewBlogUseCase creates a new instance of BlogUseCase
-func NewBlogUseCase(blogRepo contract.IBlogRepository, uuidgenrator contract.IUUIDGenerator, logger usecasecontract.IAppLogger, aiUC usecasecontract.IAIUseCase) *BlogUseCaseImpl {
+func NewBlogUseCase(blogRepo contract.IBlogRepository, searchIndex contract.IBlogSearchIndex, uuidgenrator contract.IUUIDGenerator, logger usecasecontract.IAppLogger, aiUC usecasecontract.IAIUseCase) *BlogUseCaseImpl {
 	return &BlogUseCaseImpl{
-		blogRepo: blogRepo,
-		logger:   logger,
-		uuidgen:  uuidgenrator,
-		aiUC:     aiUC,
+		blogRepo:     blogRepo,
+		searchIndex:  searchIndex,
+		logger:       logger,
+		uuidgen:      uuidgenrator,
+		aiUC:         aiUC,
+		ipHashSecret: randomIPHashSecret(),
+		uow:          directUnitOfWork{},
 	}
 }
 
@@ -59,6 +72,32 @@ var _ IBlogUseCase = (*BlogUseCaseImpl)(nil)
 // separate blog instance for blogCache injection
 func (uc *BlogUseCaseImpl) SetBlogCache(cache contract.IBlogCache) {
 	uc.blogCache = cache
+	if uc.viewFraud != nil {
+		uc.viewFraud.SetBlogCache(cache)
+	}
+}
+
+// SetSemanticSearch enables the semantic and hybrid search modes; hybrid becomes the default
+func (uc *BlogUseCaseImpl) SetSemanticSearch(semantic *SemanticSearchUsecase) {
+	uc.semantic = semantic
+}
+
+// SetSearchVocabulary enables typo-tolerant search against the words of published blogs
+func (uc *BlogUseCaseImpl) SetSearchVocabulary(vocabulary *SearchVocabulary) {
+	uc.vocabulary = vocabulary
+}
+
+// SetViewFraudUsecase screens tracked views with the configured fraud rules
+func (uc *BlogUseCaseImpl) SetViewFraudUsecase(viewFraud *ViewFraudUsecase) {
+	uc.viewFraud = viewFraud
+	if uc.blogCache != nil {
+		viewFraud.SetBlogCache(uc.blogCache)
+	}
+}
+
+// SetNotificationUsecase enables @mention resolution and notifications for blog posts
+func (uc *BlogUseCaseImpl) SetNotificationUsecase(notifications *NotificationUsecase) {
+	uc.notifications = notifications
 }
 
 // buildBlogsListCacheKey builds a stable key for list endpoint caching
@@ -109,6 +148,9 @@ func (uc *BlogUseCaseImpl) CreateBlog(ctx context.Context, title, content string
 		FeaturedImageID: featuredImageID,
 		IsDeleted:       false,
 	}
+	if uc.notifications != nil {
+		blog.Mentions = uc.notifications.ResolveMentions(ctx, content)
+	}
 
 	if status == entity.BlogStatusPublished {
 		now := time.Now()
@@ -143,6 +185,16 @@ func (uc *BlogUseCaseImpl) CreateBlog(ctx context.Context, title, content string
 		}
 	}
 
+	// Mentioned users are only notified once the post is public
+	if uc.notifications != nil && blog.Status == entity.BlogStatusPublished && len(blog.Mentions) > 0 {
+		_ = uc.notifications.NotifyMentions(ctx, entity.NotificationTypeBlogMention, blog.ID, authorID, blog.Mentions, nil)
+	}
+
+	// Keep the search index in sync; a failure only delays the blog showing up in search
+	if err := uc.searchIndex.Index(ctx, blog); err != nil {
+		uc.logger.Errorf("failed to index blog %s for search: %v", blog.ID, err)
+	}
+
 	// Invalidate list caches after creating a blog
 	if uc.blogCache != nil {
 		_ = uc.blogCache.InvalidateBlogLists(ctx)
@@ -334,6 +386,9 @@ func (uc *BlogUseCaseImpl) UpdateBlog(ctx context.Context, blogID, authorID stri
 		if feedback == "no" {
 			return nil, errors.New("content contains inappropriate material")
 		}
+		if uc.notifications != nil {
+			updates["mentions"] = uc.notifications.ResolveMentions(ctx, *content)
+		}
 	}
 
 	if status != nil {
@@ -363,6 +418,22 @@ func (uc *BlogUseCaseImpl) UpdateBlog(ctx context.Context, blogID, authorID stri
 		return nil, fmt.Errorf("failed to get updated blog: %w", err)
 	}
 
+	// Notify users newly mentioned in a published post. Mentions made while the post was
+	// still a draft were never announced, so publishing notifies all of them.
+	if uc.notifications != nil && updatedBlog != nil && updatedBlog.Status == entity.BlogStatusPublished && len(updatedBlog.Mentions) > 0 {
+		var notified []entity.Mention
+		if blog.Status == entity.BlogStatusPublished {
+			notified = blog.Mentions
+		}
+		_ = uc.notifications.NotifyMentions(ctx, entity.NotificationTypeBlogMention, blogID, authorID, updatedBlog.Mentions, notified)
+	}
+
+	if updatedBlog != nil {
+		if err := uc.searchIndex.Index(ctx, updatedBlog); err != nil {
+			uc.logger.Errorf("failed to index blog %s for search: %v", blogID, err)
+		}
+	}
+
 	// Invalidate caches after update
 	if uc.blogCache != nil {
 		_ = uc.blogCache.InvalidateBlogLists(ctx)
@@ -405,6 +476,9 @@ func (uc *BlogUseCaseImpl) DeleteBlog(ctx context.Context, blogID, userID string
 		uc.logger.Errorf("failed to delete blog: %v", err)
 		return false, fmt.Errorf("failed to delete blog: %w", err)
 	}
+	if err := uc.searchIndex.Remove(ctx, blogID); err != nil {
+		uc.logger.Errorf("failed to remove blog %s from search: %v", blogID, err)
+	}
 
 	// Invalidate caches after delete
 	if uc.blogCache != nil {
@@ -418,117 +492,50 @@ func (uc *BlogUseCaseImpl) DeleteBlog(ctx context.Context, blogID, userID string
 }
 
 // TrackBlogView tracks a view on a blog post, ensuring it's authentic by checking user ID, IP address, and User-Agent.
-
-// isBot returns true if the User-Agent string matches common bot patterns.
-func isBot(userAgent string) bool {
-	ua := strings.ToLower(userAgent)
-	botSignatures := []string{"bot", "spider", "crawl", "slurp", "curl", "wget", "python-requests", "httpclient", "feedfetcher", "mediapartners-google"}
-	for _, sig := range botSignatures {
-		if strings.Contains(ua, sig) {
-			return true
-		}
-	}
-	return false
-}
-func (uc *BlogUseCaseImpl) TrackBlogView(ctx context.Context, blogID, userID, ipAddress, userAgent string) error {
+func (uc *BlogUseCaseImpl) TrackBlogView(ctx context.Context, blogID string, req dto.TrackViewRequest) error {
 	if blogID == "" {
 		return errors.New("blog ID is required")
 	}
 
 	// For a view to be considered unique, either the userID (if logged in) or the IP address must be provided.
-	if userID == "" && ipAddress == "" {
+	if req.UserID == "" && req.IPAddress == "" {
 		return errors.New("unable to track view without user ID or IP address")
 	}
 
-	// 1. Basic Bot Detection
-	if isBot(userAgent) {
-		uc.logger.Infof("Bot detected, view not counted for blog %s. User-Agent: %s", blogID, userAgent)
-		return nil
-	}
+	now := time.Now()
+	viewer := uc.viewerIdentity(req, now)
 
-	// 2. Check for recent view from this user/IP for this specific blog post
-	hasViewed, err := uc.blogRepo.HasViewedRecently(ctx, blogID, userID, ipAddress)
+	// 1. Check for recent view from this user/IP for this specific blog post
+	hasViewed, err := uc.blogRepo.HasViewedRecently(ctx, blogID, req.UserID, []string{viewer.IPHash, viewer.PrevIPHash})
 	if err != nil {
 		uc.logger.Errorf("failed to check for recent blog view: %v", err)
 		return fmt.Errorf("failed to check for recent blog view: %w", err)
 	}
 	if hasViewed {
 		// Already viewed recently: return sentinel error for handler
-		uc.logger.Infof("User %s or IP %s already viewed blog %s recently", userID, ipAddress, blogID)
+		uc.logger.Infof("User %s or IP hash %s already viewed blog %s recently", req.UserID, viewer.IPHash, blogID)
 		return errors.New("already viewed recently")
 	}
 
-	// 3. Advanced Velocity & Rotation Checks (using Redis cache)
-	const (
-		maxIpVelocity     = 10      // max 10 views from one IP in 5 mins
-		ipVelocityTTL     = 5 * 60  // 5 minutes in seconds
-		maxUserIPs        = 5       // max 5 different IPs for one user in 1 hour
-		userIPRotationTTL = 60 * 60 // 60 minutes in seconds
-	)
-	if uc.blogCache != nil {
-		// IP velocity check: Has this IP viewed too many different blogs in the last 5 minutes?
-		// Add this view to the IP's recent views set
-		_ = uc.blogCache.AddRecentViewByIP(ctx, ipAddress, blogID, int64(ipVelocityTTL))
-		ipViewCount, err := uc.blogCache.GetRecentViewCountByIP(ctx, ipAddress)
-		if err == nil {
-			if ipViewCount > int64(maxIpVelocity) {
-				uc.logger.Warningf("High IP velocity detected for %s. Views: %d", ipAddress, ipViewCount)
-				return fmt.Errorf("exceeded view velocity limit: too many views from this IP recently")
-			}
-		} else {
-			// Redis failed, fallback to DB
-			shortWindow := time.Now().Add(-5 * time.Minute)
-			ipViews, dbErr := uc.blogRepo.GetRecentViewsByIP(ctx, ipAddress, shortWindow)
-			if dbErr == nil && len(ipViews) > maxIpVelocity {
-				uc.logger.Warningf("[DB Fallback] High IP velocity detected for %s. Views: %d", ipAddress, len(ipViews))
-				return fmt.Errorf("exceeded view velocity limit: too many views from this IP recently")
-			}
-		}
-
-		// User-IP rotation check: Has this user account used too many IPs in the last 1 hour?
-		// Add this IP to the user's recent IPs set
-		if userID != "" {
-			_ = uc.blogCache.AddRecentViewByUser(ctx, userID, ipAddress, int64(userIPRotationTTL))
-			userIPCount, err := uc.blogCache.GetRecentIPCountByUser(ctx, userID)
-			if err == nil {
-				if userIPCount > int64(maxUserIPs) {
-					uc.logger.Warningf("High IP rotation detected for user %s. IPs used: %d", userID, userIPCount)
-					return fmt.Errorf("exceeded IP rotation limit: too many IPs used by this user recently")
-				}
-			} else {
-				// Redis failed, fallback to DB
-				mediumWindow := time.Now().Add(-60 * time.Minute)
-				userViews, dbErr := uc.blogRepo.GetRecentViewsByUser(ctx, userID, mediumWindow)
-				if dbErr == nil {
-					ipSet := make(map[string]struct{})
-					for _, view := range userViews {
-						ipSet[view.IPAddress] = struct{}{}
-					}
-					if len(ipSet) > maxUserIPs {
-						uc.logger.Warningf("[DB Fallback] High IP rotation detected for user %s. IPs used: %d", userID, len(ipSet))
-						return fmt.Errorf("exceeded IP rotation limit: too many IPs used by this user recently")
-					}
-				}
-			}
+	// 2. Fraud rules (bots, suspicious clients, velocity): the strictest match decides
+	if uc.viewFraud != nil {
+		decision := uc.viewFraud.Evaluate(ctx, blogID, req, viewer)
+		uc.viewFraud.Record(ctx, blogID, req, viewer, decision)
+		switch decision.Action {
+		case entity.ViewFraudActionBlock:
+			return decision.Err()
+		case entity.ViewFraudActionDrop:
+			return nil
 		}
 	}
 
-	// If all checks pass, increment the view count and record the view on the DB
-	if err := uc.blogRepo.IncrementViewCount(ctx, blogID); err != nil {
-		uc.logger.Errorf("failed to increment view count: %v", err)
-		return fmt.Errorf("failed to increment view count: %w", err)
-	}
-
-	if err := uc.blogRepo.RecordView(ctx, blogID, userID, ipAddress, userAgent); err != nil {
+	// If all checks pass, record the view (for the recent-view checks above) and count it
+	if err := uc.blogRepo.RecordView(ctx, newBlogView(blogID, req, viewer, now)); err != nil {
 		uc.logger.Errorf("failed to record user view: %v", err)
 		return fmt.Errorf("failed to record user view: %w", err)
 	}
 
-	// Update popularity after view
-	if err := uc.UpdateBlogPopularity(ctx, blogID); err != nil {
-		uc.logger.Errorf("failed to update blog popularity after view: %v", err)
-	}
-	return nil
+	return uc.countView(ctx, blogID)
 }
 
 // GetPopularBlogs returns blogs sorted by view count (descending), paginated.
@@ -566,54 +573,144 @@ func (uc *BlogUseCaseImpl) GetPopularBlogs(ctx context.Context, page, pageSize i
 	return blogEntities, int(totalCount), page, totalPages, nil
 }
 
-// SearchAndFilterBlogs implements advanced search and filtering for blogs.
-func (uc *BlogUseCaseImpl) SearchAndFilterBlogs(
-	ctx context.Context,
-	query string,
-	tags []string,
-	dateFrom *time.Time,
-	dateTo *time.Time,
-	minViews *int,
-	maxViews *int,
-	minLikes *int,
-	maxLikes *int,
-	authorID *string,
-	page int,
-	pageSize int,
-) ([]entity.Blog, int, int, int, error) {
+// SearchAndFilterBlogs implements advanced search and filtering for blogs. The mode picks
+// how a query matches: keyword (the text index), semantic (embedding similarity) or hybrid
+// (a blend of both). It defaults to hybrid when semantic search is enabled. Queries may hold
+// "quoted phrases" and -excluded terms, and unknown terms are also searched as the closest
+// word used in published blogs. Readers only find published posts; an author searching their
+// own posts sees every status and gets a status facet.
+func (uc *BlogUseCaseImpl) SearchAndFilterBlogs(ctx context.Context, req dto.SearchBlogsRequest, viewerID string) (*usecasecontract.BlogSearchResult, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	ownPosts := viewerID != "" && req.AuthorID != nil && *req.AuthorID == viewerID
+	statuses := []entity.BlogStatus{entity.BlogStatusPublished}
+	if ownPosts {
+		switch status := entity.BlogStatus(req.Status); status {
+		case "", "all":
+			statuses = nil
+		case entity.BlogStatusDraft, entity.BlogStatusPublished, entity.BlogStatusArchived:
+			statuses = []entity.BlogStatus{status}
+		default:
+			return nil, fmt.Errorf("invalid status %q: use draft, published, archived or all", req.Status)
+		}
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = SearchModeKeyword
+		if uc.semantic != nil {
+			mode = SearchModeHybrid
+		}
+	}
+	switch mode {
+	case SearchModeKeyword:
+	case SearchModeSemantic, SearchModeHybrid:
+		if uc.semantic == nil {
+			return nil, fmt.Errorf("invalid mode %q: semantic search is not enabled", mode)
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode %q: use keyword, semantic or hybrid", mode)
+	}
+
+	query := utils.ParseSearchQuery(req.Query)
+	if query.IsEmpty() && len(query.Excluded) > 0 {
+		return nil, errors.New("invalid query: add a term or phrase to search for besides the exclusions")
+	}
+	if query.OnlyStopWords() {
+		return nil, errors.New("invalid query: add a less common term, or quote the words to search for them as a phrase")
+	}
+
+	sortBy := req.SortBy
+	switch sortBy {
+	case "":
+		if !query.IsEmpty() {
+			sortBy = searchSortRelevance
+		}
+	case searchSortRelevance:
+		if query.IsEmpty() {
+			sortBy = ""
+		}
+	case "created_at", "view_count", "like_count", "popularity":
+	default:
+		return nil, fmt.Errorf("invalid sortBy %q: use relevance, created_at, view_count, like_count or popularity", req.SortBy)
+	}
+	if req.SortOrder != "" && req.SortOrder != "asc" && req.SortOrder != "desc" {
+		return nil, fmt.Errorf("invalid sortOrder %q: use asc or desc", req.SortOrder)
+	}
+
 	filterOptions := &contract.BlogFilterOptions{
-		Page:     page,
-		PageSize: pageSize,
-		DateFrom: dateFrom,
-		DateTo:   dateTo,
-		MinViews: minViews,
-		MaxViews: maxViews,
-		MinLikes: minLikes,
-		MaxLikes: maxLikes,
-		AuthorID: authorID,
-		TagIDs:   tags,
-	}
-	var blogs []*entity.Blog
+		Page:      page,
+		PageSize:  pageSize,
+		SortBy:    sortBy,
+		SortOrder: req.SortOrder,
+		DateFrom:  req.DateFrom,
+		DateTo:    req.DateTo,
+		MinViews:  req.MinViews,
+		MaxViews:  req.MaxViews,
+		MinLikes:  req.MinLikes,
+		MaxLikes:  req.MaxLikes,
+		AuthorID:  req.AuthorID,
+		TagIDs:    req.Tags,
+		Statuses:  statuses,
+	}
+
+	result := &usecasecontract.BlogSearchResult{CurrentPage: page, Mode: mode}
+	expanded, corrected := uc.correctSearchQuery(query)
+	if corrected.String() != query.String() {
+		result.CorrectedQuery = corrected.String()
+	}
+
+	var scored []contract.ScoredBlog
+	var facets *contract.BlogFacets
 	var totalCount int64
 	var err error
-	if query != "" {
-		blogs, totalCount, err = uc.blogRepo.SearchBlogs(ctx, query, filterOptions)
-	} else {
+	switch {
+	case query.IsEmpty():
+		var blogs []*entity.Blog
 		blogs, totalCount, err = uc.blogRepo.GetBlogs(ctx, filterOptions)
+		if err == nil {
+			for _, b := range blogs {
+				scored = append(scored, contract.ScoredBlog{Blog: b})
+			}
+			facets, err = uc.blogRepo.GetBlogFacets(ctx, filterOptions, ownPosts)
+		}
+	case mode == SearchModeKeyword:
+		var page *contract.BlogSearchPage
+		page, err = uc.searchIndex.Search(ctx, expanded.String(), filterOptions, ownPosts)
+		if err == nil {
+			scored, totalCount, facets = page.Blogs, page.Total, page.Facets
+		}
+	default:
+		scored, totalCount, facets, err = uc.rankedSearch(ctx, expanded, mode, filterOptions, ownPosts)
 	}
 	if err != nil {
 		uc.logger.Errorf("failed to search/filter blogs: %v", err)
-		return nil, 0, 0, 0, fmt.Errorf("failed to search/filter blogs: %w", err)
+		return nil, fmt.Errorf("failed to search/filter blogs: %w", err)
 	}
-	totalPages := int(totalCount) / pageSize
-	if int(totalCount)%pageSize != 0 {
-		totalPages++
+
+	terms := expanded.HighlightTerms()
+	result.Hits = make([]usecasecontract.BlogSearchHit, 0, len(scored))
+	for _, s := range scored {
+		result.Hits = append(result.Hits, usecasecontract.BlogSearchHit{
+			Blog:             *s.Blog,
+			Score:            s.Score,
+			TitleHighlight:   utils.HighlightSnippet(s.Blog.Title, terms, 0),
+			ContentHighlight: utils.HighlightSnippet(utils.PlainText(s.Blog.Content), terms, blogSnippetLength),
+		})
 	}
-	var blogEntities []entity.Blog
-	for _, blog := range blogs {
-		blogEntities = append(blogEntities, *blog)
+	result.TotalCount = int(totalCount)
+	result.TotalPages = int(totalCount) / pageSize
+	if int(totalCount)%pageSize != 0 {
+		result.TotalPages++
 	}
-	return blogEntities, int(totalCount), page, totalPages, nil
+	result.Facets = *facets
+	return result, nil
 }
 
 // UpdateBlogPopularity fetches counts and updates the popularity field in the DB
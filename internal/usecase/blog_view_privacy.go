@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// ViewerIdentity is all that view tracking keeps of a viewer's IP address.
+type ViewerIdentity struct {
+	IPHash     string // keyed hash of the IP, rotated every UTC day
+	PrevIPHash string // the same IP hashed with yesterday's key, so dedupe spans midnight
+	IPPrefix   string // network prefix of the IP; empty when the viewer opted out
+	NoTracking bool   // the request carried Do-Not-Track or Global Privacy Control
+}
+
+// SetIPHashSecret sets the key viewer IPs are hashed with. Every instance must share it for
+// deduplication and velocity checks to work across instances; without it a random key is
+// used, which changes on every restart.
+func (uc *BlogUseCaseImpl) SetIPHashSecret(secret string) {
+	if secret != "" {
+		uc.ipHashSecret = []byte(secret)
+	}
+}
+
+func randomIPHashSecret() []byte {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return secret
+}
+
+// viewerIdentity derives the pseudonymous identity of the viewer behind a request.
+func (uc *BlogUseCaseImpl) viewerIdentity(req dto.TrackViewRequest, now time.Time) ViewerIdentity {
+	identity := ViewerIdentity{
+		IPHash:     utils.HashIP(uc.ipHashSecret, req.IPAddress, now),
+		PrevIPHash: utils.HashIP(uc.ipHashSecret, req.IPAddress, now.Add(-24*time.Hour)),
+		NoTracking: utils.PrefersNoTracking(req.Headers),
+	}
+	if !identity.NoTracking {
+		identity.IPPrefix = utils.IPNetworkPrefix(req.IPAddress)
+	}
+	return identity
+}
+
+// newBlogView builds the view record to store. Readers who opted out of tracking are
+// recorded by IP hash alone, which only serves deduplication and expires within a day.
+func newBlogView(blogID string, req dto.TrackViewRequest, viewer ViewerIdentity, now time.Time) *entity.BlogView {
+	view := &entity.BlogView{BlogID: blogID, IPHash: viewer.IPHash, ViewedAt: now}
+	if viewer.NoTracking {
+		return view
+	}
+	view.UserID = req.UserID
+	view.IPPrefix = viewer.IPPrefix
+	view.Referrer = req.Referrer
+	if req.UserAgent != "" {
+		view.UserAgentFamily = utils.UserAgentFamily(req.UserAgent)
+	}
+	return view
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	maxReadingListsPerUser   = 100
+	maxReadingListNameLength = 100
+	maxReadingListDescLength = 500
+	blogExcerptLength        = 200
+)
+
+// BookmarkUsecase lets readers save blogs for later, optionally sorted into named reading
+// lists that can be shared publicly.
+type BookmarkUsecase struct {
+	bookmarkRepo contract.IBookmarkRepository
+	blogRepo     contract.IBlogRepository
+	// uow deletes a reading list and its bookmarks together
+	uow contract.IUnitOfWork
+}
+
+// NewBookmarkUsecase creates and returns a new BookmarkUsecase instance.
+func NewBookmarkUsecase(bookmarkRepo contract.IBookmarkRepository, blogRepo contract.IBlogRepository) *BookmarkUsecase {
+	return &BookmarkUsecase{bookmarkRepo: bookmarkRepo, blogRepo: blogRepo, uow: directUnitOfWork{}}
+}
+
+// SetUnitOfWork makes multi-document writes commit atomically; nil restores direct writes.
+func (u *BookmarkUsecase) SetUnitOfWork(uow contract.IUnitOfWork) {
+	if uow == nil {
+		uow = directUnitOfWork{}
+	}
+	u.uow = uow
+}
+
+// AddBookmark saves a blog for the user, unsorted when listID is empty, and reports whether
+// a new bookmark was created. Saving a blog that is already in the list is not an error; the
+// existing bookmark is kept and returned.
+func (u *BookmarkUsecase) AddBookmark(ctx context.Context, userID, blogID, listID string) (*dto.BookmarkResponse, bool, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, false, errors.New("blog not found")
+		}
+		return nil, false, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if !blogVisibleTo(blog, userID) {
+		return nil, false, errors.New("blog not found")
+	}
+	if listID != "" {
+		if _, err := u.getOwnedReadingList(ctx, listID, userID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	bookmark := &entity.Bookmark{UserID: userID, BlogID: blogID, ListID: listID, CreatedAt: time.Now()}
+	created, err := u.bookmarkRepo.AddBookmark(ctx, bookmark)
+	if err != nil {
+		return nil, false, err
+	}
+	return &dto.BookmarkResponse{
+		ID:        bookmark.ID,
+		BlogID:    blogID,
+		ListID:    listID,
+		CreatedAt: bookmark.CreatedAt,
+		Blog:      toBlogSummary(blog),
+	}, created, nil
+}
+
+// RemoveBookmark removes a blog from one of the user's lists ("" is unsorted) or, when
+// listID is nil, from everywhere the user saved it.
+func (u *BookmarkUsecase) RemoveBookmark(ctx context.Context, userID, blogID string, listID *string) error {
+	removed, err := u.bookmarkRepo.RemoveBookmark(ctx, userID, blogID, listID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return errors.New("bookmark not found")
+	}
+	return nil
+}
+
+// ListBookmarks returns the user's bookmarks with blog summaries, newest first. listID nil
+// lists every bookmark; "" lists the unsorted ones.
+func (u *BookmarkUsecase) ListBookmarks(ctx context.Context, userID string, listID *string, page, pageSize int) (*dto.BookmarksResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	if listID != nil && *listID != "" {
+		if _, err := u.getOwnedReadingList(ctx, *listID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	bookmarks, total, err := u.bookmarkRepo.ListBookmarks(ctx, userID, listID, userID, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	responses, err := u.withBlogSummaries(ctx, bookmarks, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.BookmarksResponse{Bookmarks: responses, Pagination: buildPaginationMeta(page, pageSize, total)}, nil
+}
+
+// IsBookmarked reports whether the user saved the blog anywhere.
+func (u *BookmarkUsecase) IsBookmarked(ctx context.Context, userID, blogID string) (bool, error) {
+	bookmarked, err := u.bookmarkRepo.BookmarkedBlogIDs(ctx, userID, []string{blogID})
+	if err != nil {
+		return false, err
+	}
+	return bookmarked[blogID], nil
+}
+
+// CreateReadingList creates a reading list; visibility defaults to private.
+func (u *BookmarkUsecase) CreateReadingList(ctx context.Context, userID, name, description, visibility string) (*dto.ReadingListResponse, error) {
+	existing, err := u.bookmarkRepo.ListReadingLists(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxReadingListsPerUser {
+		return nil, fmt.Errorf("invalid request: at most %d reading lists allowed", maxReadingListsPerUser)
+	}
+
+	list := &entity.ReadingList{UserID: userID, Visibility: entity.ReadingListPrivate}
+	if err := applyReadingListFields(list, &name, &description, &visibility, existing); err != nil {
+		return nil, err
+	}
+	list.CreatedAt = time.Now()
+	list.UpdatedAt = list.CreatedAt
+	if err := u.bookmarkRepo.CreateReadingList(ctx, list); err != nil {
+		return nil, err
+	}
+	return toReadingListResponse(list, 0), nil
+}
+
+// UpdateReadingList changes the given fields of one of the user's reading lists.
+func (u *BookmarkUsecase) UpdateReadingList(ctx context.Context, userID, listID string, name, description, visibility *string) (*dto.ReadingListResponse, error) {
+	list, err := u.getOwnedReadingList(ctx, listID, userID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := u.bookmarkRepo.ListReadingLists(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyReadingListFields(list, name, description, visibility, existing); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"name":        list.Name,
+		"description": list.Description,
+		"visibility":  list.Visibility,
+	}
+	if err := u.bookmarkRepo.UpdateReadingList(ctx, listID, updates); err != nil {
+		return nil, err
+	}
+	list.UpdatedAt = time.Now()
+
+	counts, err := u.bookmarkRepo.CountBookmarksByList(ctx, []string{listID})
+	if err != nil {
+		return nil, err
+	}
+	return toReadingListResponse(list, counts[listID]), nil
+}
+
+// DeleteReadingList deletes one of the user's reading lists and the bookmarks in it.
+func (u *BookmarkUsecase) DeleteReadingList(ctx context.Context, userID, listID string) error {
+	if _, err := u.getOwnedReadingList(ctx, listID, userID); err != nil {
+		return err
+	}
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		return u.bookmarkRepo.DeleteReadingList(ctx, listID)
+	})
+}
+
+// ListReadingLists returns a user's reading lists: all of them to the owner, only the
+// public ones to everybody else.
+func (u *BookmarkUsecase) ListReadingLists(ctx context.Context, ownerID, viewerID string) (*dto.ReadingListsResponse, error) {
+	lists, err := u.bookmarkRepo.ListReadingLists(ctx, ownerID, ownerID != viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reading lists: %w", err)
+	}
+
+	ids := make([]string, len(lists))
+	for i, l := range lists {
+		ids[i] = l.ID
+	}
+	counts, err := u.bookmarkRepo.CountBookmarksByList(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReadingListsResponse{Lists: make([]*dto.ReadingListResponse, len(lists))}
+	for i, l := range lists {
+		resp.Lists[i] = toReadingListResponse(l, counts[l.ID])
+	}
+	return resp, nil
+}
+
+// GetReadingList returns a reading list with a page of its bookmarks. Private lists are
+// only visible to their owner and look like missing ones to everybody else.
+func (u *BookmarkUsecase) GetReadingList(ctx context.Context, listID, viewerID string, page, pageSize int) (*dto.ReadingListDetailResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	list, err := u.bookmarkRepo.GetReadingList(ctx, listID)
+	if err != nil || (list.Visibility != entity.ReadingListPublic && list.UserID != viewerID) {
+		return nil, errors.New("reading list not found")
+	}
+
+	bookmarks, total, err := u.bookmarkRepo.ListBookmarks(ctx, list.UserID, &list.ID, viewerID, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	responses, err := u.withBlogSummaries(ctx, bookmarks, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReadingListDetailResponse{
+		List:       toReadingListResponse(list, total),
+		Bookmarks:  responses,
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}, nil
+}
+
+func (u *BookmarkUsecase) getOwnedReadingList(ctx context.Context, listID, userID string) (*entity.ReadingList, error) {
+	list, err := u.bookmarkRepo.GetReadingList(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("reading list not found: %w", err)
+	}
+	if list.UserID != userID {
+		return nil, errors.New("unauthorized: only the owner can change this reading list")
+	}
+	return list, nil
+}
+
+// withBlogSummaries joins the bookmarked blogs in. The repository already leaves out blogs
+// the viewer cannot see; any deleted since are skipped.
+func (u *BookmarkUsecase) withBlogSummaries(ctx context.Context, bookmarks []*entity.Bookmark, viewerID string) ([]*dto.BookmarkResponse, error) {
+	ids := make([]string, len(bookmarks))
+	for i, b := range bookmarks {
+		ids[i] = b.BlogID
+	}
+	blogs, err := u.blogRepo.GetBlogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Blog, len(blogs))
+	for _, b := range blogs {
+		byID[b.ID] = b
+	}
+
+	responses := make([]*dto.BookmarkResponse, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		blog, ok := byID[b.BlogID]
+		if !ok || !blogVisibleTo(blog, viewerID) {
+			continue
+		}
+		responses = append(responses, &dto.BookmarkResponse{
+			ID:        b.ID,
+			BlogID:    b.BlogID,
+			ListID:    b.ListID,
+			CreatedAt: b.CreatedAt,
+			Blog:      toBlogSummary(blog),
+		})
+	}
+	return responses, nil
+}
+
+// applyReadingListFields validates and sets the non-nil fields. Names must be unique per
+// user, ignoring case.
+func applyReadingListFields(list *entity.ReadingList, name, description, visibility *string, existing []*entity.ReadingList) error {
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" || len([]rune(trimmed)) > maxReadingListNameLength {
+			return fmt.Errorf("invalid name: must be 1 to %d characters", maxReadingListNameLength)
+		}
+		for _, other := range existing {
+			if other.ID != list.ID && strings.EqualFold(other.Name, trimmed) {
+				return fmt.Errorf("invalid name: you already have a reading list called %q", other.Name)
+			}
+		}
+		list.Name = trimmed
+	}
+	if description != nil {
+		trimmed := strings.TrimSpace(*description)
+		if len([]rune(trimmed)) > maxReadingListDescLength {
+			return fmt.Errorf("invalid description: at most %d characters", maxReadingListDescLength)
+		}
+		list.Description = trimmed
+	}
+	if visibility != nil && *visibility != "" {
+		switch v := entity.ReadingListVisibility(*visibility); v {
+		case entity.ReadingListPublic, entity.ReadingListPrivate:
+			list.Visibility = v
+		default:
+			return fmt.Errorf("invalid visibility %q: use public or private", *visibility)
+		}
+	}
+	return nil
+}
+
+// blogVisibleTo reports whether a viewer may see a blog: published blogs are visible to
+// everyone, others only to their author.
+func blogVisibleTo(blog *entity.Blog, viewerID string) bool {
+	return blog.Status == entity.BlogStatusPublished || (viewerID != "" && blog.AuthorID == viewerID)
+}
+
+func toBlogSummary(blog *entity.Blog) *dto.BlogSummaryResponse {
+	tags := blog.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &dto.BlogSummaryResponse{
+		ID:           blog.ID,
+		Title:        blog.Title,
+		Slug:         blog.Slug,
+		AuthorID:     blog.AuthorID,
+		Excerpt:      utils.Excerpt(blog.Content, blogExcerptLength),
+		Tags:         tags,
+		ViewCount:    blog.ViewCount,
+		LikeCount:    blog.LikeCount,
+		CommentCount: blog.CommentCount,
+		PublishedAt:  blog.PublishedAt,
+	}
+}
+
+func toReadingListResponse(list *entity.ReadingList, count int64) *dto.ReadingListResponse {
+	return &dto.ReadingListResponse{
+		ID:            list.ID,
+		UserID:        list.UserID,
+		Name:          list.Name,
+		Description:   list.Description,
+		Visibility:    string(list.Visibility),
+		BookmarkCount: count,
+		CreatedAt:     list.CreatedAt,
+		UpdatedAt:     list.UpdatedAt,
+	}
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// maxBlocklistKeywords caps the number of keywords a blog author may block.
+	maxBlocklistKeywords = 100
+	// minResolvedReportsForReputation is how many resolved reports a user needs before
+	// their accuracy affects the weight of new reports.
+	minResolvedReportsForReputation = 3
+)
+
+// CommentVerdict is the outcome of running a comment through auto-moderation
+type CommentVerdict string
+
+const (
+	CommentVerdictApprove CommentVerdict = "approve"
+	CommentVerdictHold    CommentVerdict = "hold"
+	CommentVerdictReject  CommentVerdict = "reject"
+)
+
+// Status returns the comment status a verdict translates to.
+func (v CommentVerdict) Status() string {
+	switch v {
+	case CommentVerdictReject:
+		return entity.CommentStatusRejected
+	case CommentVerdictHold:
+		return entity.CommentStatusPending
+	default:
+		return entity.CommentStatusApproved
+	}
+}
+
+// CommentModerationConfig configures the auto-moderation rules. Zero values disable a rule.
+type CommentModerationConfig struct {
+	RejectWords          []string      // words that get a comment rejected outright
+	HoldWords            []string      // words that send a comment to the review queue
+	MaxLinks             int           // more links than this holds the comment
+	MaxRepeatedChars     int           // a longer run of one character holds the comment
+	NewAccountHoldPeriod time.Duration // comments from accounts younger than this are held
+	ReportHideThreshold  float64       // weighted report score at which a comment is hidden for review
+}
+
+// CommentModerationInput is everything the rules look at for a single comment.
+// Author and Blog are optional; rules that need them are skipped when nil.
+type CommentModerationInput struct {
+	Content string
+	Author  *entity.User
+	Blog    *entity.Blog
+}
+
+// CommentModerationResult carries the verdict and the reasons that produced it
+type CommentModerationResult struct {
+	Verdict CommentVerdict
+	Reasons []string
+}
+
+// CommentModerator applies rule-based auto-moderation to new and edited comments.
+type CommentModerator struct {
+	config      CommentModerationConfig
+	rejectWords []string
+	holdWords   []string
+}
+
+// NewCommentModerator creates a moderator for the given rules.
+func NewCommentModerator(config CommentModerationConfig) *CommentModerator {
+	return &CommentModerator{
+		config:      config,
+		rejectWords: normalizeWordList(config.RejectWords),
+		holdWords:   normalizeWordList(config.HoldWords),
+	}
+}
+
+// Evaluate runs every rule and returns the strictest verdict reached.
+func (m *CommentModerator) Evaluate(input CommentModerationInput) CommentModerationResult {
+	result := CommentModerationResult{Verdict: CommentVerdictApprove}
+
+	// Administrators are trusted and skip auto-moderation entirely
+	if input.Author != nil && input.Author.Role == entity.UserRoleAdmin {
+		return result
+	}
+
+	tokens := utils.NormalizeForMatching(input.Content)
+
+	if word, ok := matchWordList(tokens, m.rejectWords); ok {
+		result.escalate(CommentVerdictReject, fmt.Sprintf("contains blocked word %q", word))
+	}
+	if input.Blog != nil && len(input.Blog.CommentBlocklist) > 0 {
+		if word, ok := matchWordList(tokens, normalizeWordList(input.Blog.CommentBlocklist)); ok {
+			result.escalate(CommentVerdictReject, fmt.Sprintf("contains keyword %q blocked by the blog author", word))
+		}
+	}
+	if word, ok := matchWordList(tokens, m.holdWords); ok {
+		result.escalate(CommentVerdictHold, fmt.Sprintf("contains flagged word %q", word))
+	}
+
+	if m.config.MaxLinks > 0 {
+		if links := utils.CountLinks(input.Content); links > m.config.MaxLinks {
+			result.escalate(CommentVerdictHold, fmt.Sprintf("contains %d links (max %d)", links, m.config.MaxLinks))
+		}
+	}
+	if m.config.MaxRepeatedChars > 0 {
+		if run := utils.LongestRepeatedRun(input.Content); run > m.config.MaxRepeatedChars {
+			result.escalate(CommentVerdictHold, fmt.Sprintf("repeats a character %d times in a row", run))
+		}
+	}
+	if m.config.NewAccountHoldPeriod > 0 && input.Author != nil && !input.Author.CreatedAt.IsZero() {
+		if time.Since(input.Author.CreatedAt) < m.config.NewAccountHoldPeriod {
+			result.escalate(CommentVerdictHold, "author account is newer than "+m.config.NewAccountHoldPeriod.String())
+		}
+	}
+
+	return result
+}
+
+// ReportWeight returns how much a single user's report counts towards the hide threshold.
+// Unverified accounts count for less, reporters whose past reports were mostly upheld count
+// for more (up to double), and administrators cross the threshold on their own.
+func (m *CommentModerator) ReportWeight(reporter *entity.User, stats *contract.ReporterStats) float64 {
+	if reporter == nil {
+		return 0.5
+	}
+	if reporter.Role == entity.UserRoleAdmin {
+		return m.config.ReportHideThreshold
+	}
+
+	weight := 1.0
+	if !reporter.IsVerified {
+		weight = 0.5
+	}
+	// Only trust a track record once there is enough of it
+	if stats != nil {
+		if resolved := stats.Upheld + stats.Dismissed; resolved >= minResolvedReportsForReputation {
+			accuracy := float64(stats.Upheld) / float64(resolved)
+			weight *= 0.5 + 1.5*accuracy
+		}
+	}
+	return weight
+}
+
+// ShouldHide reports whether a weighted report score has crossed the hide threshold.
+func (m *CommentModerator) ShouldHide(score float64) bool {
+	return m.config.ReportHideThreshold > 0 && score >= m.config.ReportHideThreshold
+}
+
+func (r *CommentModerationResult) escalate(verdict CommentVerdict, reason string) {
+	r.Reasons = append(r.Reasons, reason)
+	if verdictRank(verdict) > verdictRank(r.Verdict) {
+		r.Verdict = verdict
+	}
+}
+
+func verdictRank(v CommentVerdict) int {
+	switch v {
+	case CommentVerdictReject:
+		return 2
+	case CommentVerdictHold:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// normalizeWordList folds each entry the same way comment text is folded, so that list
+// entries and comments are compared on equal terms. Multi-word entries become phrases.
+func normalizeWordList(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		if tokens := utils.NormalizeForMatching(w); len(tokens) > 0 {
+			normalized = append(normalized, strings.Join(tokens, " "))
+		}
+	}
+	return normalized
+}
+
+// matchWordList reports the first list entry found as a whole word or phrase in tokens.
+// Elongated spellings ("baaad") are matched by also comparing with repeats squeezed out.
+func matchWordList(tokens, words []string) (string, bool) {
+	if len(tokens) == 0 || len(words) == 0 {
+		return "", false
+	}
+
+	text := " " + strings.Join(tokens, " ") + " "
+	variants := []string{text, utils.SqueezeRepeats(text, 1), utils.SqueezeRepeats(text, 2)}
+	for _, word := range words {
+		needle := " " + word + " "
+		for _, variant := range variants {
+			if strings.Contains(variant, needle) {
+				return word, true
+			}
+		}
+	}
+	return "", false
+}
+
+// moderateEdit evaluates edited content against the same rules as new comments.
+func (uc *commentUseCase) moderateEdit(ctx context.Context, comment *entity.Comment, content string) CommentModerationResult {
+	input := CommentModerationInput{Content: content}
+	if author, err := uc.userRepo.GetUserByID(ctx, comment.AuthorID); err == nil {
+		input.Author = author
+	}
+	if blog, err := uc.blogRepo.GetBlogByID(ctx, comment.BlogID); err == nil {
+		input.Blog = blog
+	}
+	return uc.moderator.Evaluate(input)
+}
+
+// recordAutoModeration writes non-approve verdicts to the moderation audit trail.
+func (uc *commentUseCase) recordAutoModeration(ctx context.Context, commentID string, result CommentModerationResult) {
+	if result.Verdict == CommentVerdictApprove {
+		return
+	}
+	uc.recordModeration(ctx, &entity.ModerationAction{
+		ModeratorID: entity.SystemModeratorID,
+		Action:      entity.ModerationActionAutoModerate,
+		TargetType:  "comment",
+		TargetID:    commentID,
+		Notes:       moderationNotes(string(result.Verdict), strings.Join(result.Reasons, "; ")),
+	})
+}
+
+// GetBlogCommentBlocklist returns the keywords the blog author has blocked from comments.
+func (uc *commentUseCase) GetBlogCommentBlocklist(ctx context.Context, blogID, userID string) (*dto.CommentBlocklistResponse, error) {
+	blog, err := uc.getOwnedBlog(ctx, blogID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := blog.CommentBlocklist
+	if keywords == nil {
+		keywords = []string{}
+	}
+	return &dto.CommentBlocklistResponse{BlogID: blog.ID, Keywords: keywords}, nil
+}
+
+// UpdateBlogCommentBlocklist replaces the blog's comment keyword blocklist.
+func (uc *commentUseCase) UpdateBlogCommentBlocklist(ctx context.Context, blogID, userID string, req dto.UpdateCommentBlocklistRequest) (*dto.CommentBlocklistResponse, error) {
+	blog, err := uc.getOwnedBlog(ctx, blogID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(req.Keywords))
+	keywords := make([]string, 0, len(req.Keywords))
+	for _, keyword := range req.Keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword == "" {
+			continue
+		}
+		if len(keyword) > 50 {
+			return nil, fmt.Errorf("invalid keyword: %q is longer than 50 characters", keyword)
+		}
+		if _, ok := seen[keyword]; ok {
+			continue
+		}
+		seen[keyword] = struct{}{}
+		keywords = append(keywords, keyword)
+	}
+	if len(keywords) > maxBlocklistKeywords {
+		return nil, fmt.Errorf("invalid keyword list: at most %d keywords allowed", maxBlocklistKeywords)
+	}
+
+	if err := uc.blogRepo.UpdateBlog(ctx, blog.ID, map[string]interface{}{"comment_blocklist": keywords}); err != nil {
+		return nil, fmt.Errorf("failed to update comment blocklist: %w", err)
+	}
+
+	return &dto.CommentBlocklistResponse{BlogID: blog.ID, Keywords: keywords}, nil
+}
+
+func (uc *commentUseCase) getOwnedBlog(ctx context.Context, blogID, userID string) (*entity.Blog, error) {
+	blog, err := uc.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return nil, fmt.Errorf("blog not found: %w", err)
+	}
+	if blog.AuthorID != userID {
+		return nil, errors.New("unauthorized: only the blog author can manage comments on this blog")
+	}
+	return blog, nil
+}
@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentModerator_Evaluate(t *testing.T) {
+	moderator := usecase.NewCommentModerator(usecase.CommentModerationConfig{
+		RejectWords:          []string{"Spam"},
+		HoldWords:            []string{"crypto deal"},
+		MaxLinks:             1,
+		MaxRepeatedChars:     5,
+		NewAccountHoldPeriod: 24 * time.Hour,
+	})
+	established := &entity.User{Role: entity.UserRoleUser, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+
+	tests := []struct {
+		name        string
+		input       usecase.CommentModerationInput
+		wantVerdict usecase.CommentVerdict
+		wantReasons []string
+	}{
+		{
+			name:        "clean comment",
+			input:       usecase.CommentModerationInput{Content: "Great post, thanks!", Author: established},
+			wantVerdict: usecase.CommentVerdictApprove,
+		},
+		{
+			name:        "no author or blog",
+			input:       usecase.CommentModerationInput{Content: "Great post, thanks!"},
+			wantVerdict: usecase.CommentVerdictApprove,
+		},
+		{
+			name:        "blocked word in leet-speak",
+			input:       usecase.CommentModerationInput{Content: "Buy $P4M now", Author: established},
+			wantVerdict: usecase.CommentVerdictReject,
+			wantReasons: []string{`contains blocked word "spam"`},
+		},
+		{
+			name:        "elongated blocked word",
+			input:       usecase.CommentModerationInput{Content: "spaaaam", Author: established},
+			wantVerdict: usecase.CommentVerdictReject,
+			wantReasons: []string{`contains blocked word "spam"`},
+		},
+		{
+			name:        "blocked word inside a longer word",
+			input:       usecase.CommentModerationInput{Content: "the spammer was banned", Author: established},
+			wantVerdict: usecase.CommentVerdictApprove,
+		},
+		{
+			name:        "flagged phrase across extra spaces",
+			input:       usecase.CommentModerationInput{Content: "best crypto   deal today", Author: established},
+			wantVerdict: usecase.CommentVerdictHold,
+			wantReasons: []string{`contains flagged word "crypto deal"`},
+		},
+		{
+			name:        "keyword blocked by the blog author",
+			input:       usecase.CommentModerationInput{Content: "I prefer rust", Author: established, Blog: &entity.Blog{CommentBlocklist: []string{"Rust"}}},
+			wantVerdict: usecase.CommentVerdictReject,
+			wantReasons: []string{`contains keyword "rust" blocked by the blog author`},
+		},
+		{
+			name:        "too many links",
+			input:       usecase.CommentModerationInput{Content: "see https://a.example and www.b.example", Author: established},
+			wantVerdict: usecase.CommentVerdictHold,
+			wantReasons: []string{"contains 2 links (max 1)"},
+		},
+		{
+			name:        "one link is allowed",
+			input:       usecase.CommentModerationInput{Content: "see https://a.example", Author: established},
+			wantVerdict: usecase.CommentVerdictApprove,
+		},
+		{
+			name:        "repeated character",
+			input:       usecase.CommentModerationInput{Content: "nooooooo", Author: established},
+			wantVerdict: usecase.CommentVerdictHold,
+			wantReasons: []string{"repeats a character 7 times in a row"},
+		},
+		{
+			name:        "new account",
+			input:       usecase.CommentModerationInput{Content: "Hello!", Author: &entity.User{CreatedAt: time.Now().Add(-time.Hour)}},
+			wantVerdict: usecase.CommentVerdictHold,
+			wantReasons: []string{"author account is newer than 24h0m0s"},
+		},
+		{
+			name:        "strictest verdict wins and every reason is kept",
+			input:       usecase.CommentModerationInput{Content: "crypto deal, no spam", Author: established},
+			wantVerdict: usecase.CommentVerdictReject,
+			wantReasons: []string{`contains blocked word "spam"`, `contains flagged word "crypto deal"`},
+		},
+		{
+			name:        "administrators skip moderation",
+			input:       usecase.CommentModerationInput{Content: "spam", Author: &entity.User{Role: entity.UserRoleAdmin, CreatedAt: time.Now()}},
+			wantVerdict: usecase.CommentVerdictApprove,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := moderator.Evaluate(tt.input)
+			assert.Equal(t, tt.wantVerdict, result.Verdict)
+			assert.Equal(t, tt.wantReasons, result.Reasons)
+		})
+	}
+}
+
+func TestCommentModerator_ReportWeight(t *testing.T) {
+	moderator := usecase.NewCommentModerator(usecase.CommentModerationConfig{ReportHideThreshold: 3})
+
+	tests := []struct {
+		name     string
+		reporter *entity.User
+		stats    *contract.ReporterStats
+		want     float64
+	}{
+		{name: "unknown reporter", reporter: nil, want: 0.5},
+		{name: "administrator", reporter: &entity.User{Role: entity.UserRoleAdmin}, want: 3},
+		{name: "verified", reporter: &entity.User{IsVerified: true}, want: 1},
+		{name: "unverified", reporter: &entity.User{}, want: 0.5},
+		{name: "too few resolved reports", reporter: &entity.User{IsVerified: true}, stats: &contract.ReporterStats{Upheld: 2}, want: 1},
+		{name: "always upheld", reporter: &entity.User{IsVerified: true}, stats: &contract.ReporterStats{Upheld: 3}, want: 2},
+		{name: "always dismissed", reporter: &entity.User{IsVerified: true}, stats: &contract.ReporterStats{Dismissed: 4}, want: 0.5},
+		{name: "unverified with a mixed record", reporter: &entity.User{}, stats: &contract.ReporterStats{Upheld: 2, Dismissed: 2}, want: 0.625},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, moderator.ReportWeight(tt.reporter, tt.stats), 1e-9)
+		})
+	}
+}
+
+func TestCommentModerator_ShouldHide(t *testing.T) {
+	tests := []struct {
+		threshold float64
+		score     float64
+		want      bool
+	}{
+		{threshold: 3, score: 2.5, want: false},
+		{threshold: 3, score: 3, want: true},
+		{threshold: 0, score: 10, want: false},
+	}
+	for _, tt := range tests {
+		moderator := usecase.NewCommentModerator(usecase.CommentModerationConfig{ReportHideThreshold: tt.threshold})
+		assert.Equal(t, tt.want, moderator.ShouldHide(tt.score))
+	}
+}
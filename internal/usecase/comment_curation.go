@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+// maxPinnedComments caps how many comments a blog author may pin on one post.
+const maxPinnedComments = 3
+
+// PinComment pins a top-level comment so it is listed ahead of the others on its blog.
+// Only the blog author may pin, and only published comments can be pinned.
+func (uc *commentUseCase) PinComment(ctx context.Context, commentID, userID string) (*dto.CommentResponse, error) {
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.getOwnedBlog(ctx, comment.BlogID, userID); err != nil {
+		return nil, err
+	}
+	if comment.ParentID != nil && *comment.ParentID != "" {
+		return nil, errors.New("invalid pin: only top-level comments can be pinned")
+	}
+	if comment.Status != entity.CommentStatusApproved {
+		return nil, errors.New("invalid pin: only published comments can be pinned")
+	}
+	if comment.PinnedAt != nil {
+		return uc.toCommentResponse(ctx, comment, &userID)
+	}
+
+	// Pins on comments hidden by moderation are kept but not shown, so only published ones
+	// count toward the limit. The count and the pin run in one unit of work.
+	now := time.Now()
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		pinned, err := uc.commentRepo.GetPinnedComments(ctx, comment.BlogID)
+		if err != nil {
+			return err
+		}
+		shown := 0
+		for _, p := range pinned {
+			if p.Status == entity.CommentStatusApproved {
+				shown++
+			}
+		}
+		if shown >= maxPinnedComments {
+			return fmt.Errorf("invalid pin: at most %d comments can be pinned, unpin one first", maxPinnedComments)
+		}
+		if err := uc.commentRepo.SetPinned(ctx, comment.ID, &now); err != nil {
+			return fmt.Errorf("failed to pin comment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.PinnedAt = &now
+
+	return uc.toCommentResponse(ctx, comment, &userID)
+}
+
+// UnpinComment returns a pinned comment to its normal place in the listing.
+func (uc *commentUseCase) UnpinComment(ctx context.Context, commentID, userID string) error {
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.getOwnedBlog(ctx, comment.BlogID, userID); err != nil {
+		return err
+	}
+	if comment.PinnedAt == nil {
+		return nil
+	}
+
+	if err := uc.commentRepo.SetPinned(ctx, comment.ID, nil); err != nil {
+		return fmt.Errorf("failed to unpin comment: %w", err)
+	}
+	return nil
+}
+
+// SetBlogCommentsLocked stops (or resumes) new comments and replies on a blog.
+// Existing comments stay visible either way.
+func (uc *commentUseCase) SetBlogCommentsLocked(ctx context.Context, blogID, userID string, locked bool) error {
+	blog, err := uc.getOwnedBlog(ctx, blogID, userID)
+	if err != nil {
+		return err
+	}
+	if blog.CommentsLocked == locked {
+		return nil
+	}
+
+	if err := uc.blogRepo.UpdateBlog(ctx, blog.ID, map[string]interface{}{"comments_locked": locked}); err != nil {
+		return fmt.Errorf("failed to update comment lock: %w", err)
+	}
+	return nil
+}
+
+// pinnedComments returns the published pinned comments of a blog, oldest pin first.
+// Pins on comments that were later hidden by moderation are kept but not shown.
+func (uc *commentUseCase) pinnedComments(ctx context.Context, blogID string, userID *string) ([]*dto.CommentResponse, error) {
+	pinned, err := uc.commentRepo.GetPinnedComments(ctx, blogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pinned comments: %w", err)
+	}
+
+	published := make([]*entity.Comment, 0, len(pinned))
+	for _, comment := range pinned {
+		if comment.Status == entity.CommentStatusApproved {
+			published = append(published, comment)
+		}
+	}
+	return uc.toCommentResponses(ctx, published, userID)
+}
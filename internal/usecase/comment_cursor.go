@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// commentCursorPayload is the JSON inside an opaque comment cursor. The sort order is
+// embedded so a cursor cannot be replayed against a listing ordered differently.
+type commentCursorPayload struct {
+	Sort      string `json:"s"`
+	CreatedAt int64  `json:"t"` // unix nanoseconds
+	LikeCount int    `json:"l,omitempty"`
+	ID        string `json:"id"`
+}
+
+// encodeCommentCursor returns the opaque cursor pointing just after comment.
+func encodeCommentCursor(sort string, comment *entity.Comment) string {
+	payload, _ := json.Marshal(commentCursorPayload{
+		Sort:      sort,
+		CreatedAt: comment.CreatedAt.UnixNano(),
+		LikeCount: comment.LikeCount,
+		ID:        comment.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeCommentCursor parses a cursor produced by encodeCommentCursor for the same sort order.
+func decodeCommentCursor(cursor, sort string) (*contract.CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var payload commentCursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID == "" || payload.Sort != sort {
+		return nil, errInvalidCursor
+	}
+
+	return &contract.CommentCursor{
+		// Mongo stores millisecond precision, which the nanosecond value round-trips exactly
+		CreatedAt: time.Unix(0, payload.CreatedAt).UTC(),
+		LikeCount: payload.LikeCount,
+		ID:        payload.ID,
+	}, nil
+}
+
+// commentListPagination validates a listing request and turns it into repository pagination.
+func commentListPagination(req dto.ListCommentsRequest, defaultSort string) (contract.Pagination, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	sort := req.Sort
+	switch sort {
+	case "":
+		sort = defaultSort
+	case contract.CommentSortNewest, contract.CommentSortOldest, contract.CommentSortTop:
+	default:
+		return contract.Pagination{}, errors.New("invalid sort: must be newest, oldest or top")
+	}
+
+	pagination := contract.Pagination{Page: page, PageSize: pageSize, Sort: sort}
+	if req.Cursor != "" {
+		after, err := decodeCommentCursor(req.Cursor, sort)
+		if err != nil {
+			return contract.Pagination{}, err
+		}
+		pagination.After = after
+	}
+	return pagination, nil
+}
+
+// toCommentList converts a page of comments into a response carrying both the classic
+// pagination metadata and, when more comments may follow, the cursor for the next page.
+func (uc *commentUseCase) toCommentList(ctx context.Context, comments []*entity.Comment, total int64, pagination contract.Pagination, userID *string) (*dto.CommentsResponse, error) {
+	commentResponses, err := uc.toCommentResponses(ctx, comments, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := buildPaginationMeta(pagination.Page, pagination.PageSize, total)
+	if pagination.After != nil {
+		// Page numbers are meaningless once a reader follows cursors
+		meta.HasPrevious = true
+		meta.HasNext = len(comments) == pagination.PageSize
+	}
+
+	response := &dto.CommentsResponse{
+		Comments:   commentResponses,
+		Pagination: meta,
+	}
+	if meta.HasNext && len(comments) > 0 {
+		response.NextCursor = encodeCommentCursor(pagination.Sort, comments[len(comments)-1])
+	}
+	return response, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+// resolveMentions returns the users mentioned in content, or nil when mentions are not wired up.
+func (uc *commentUseCase) resolveMentions(ctx context.Context, content string) []entity.Mention {
+	if uc.notifications == nil {
+		return nil
+	}
+	return uc.notifications.ResolveMentions(ctx, content)
+}
+
+// notifyMentions notifies users newly mentioned in a published comment. Held or rejected
+// comments stay silent so that moderated content never pings anyone.
+func (uc *commentUseCase) notifyMentions(ctx context.Context, comment *entity.Comment, previous []entity.Mention) {
+	if uc.notifications == nil || comment.Status != entity.CommentStatusApproved || len(comment.Mentions) == 0 {
+		return
+	}
+	_ = uc.notifications.NotifyMentions(ctx, entity.NotificationTypeCommentMention, comment.ID, comment.AuthorID, comment.Mentions, previous)
+}
+
+func toMentionResponses(mentions []entity.Mention) []*dto.MentionResponse {
+	if len(mentions) == 0 {
+		return nil
+	}
+	responses := make([]*dto.MentionResponse, len(mentions))
+	for i, m := range mentions {
+		responses[i] = &dto.MentionResponse{UserID: m.UserID, Username: m.Username}
+	}
+	return responses
+}
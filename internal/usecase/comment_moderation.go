@@ -0,0 +1,519 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+// maxBulkModerationSize caps how many comments a single bulk action may touch.
+const maxBulkModerationSize = 100
+
+// Moderation
+// Callers are expected to have verified the moderator role (see middleware.RequireRole).
+func (uc *commentUseCase) UpdateCommentStatus(ctx context.Context, commentID, moderatorID string, req dto.UpdateCommentStatusRequest) error {
+	if !isValidCommentStatus(req.Status) {
+		return fmt.Errorf("invalid comment status: %s", req.Status)
+	}
+
+	// Read the current status in the transaction so the counters move exactly once
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		comment, err := uc.commentRepo.GetByID(ctx, commentID)
+		if err != nil {
+			return err
+		}
+		if err := uc.commentRepo.UpdateStatus(ctx, commentID, req.Status); err != nil {
+			return err
+		}
+		if delta := publishedDelta(comment.Status, req.Status); delta != 0 {
+			return uc.adjustCommentCounters(ctx, comment, delta)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.recordModeration(ctx, &entity.ModerationAction{
+		ModeratorID: moderatorID,
+		Action:      entity.ModerationActionStatusChange,
+		TargetType:  "comment",
+		TargetID:    commentID,
+		Notes:       moderationNotes("status set to "+req.Status, req.Reason),
+	})
+	return nil
+}
+
+// publishedDelta is how a status change moves the comment counters: +1 when the comment
+// becomes approved, -1 when it stops being approved and 0 otherwise.
+func publishedDelta(from, to string) int {
+	switch {
+	case from != entity.CommentStatusApproved && to == entity.CommentStatusApproved:
+		return 1
+	case from == entity.CommentStatusApproved && to != entity.CommentStatusApproved:
+		return -1
+	}
+	return 0
+}
+
+// GetModerationQueue lists comments awaiting review, oldest first.
+func (uc *commentUseCase) GetModerationQueue(ctx context.Context, status string, page, pageSize int) (*dto.CommentsResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	statuses := []string{entity.CommentStatusPending}
+	switch status {
+	case "":
+	case entity.CommentStatusPending, entity.CommentStatusFlagged, entity.CommentStatusHidden:
+		statuses = []string{status}
+	case "all":
+		statuses = []string{entity.CommentStatusPending, entity.CommentStatusFlagged, entity.CommentStatusHidden}
+	default:
+		return nil, fmt.Errorf("invalid queue status: %s", status)
+	}
+
+	pagination := contract.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	comments, total, err := uc.commentRepo.GetCommentsByStatus(ctx, statuses, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation queue: %w", err)
+	}
+
+	commentResponses, err := uc.toCommentResponses(ctx, comments, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CommentsResponse{
+		Comments:   commentResponses,
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}, nil
+}
+
+// GetReportedComments lists comments with pending reports, grouped per comment.
+func (uc *commentUseCase) GetReportedComments(ctx context.Context, page, pageSize int) (*dto.ReportedCommentsResponse, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	pagination := contract.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	summaries, total, err := uc.commentRepo.GetReportedComments(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reported comments: %w", err)
+	}
+
+	commentIDs := make([]string, len(summaries))
+	for i, summary := range summaries {
+		commentIDs[i] = summary.CommentID
+	}
+	comments, err := uc.commentRepo.GetByIDs(ctx, commentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reported comments: %w", err)
+	}
+	commentResponses, err := uc.toCommentResponses(ctx, comments, nil)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*dto.CommentResponse, len(commentResponses))
+	for _, response := range commentResponses {
+		byID[response.ID] = response
+	}
+
+	items := make([]*dto.ReportedCommentResponse, len(summaries))
+	for i, summary := range summaries {
+		// The comment may have been deleted since it was reported; keep the summary regardless
+		items[i] = &dto.ReportedCommentResponse{
+			Comment:        byID[summary.CommentID],
+			ReportCount:    summary.ReportCount,
+			Reasons:        summary.Reasons,
+			LatestReportAt: summary.LatestReportAt,
+		}
+	}
+
+	return &dto.ReportedCommentsResponse{
+		Items:      items,
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}, nil
+}
+
+// BulkModerateComments approves, rejects or deletes a batch of comments in one call.
+func (uc *commentUseCase) BulkModerateComments(ctx context.Context, moderatorID string, req dto.BulkModerationRequest) (*dto.BulkModerationResponse, error) {
+	if len(req.CommentIDs) == 0 {
+		return nil, errors.New("at least one comment ID is required")
+	}
+	if len(req.CommentIDs) > maxBulkModerationSize {
+		return nil, fmt.Errorf("too many comments: at most %d per request", maxBulkModerationSize)
+	}
+
+	var action entity.ModerationActionType
+	switch req.Action {
+	case "approve":
+		action = entity.ModerationActionApprove
+	case "reject":
+		action = entity.ModerationActionReject
+	case "delete":
+		action = entity.ModerationActionDelete
+	default:
+		return nil, fmt.Errorf("invalid moderation action: %s", req.Action)
+	}
+
+	// Apply the action and move the blog and parent counters of every comment it publishes
+	// or unpublishes in one transaction. Only comments the action actually changes are
+	// written and audited; missing, deleted and already moderated ones are left alone.
+	var affected int64
+	var changed []*entity.Comment
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		comments, err := uc.commentRepo.GetByIDs(ctx, req.CommentIDs)
+		if err != nil {
+			return err
+		}
+		changed = changed[:0]
+		for _, comment := range comments {
+			switch {
+			case req.Action == "approve" && comment.Status == entity.CommentStatusApproved,
+				req.Action == "reject" && comment.Status == entity.CommentStatusRejected:
+				continue
+			}
+			changed = append(changed, comment)
+		}
+		ids := make([]string, len(changed))
+		for i, comment := range changed {
+			ids[i] = comment.ID
+		}
+
+		switch req.Action {
+		case "approve":
+			affected, err = uc.commentRepo.BulkUpdateStatus(ctx, ids, entity.CommentStatusApproved)
+		case "reject":
+			affected, err = uc.commentRepo.BulkUpdateStatus(ctx, ids, entity.CommentStatusRejected)
+		case "delete":
+			affected, err = uc.commentRepo.BulkDelete(ctx, ids)
+		}
+		if err != nil {
+			return err
+		}
+
+		blogDeltas := make(map[string]int)
+		parentDeltas := make(map[string]int)
+		for _, comment := range changed {
+			var delta int
+			if req.Action == "approve" {
+				delta = publishedDelta(comment.Status, entity.CommentStatusApproved)
+			} else if comment.Status == entity.CommentStatusApproved {
+				delta = -1
+			}
+			if delta == 0 {
+				continue
+			}
+			blogDeltas[comment.BlogID] += delta
+			if comment.ParentID != nil && *comment.ParentID != "" {
+				parentDeltas[*comment.ParentID] += delta
+			}
+		}
+		for blogID, delta := range blogDeltas {
+			if err := uc.blogRepo.IncrementCommentCount(ctx, blogID, delta); err != nil {
+				return fmt.Errorf("failed to update blog comment count: %w", err)
+			}
+		}
+		for parentID, delta := range parentDeltas {
+			if err := uc.commentRepo.IncrementReplyCount(ctx, parentID, delta); err != nil {
+				return fmt.Errorf("failed to update parent reply count: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s comments: %w", req.Action, err)
+	}
+
+	actions := make([]*entity.ModerationAction, len(changed))
+	for i, comment := range changed {
+		actions[i] = &entity.ModerationAction{
+			ModeratorID: moderatorID,
+			Action:      action,
+			TargetType:  "comment",
+			TargetID:    comment.ID,
+			Notes:       req.Reason,
+		}
+	}
+	uc.recordModeration(ctx, actions...)
+
+	return &dto.BulkModerationResponse{
+		Action:         req.Action,
+		TotalRequested: len(req.CommentIDs),
+		AffectedCount:  affected,
+	}, nil
+}
+
+// ResolveReport closes a report as reviewed or dismissed with the reviewer's notes.
+func (uc *commentUseCase) ResolveReport(ctx context.Context, reportID, reviewerID string, req dto.ResolveReportRequest) error {
+	if req.Status != entity.ReportStatusReviewed && req.Status != entity.ReportStatusDismissed {
+		return fmt.Errorf("invalid report status: %s", req.Status)
+	}
+
+	report, err := uc.commentRepo.GetReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.commentRepo.UpdateReportStatus(ctx, reportID, req.Status, reviewerID, req.Notes); err != nil {
+		return err
+	}
+
+	uc.recordModeration(ctx, &entity.ModerationAction{
+		ModeratorID: reviewerID,
+		Action:      entity.ModerationActionResolveReport,
+		TargetType:  "report",
+		TargetID:    reportID,
+		Notes:       moderationNotes(req.Status, req.Notes),
+	})
+
+	if req.Status == entity.ReportStatusDismissed {
+		uc.restoreIfAllReportsDismissed(ctx, report.CommentID)
+	}
+	return nil
+}
+
+// GetModerationLog returns the audit trail of moderator actions, newest first.
+func (uc *commentUseCase) GetModerationLog(ctx context.Context, moderatorID, targetID string, page, pageSize int) (*dto.ModerationLogResponse, error) {
+	if uc.moderationLogRepo == nil {
+		return nil, errors.New("moderation audit log is not configured")
+	}
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filter := contract.ModerationLogFilter{
+		ModeratorID: moderatorID,
+		TargetID:    targetID,
+	}
+	pagination := contract.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	actions, total, err := uc.moderationLogRepo.List(ctx, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation log: %w", err)
+	}
+
+	responses := make([]*dto.ModerationActionResponse, len(actions))
+	for i, action := range actions {
+		responses[i] = &dto.ModerationActionResponse{
+			ID:          action.ID,
+			ModeratorID: action.ModeratorID,
+			Action:      string(action.Action),
+			TargetType:  action.TargetType,
+			TargetID:    action.TargetID,
+			Notes:       action.Notes,
+			CreatedAt:   action.CreatedAt,
+		}
+	}
+
+	return &dto.ModerationLogResponse{
+		Actions:    responses,
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}, nil
+}
+
+// GetCommentEditHistory returns a comment together with every earlier version of its content.
+func (uc *commentUseCase) GetCommentEditHistory(ctx context.Context, commentID string) (*dto.CommentEditHistoryResponse, error) {
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := uc.commentRepo.GetCommentEdits(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment edit history: %w", err)
+	}
+
+	commentResponse, err := uc.toCommentResponse(ctx, comment, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	editResponses := make([]*dto.CommentEditResponse, len(edits))
+	for i, edit := range edits {
+		editResponses[i] = &dto.CommentEditResponse{
+			ID:              edit.ID,
+			EditorID:        edit.EditorID,
+			PreviousContent: edit.PreviousContent,
+			EditedAt:        edit.EditedAt,
+		}
+	}
+
+	return &dto.CommentEditHistoryResponse{
+		Comment: commentResponse,
+		Edits:   editResponses,
+	}, nil
+}
+
+// applyReportThreshold hides an approved comment once the weighted score of its open reports,
+// counting each reporter once, crosses the configured threshold, and notifies the author.
+func (uc *commentUseCase) applyReportThreshold(ctx context.Context, comment *entity.Comment, pending []*entity.CommentReport) {
+	if comment.Status != entity.CommentStatusApproved {
+		return
+	}
+
+	score := 0.0
+	reporters := make(map[string]struct{}, len(pending))
+	for _, report := range pending {
+		if _, seen := reporters[report.ReporterID]; seen || report.ReporterID == comment.AuthorID {
+			continue
+		}
+		reporters[report.ReporterID] = struct{}{}
+
+		var reporter *entity.User
+		if user, err := uc.userRepo.GetUserByID(ctx, report.ReporterID); err == nil {
+			reporter = user
+		}
+		stats, _ := uc.commentRepo.GetReporterStats(ctx, report.ReporterID)
+		score += uc.moderator.ReportWeight(reporter, stats)
+	}
+
+	if !uc.moderator.ShouldHide(score) {
+		return
+	}
+
+	// The conditional update fails if another report or a moderator changed the status first,
+	// in which case the counters are left alone and nothing is recorded.
+	now := time.Now()
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.SetAutoHidden(ctx, comment.ID, entity.CommentStatusApproved, entity.CommentStatusPending, &now); err != nil {
+			return err
+		}
+		return uc.adjustCommentCounters(ctx, comment, -1)
+	})
+	if err != nil {
+		return
+	}
+	comment.Status = entity.CommentStatusPending
+	comment.AutoHiddenAt = &now
+
+	uc.recordModeration(ctx, &entity.ModerationAction{
+		ModeratorID: entity.SystemModeratorID,
+		Action:      entity.ModerationActionAutoHide,
+		TargetType:  "comment",
+		TargetID:    comment.ID,
+		Notes:       fmt.Sprintf("hidden after reports from %d users (weighted score %.2f)", len(reporters), score),
+	})
+	uc.notifyCommentHidden(ctx, comment)
+}
+
+// restoreIfAllReportsDismissed republishes an auto-hidden comment once every report against it
+// has been dismissed. Comments with upheld or still-open reports stay hidden.
+func (uc *commentUseCase) restoreIfAllReportsDismissed(ctx context.Context, commentID string) {
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil || comment.AutoHiddenAt == nil || comment.Status != entity.CommentStatusPending {
+		return
+	}
+
+	open, err := uc.commentRepo.GetReportsByComment(ctx, commentID, []string{entity.ReportStatusPending, entity.ReportStatusReviewed})
+	if err != nil || len(open) > 0 {
+		return
+	}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.SetAutoHidden(ctx, commentID, entity.CommentStatusPending, entity.CommentStatusApproved, nil); err != nil {
+			return err
+		}
+		return uc.adjustCommentCounters(ctx, comment, 1)
+	})
+	if err != nil {
+		return
+	}
+	uc.recordModeration(ctx, &entity.ModerationAction{
+		ModeratorID: entity.SystemModeratorID,
+		Action:      entity.ModerationActionRestore,
+		TargetType:  "comment",
+		TargetID:    commentID,
+		Notes:       "all reports dismissed",
+	})
+}
+
+// notifyCommentHidden emails the comment author that their comment is awaiting review.
+func (uc *commentUseCase) notifyCommentHidden(ctx context.Context, comment *entity.Comment) {
+	if uc.mailService == nil {
+		return
+	}
+	author, err := uc.userRepo.GetUserByID(ctx, comment.AuthorID)
+	if err != nil || author.Email == "" {
+		return
+	}
+
+	subject := "Your comment is under review"
+	body := fmt.Sprintf(
+		"Hi %s,\n\nYour comment has been temporarily hidden after being reported by several readers. "+
+			"A moderator will review it shortly; if the reports are dismissed it will be visible again.\n\n"+
+			"Comment: %q\n",
+		author.Username, comment.Content,
+	)
+	_ = uc.mailService.SendEmail(ctx, author.Email, subject, body)
+}
+
+// recordModeration appends to the audit trail. Failures are not surfaced to the caller
+// because the moderation decision itself has already been applied.
+func (uc *commentUseCase) recordModeration(ctx context.Context, actions ...*entity.ModerationAction) {
+	if uc.moderationLogRepo == nil || len(actions) == 0 {
+		return
+	}
+	_ = uc.moderationLogRepo.Record(ctx, actions...)
+}
+
+// moderationNotes prefixes free-form moderator notes with a short summary of the decision.
+func moderationNotes(summary, notes string) string {
+	if notes == "" {
+		return summary
+	}
+	return summary + ": " + notes
+}
+
+func isValidCommentStatus(status string) bool {
+	switch status {
+	case entity.CommentStatusApproved, entity.CommentStatusPending, entity.CommentStatusHidden,
+		entity.CommentStatusFlagged, entity.CommentStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+func buildPaginationMeta(page, pageSize int, total int64) dto.PaginationMeta {
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+	return dto.PaginationMeta{
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalItems:  total,
+		TotalPages:  totalPages,
+		HasNext:     page < totalPages,
+		HasPrevious: page > 1,
+	}
+}
@@ -6,40 +6,74 @@ import (
 	"fmt"
 	"math"
 	"strings"
-
-	// "time"
+	"time"
 
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
 	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
 )
 
+// commentSnippetLength is the maximum length, in characters, of a highlighted search snippet.
+const commentSnippetLength = 160
+
+// minCommentSearchLength is the fewest letters or digits a comment search must contain.
+const minCommentSearchLength = 2
+
 type commentUseCase struct {
-	commentRepo contract.ICommentRepository
-	blogRepo    contract.IBlogRepository
-	userRepo    contract.IUserRepository
+	commentRepo       contract.ICommentRepository
+	blogRepo          contract.IBlogRepository
+	userRepo          contract.IUserRepository
+	moderationLogRepo contract.IModerationLogRepository
+	moderator         *CommentModerator
+	mailService       contract.IEmailService
+	notifications     *NotificationUsecase
+	likes             *LikeUsecase
+	uow               contract.IUnitOfWork
 }
 
 func NewCommentUseCase(
 	commentRepo contract.ICommentRepository,
 	blogRepo contract.IBlogRepository,
 	userRepo contract.IUserRepository,
+	moderationLogRepo contract.IModerationLogRepository,
+	moderator *CommentModerator,
+	mailService contract.IEmailService,
+	notifications *NotificationUsecase,
+	likes *LikeUsecase,
+	uow contract.IUnitOfWork,
 ) usecasecontract.ICommentUseCase {
+	if moderator == nil {
+		moderator = NewCommentModerator(CommentModerationConfig{})
+	}
+	if uow == nil {
+		uow = directUnitOfWork{}
+	}
 	return &commentUseCase{
-		commentRepo: commentRepo,
-		blogRepo:    blogRepo,
-		userRepo:    userRepo,
+		commentRepo:       commentRepo,
+		blogRepo:          blogRepo,
+		userRepo:          userRepo,
+		moderationLogRepo: moderationLogRepo,
+		moderator:         moderator,
+		mailService:       mailService,
+		notifications:     notifications,
+		likes:             likes,
+		uow:               uow,
 	}
 }
 
 // Core Operations
 func (uc *commentUseCase) CreateComment(ctx context.Context, req dto.CreateCommentRequest, userID, blogID string) (*dto.CommentResponse, error) {
 	// Validate blog exists
-	_, err := uc.blogRepo.GetBlogByID(ctx, blogID)
+	blog, err := uc.blogRepo.GetBlogByID(ctx, blogID)
 	if err != nil {
 		return nil, fmt.Errorf("blog not found: %w", err)
 	}
+	// The blog author can still take part in a discussion they have locked
+	if blog.CommentsLocked && blog.AuthorID != userID {
+		return nil, errors.New("comments are locked on this blog")
+	}
 
 	// Validate content
 	if err := uc.validateContent(req.Content); err != nil {
@@ -63,15 +97,12 @@ func (uc *commentUseCase) CreateComment(ctx context.Context, req dto.CreateComme
 		}
 	}
 
-	replyCount := 0
-	if req.ParentID != nil && *req.ParentID != "" {
+	isReply := req.ParentID != nil && *req.ParentID != ""
+	if isReply {
 		parent, err := uc.commentRepo.GetByID(ctx, *req.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid parent/target relationship: parent comment not found: %w", err)
 		}
-		replyCount = parent.ReplyCount + 1
-		parent.ReplyCount = replyCount
-		_ = uc.commentRepo.Update(ctx, parent)
 
 		// If no explicit target provided, default target to the parent comment's author
 		if (req.TargetID == nil || *req.TargetID == "") && targetUserName == "" {
@@ -81,13 +112,21 @@ func (uc *commentUseCase) CreateComment(ctx context.Context, req dto.CreateComme
 
 	// Fetch author name from userRepo
 	authorName := ""
+	var author *entity.User
 	if uc.userRepo != nil {
 		user, err := uc.userRepo.GetUserByID(ctx, userID)
 		if err == nil {
+			author = user
 			authorName = user.Username
 		}
 	}
 
+	verdict := uc.moderator.Evaluate(CommentModerationInput{
+		Content: req.Content,
+		Author:  author,
+		Blog:    blog,
+	})
+
 	comment := &entity.Comment{
 		BlogID:         blogID,
 		AuthorID:       userID,
@@ -97,14 +136,28 @@ func (uc *commentUseCase) CreateComment(ctx context.Context, req dto.CreateComme
 		TargetID:       req.TargetID,
 		Type:           commentType,
 		TargetUserName: targetUserName,
-		Status:         "approved",
+		Status:         verdict.Verdict.Status(),
 		ReplyCount:     0,
+		Mentions:       uc.resolveMentions(ctx, req.Content),
 	}
 
-	// Create comment
-	if err := uc.commentRepo.Create(ctx, comment); err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+	// Create the comment and bump the blog's comment count and the parent's reply count
+	// together. Only published comments are counted, matching what the counter reconciler
+	// recomputes.
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.Create(ctx, comment); err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		if comment.Status == entity.CommentStatusApproved {
+			return uc.adjustCommentCounters(ctx, comment, 1)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	uc.recordAutoModeration(ctx, comment.ID, verdict)
+	uc.notifyMentions(ctx, comment, nil)
 
 	// Update blog popularity after comment creation
 	if blogID != "" && uc.blogRepo != nil {
@@ -140,16 +193,61 @@ func (uc *commentUseCase) UpdateComment(ctx context.Context, commentID, userID s
 		return nil, errors.New("unauthorized: can only edit your own comments")
 	}
 
+	if req.EditWindow > 0 && time.Since(comment.CreatedAt) > req.EditWindow {
+		return nil, errors.New("comment edit time window has expired")
+	}
+
 	// Validate content
 	if err := uc.validateContent(req.Content); err != nil {
 		return nil, err
 	}
 
-	// Update comment
-	comment.Content = strings.TrimSpace(req.Content)
-	if err := uc.commentRepo.Update(ctx, comment); err != nil {
-		return nil, fmt.Errorf("failed to update comment: %w", err)
+	content := strings.TrimSpace(req.Content)
+	if content == comment.Content {
+		return uc.toCommentResponse(ctx, comment, &userID)
+	}
+	previousContent := comment.Content
+	previousMentions := comment.Mentions
+
+	// Re-run auto-moderation on the new content. Edits can only tighten the status of a
+	// published comment; they never release a comment a moderator has held or rejected.
+	verdict := uc.moderateEdit(ctx, comment, req.Content)
+
+	// Record the snapshot of what the comment said before, save the new content and tighten
+	// the status together, so no edit is ever saved without its history
+	comment.Content = content
+	comment.Mentions = uc.resolveMentions(ctx, content)
+	edit := &entity.CommentEdit{
+		CommentID:       comment.ID,
+		EditorID:        userID,
+		PreviousContent: previousContent,
+	}
+	tighten := verdict.Verdict != CommentVerdictApprove && comment.Status == entity.CommentStatusApproved
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.RecordEdit(ctx, edit); err != nil {
+			return fmt.Errorf("failed to record comment edit: %w", err)
+		}
+		if err := uc.commentRepo.Update(ctx, comment); err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		if !tighten {
+			return nil
+		}
+		if err := uc.commentRepo.UpdateStatus(ctx, comment.ID, verdict.Verdict.Status()); err != nil {
+			return fmt.Errorf("failed to update comment status: %w", err)
+		}
+		return uc.adjustCommentCounters(ctx, comment, -1)
+	})
+	if err != nil {
+		return nil, err
 	}
+	comment.EditCount++
+	comment.EditedAt = &edit.EditedAt
+	if tighten {
+		comment.Status = verdict.Verdict.Status()
+	}
+	uc.recordAutoModeration(ctx, comment.ID, verdict)
+	uc.notifyMentions(ctx, comment, previousMentions)
 
 	return uc.toCommentResponse(ctx, comment, &userID)
 }
@@ -166,7 +264,16 @@ func (uc *commentUseCase) DeleteComment(ctx context.Context, commentID, userID s
 		return errors.New("unauthorized: can only delete your own comments")
 	}
 
-	err = uc.commentRepo.Delete(ctx, commentID)
+	// Delete the comment and, if it was published, uncount it in the same transaction
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.Delete(ctx, commentID); err != nil {
+			return err
+		}
+		if comment.Status == entity.CommentStatusApproved {
+			return uc.adjustCommentCounters(ctx, comment, -1)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -182,19 +289,26 @@ func (uc *commentUseCase) DeleteComment(ctx context.Context, commentID, userID s
 	return nil
 }
 
-// Listing Operations
-func (uc *commentUseCase) GetBlogComments(ctx context.Context, blogID string, page, pageSize int, userID *string) (*dto.CommentsResponse, error) {
-	// Validate pagination
-	if page < 1 {
-		page = 1
+// adjustCommentCounters adds delta to the blog's comment count and, for a reply, to the
+// parent's reply count. Call it with +1 when a comment becomes published and -1 when it stops
+// being published, inside the unit of work that writes the change.
+func (uc *commentUseCase) adjustCommentCounters(ctx context.Context, comment *entity.Comment, delta int) error {
+	if err := uc.blogRepo.IncrementCommentCount(ctx, comment.BlogID, delta); err != nil {
+		return fmt.Errorf("failed to update blog comment count: %w", err)
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	if comment.ParentID != nil && *comment.ParentID != "" {
+		if err := uc.commentRepo.IncrementReplyCount(ctx, *comment.ParentID, delta); err != nil {
+			return fmt.Errorf("failed to update parent reply count: %w", err)
+		}
 	}
+	return nil
+}
 
-	pagination := contract.Pagination{
-		Page:     page,
-		PageSize: pageSize,
+// Listing Operations
+func (uc *commentUseCase) GetBlogComments(ctx context.Context, blogID string, req dto.ListCommentsRequest, userID *string) (*dto.CommentsResponse, error) {
+	pagination, err := commentListPagination(req, contract.CommentSortNewest)
+	if err != nil {
+		return nil, err
 	}
 
 	comments, total, err := uc.commentRepo.GetTopLevelComments(ctx, blogID, pagination)
@@ -202,30 +316,39 @@ func (uc *commentUseCase) GetBlogComments(ctx context.Context, blogID string, pa
 		return nil, fmt.Errorf("failed to get blog comments: %w", err)
 	}
 
-	// Convert to response DTOs
-	commentResponses := make([]*dto.CommentResponse, len(comments))
-	for i, comment := range comments {
-		commentResponses[i], err = uc.toCommentResponse(ctx, comment, userID)
+	response, err := uc.toCommentList(ctx, comments, total, pagination, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pinned comments lead the first page regardless of sort
+	if pagination.After == nil && pagination.Page == 1 {
+		pinned, err := uc.pinnedComments(ctx, blogID, userID)
 		if err != nil {
 			return nil, err
 		}
+		response.Comments = append(pinned, response.Comments...)
 	}
+	return response, nil
+}
 
-	// Create pagination meta
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
-	paginationMeta := dto.PaginationMeta{
-		CurrentPage: page,
-		PageSize:    pageSize,
-		TotalItems:  total,
-		TotalPages:  totalPages,
-		HasNext:     page < totalPages,
-		HasPrevious: page > 1,
+// GetCommentReplies lists every reply below a comment as a flat page, oldest first by default.
+func (uc *commentUseCase) GetCommentReplies(ctx context.Context, commentID string, req dto.ListCommentsRequest, userID *string) (*dto.CommentsResponse, error) {
+	pagination, err := commentListPagination(req, contract.CommentSortOldest)
+	if err != nil {
+		return nil, err
 	}
 
-	return &dto.CommentsResponse{
-		Comments:   commentResponses,
-		Pagination: paginationMeta,
-	}, nil
+	if _, err := uc.commentRepo.GetByID(ctx, commentID); err != nil {
+		return nil, err
+	}
+
+	replies, total, err := uc.commentRepo.GetReplies(ctx, commentID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment replies: %w", err)
+	}
+
+	return uc.toCommentList(ctx, replies, total, pagination, userID)
 }
 
 func (uc *commentUseCase) GetCommentThread(ctx context.Context, commentID string, userID *string) (*dto.CommentThreadResponse, error) {
@@ -237,31 +360,84 @@ func (uc *commentUseCase) GetCommentThread(ctx context.Context, commentID string
 	return uc.toCommentThreadResponse(ctx, thread, userID)
 }
 
-func (uc *commentUseCase) GetUserComments(ctx context.Context, userID string, page, pageSize int) (*dto.CommentsResponse, error) {
+func (uc *commentUseCase) GetUserComments(ctx context.Context, userID string, req dto.ListCommentsRequest) (*dto.CommentsResponse, error) {
+	pagination, err := commentListPagination(req, contract.CommentSortNewest)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, total, err := uc.commentRepo.GetCommentsByUser(ctx, userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user comments: %w", err)
+	}
+
+	return uc.toCommentList(ctx, comments, total, pagination, &userID)
+}
+
+// Search
+func (uc *commentUseCase) SearchComments(ctx context.Context, req dto.SearchCommentsRequest, userID *string, isModerator bool) (*dto.CommentSearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, errors.New("invalid query: search query is required")
+	}
+	if len([]rune(strings.Join(utils.SearchTerms(query), ""))) < minCommentSearchLength {
+		return nil, fmt.Errorf("invalid query: use at least %d letters or digits", minCommentSearchLength)
+	}
+
 	// Validate pagination
+	page, pageSize := req.Page, req.PageSize
 	if page < 1 {
-		page = 1
+		return nil, errors.New("invalid page: must be at least 1")
 	}
 	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+		return nil, errors.New("invalid page_size: must be between 1 and 100")
 	}
 
+	// Regular users only ever see approved comments; moderators may filter by any status
+	statuses := []string{"approved"}
+	if isModerator {
+		switch req.Status {
+		case "", "all":
+			statuses = nil
+		default:
+			statuses = []string{req.Status}
+		}
+	}
+
+	opts := contract.CommentSearchOptions{
+		Query:    query,
+		BlogID:   req.BlogID,
+		AuthorID: req.AuthorID,
+		Statuses: statuses,
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	}
 	pagination := contract.Pagination{
 		Page:     page,
 		PageSize: pageSize,
 	}
 
-	comments, total, err := uc.commentRepo.GetCommentsByUser(ctx, userID, pagination)
+	hits, total, err := uc.commentRepo.SearchComments(ctx, opts, pagination)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user comments: %w", err)
+		return nil, fmt.Errorf("failed to search comments: %w", err)
 	}
 
-	// Convert to response DTOs
-	commentResponses := make([]*dto.CommentResponse, len(comments))
-	for i, comment := range comments {
-		commentResponses[i], err = uc.toCommentResponse(ctx, comment, &userID)
-		if err != nil {
-			return nil, err
+	// Convert to response DTOs with highlighted snippets
+	terms := utils.SearchTerms(query)
+	comments := make([]*entity.Comment, len(hits))
+	for i, hit := range hits {
+		comments[i] = hit.Comment
+	}
+	commentResponses, err := uc.toCommentResponses(ctx, comments, userID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*dto.CommentSearchHit, len(hits))
+	for i, hit := range hits {
+		results[i] = &dto.CommentSearchHit{
+			Comment:   commentResponses[i],
+			Score:     hit.Score,
+			Highlight: utils.HighlightSnippet(hit.Comment.Content, terms, commentSnippetLength),
 		}
 	}
 
@@ -276,21 +452,16 @@ func (uc *commentUseCase) GetUserComments(ctx context.Context, userID string, pa
 		HasPrevious: page > 1,
 	}
 
-	return &dto.CommentsResponse{
-		Comments:   commentResponses,
+	return &dto.CommentSearchResponse{
+		Query:      query,
+		Results:    results,
 		Pagination: paginationMeta,
 	}, nil
 }
 
-// Moderation
-func (uc *commentUseCase) UpdateCommentStatus(ctx context.Context, commentID, moderatorID string, req dto.UpdateCommentStatusRequest) error {
-	// Here you would check if moderatorID has admin/moderator role
-	// For now, we'll assume they do
-
-	return uc.commentRepo.UpdateStatus(ctx, commentID, req.Status)
-}
-
 // Engagement
+// LikeComment likes a comment through the shared reaction system. A dislike by the same
+// user is turned into a like.
 func (uc *commentUseCase) LikeComment(ctx context.Context, commentID, userID string) error {
 	// Check if comment exists
 	_, err := uc.commentRepo.GetByID(ctx, commentID)
@@ -298,7 +469,14 @@ func (uc *commentUseCase) LikeComment(ctx context.Context, commentID, userID str
 		return err
 	}
 
-	return uc.commentRepo.LikeComment(ctx, commentID, userID)
+	reaction, err := uc.likes.GetUserReaction(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	if reaction != nil && reaction.Type == entity.LIKE_TYPE_LIKE {
+		return errors.New("comment already liked by user")
+	}
+	return uc.likes.ToggleLike(ctx, userID, commentID, entity.TargetTypeComment)
 }
 
 func (uc *commentUseCase) UnlikeComment(ctx context.Context, commentID, userID string) error {
@@ -308,16 +486,34 @@ func (uc *commentUseCase) UnlikeComment(ctx context.Context, commentID, userID s
 		return err
 	}
 
-	return uc.commentRepo.UnlikeComment(ctx, commentID, userID)
+	reaction, err := uc.likes.GetUserReaction(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	if reaction == nil || reaction.Type != entity.LIKE_TYPE_LIKE {
+		return errors.New("comment not liked by user")
+	}
+	return uc.likes.ToggleLike(ctx, userID, commentID, entity.TargetTypeComment)
 }
 
 // Reporting
 func (uc *commentUseCase) ReportComment(ctx context.Context, commentID, userID string, req dto.ReportCommentRequest) error {
 	// Check if comment exists
-	_, err := uc.commentRepo.GetByID(ctx, commentID)
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	// Only one open report per user counts towards the hide threshold
+	pending, err := uc.commentRepo.GetReportsByComment(ctx, commentID, []string{entity.ReportStatusPending})
 	if err != nil {
 		return err
 	}
+	for _, existing := range pending {
+		if existing.ReporterID == userID {
+			return errors.New("comment already reported by user")
+		}
+	}
 
 	report := &entity.CommentReport{
 		CommentID:  commentID,
@@ -326,7 +522,12 @@ func (uc *commentUseCase) ReportComment(ctx context.Context, commentID, userID s
 		Details:    req.Details,
 	}
 
-	return uc.commentRepo.ReportComment(ctx, report)
+	if err := uc.commentRepo.ReportComment(ctx, report); err != nil {
+		return err
+	}
+
+	uc.applyReportThreshold(ctx, comment, append(pending, report))
+	return nil
 }
 
 func (uc *commentUseCase) GetCommentReports(ctx context.Context, page, pageSize int) (*dto.ReportsResponse, error) {
@@ -361,6 +562,7 @@ func (uc *commentUseCase) GetCommentReports(ctx context.Context, page, pageSize
 			CreatedAt:  report.CreatedAt,
 			ReviewedAt: report.ReviewedAt,
 			ReviewedBy: report.ReviewedBy,
+			Notes:      report.Notes,
 		}
 	}
 
@@ -381,10 +583,6 @@ func (uc *commentUseCase) GetCommentReports(ctx context.Context, page, pageSize
 	}, nil
 }
 
-func (uc *commentUseCase) UpdateReportStatus(ctx context.Context, reportID, reviewerID string, status string) error {
-	return uc.commentRepo.UpdateReportStatus(ctx, reportID, status, reviewerID)
-}
-
 // Helper Methods
 func (uc *commentUseCase) validateContent(content string) error {
 	content = strings.TrimSpace(content)
@@ -397,78 +595,152 @@ func (uc *commentUseCase) validateContent(content string) error {
 		return errors.New("comment content too long (max 1000 characters)")
 	}
 
-	// Add profanity filter, spam detection, etc.
-	if uc.containsProfanity(content) {
-		return errors.New("comment contains inappropriate language")
-	}
-
 	return nil
 }
 
-func (uc *commentUseCase) containsProfanity(content string) bool {
-	// Implement profanity detection logic
-	// For now, return false
-	if strings.Contains(strings.ToLower(content), "badword") {
-		return true
+func (uc *commentUseCase) toCommentResponse(ctx context.Context, comment *entity.Comment, userID *string) (*dto.CommentResponse, error) {
+	responses, err := uc.toCommentResponses(ctx, []*entity.Comment{comment}, userID)
+	if err != nil {
+		return nil, err
 	}
-	return false
+	return responses[0], nil
 }
 
-func (uc *commentUseCase) toCommentResponse(ctx context.Context, comment *entity.Comment, userID *string) (*dto.CommentResponse, error) {
-	// Get author name
-	author, err := uc.userRepo.GetUserByID(ctx, comment.AuthorID)
+// toCommentResponses converts a page of comments, loading their authors, blogs and the
+// viewer's reactions with one query each rather than per comment.
+func (uc *commentUseCase) toCommentResponses(ctx context.Context, comments []*entity.Comment, userID *string) ([]*dto.CommentResponse, error) {
+	commentIDs := make([]string, len(comments))
+	authorSet := make(map[string]struct{})
+	blogSet := make(map[string]struct{})
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+		authorSet[comment.AuthorID] = struct{}{}
+		blogSet[comment.BlogID] = struct{}{}
+	}
+	authorIDs := make([]string, 0, len(authorSet))
+	for id := range authorSet {
+		authorIDs = append(authorIDs, id)
+	}
+	blogIDs := make([]string, 0, len(blogSet))
+	for id := range blogSet {
+		blogIDs = append(blogIDs, id)
+	}
+
+	users, err := uc.userRepo.GetUsersByIDs(ctx, authorIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get comment author: %w", err)
+		return nil, fmt.Errorf("failed to get comment authors: %w", err)
+	}
+	authors := make(map[string]*entity.User, len(users))
+	for _, user := range users {
+		authors[user.ID] = user
 	}
 
-	// Check if liked by current user
-	var isLiked bool
+	blogAuthors := make(map[string]string, len(blogIDs))
+	if blogs, err := uc.blogRepo.GetBlogsByIDs(ctx, blogIDs); err == nil {
+		for _, blog := range blogs {
+			blogAuthors[blog.ID] = blog.AuthorID
+		}
+	}
+
+	// Current user's reactions, if any
+	var reactions map[string]*entity.Like
 	if userID != nil {
-		isLiked, _ = uc.commentRepo.IsCommentLikedByUser(ctx, comment.ID, *userID)
+		reactions, _ = uc.likes.GetUserReactions(ctx, *userID, commentIDs)
 	}
 
-	// Use stored reply count for now (could be recalculated if needed)
-	replyCount := comment.ReplyCount
+	responses := make([]*dto.CommentResponse, len(comments))
+	for i, comment := range comments {
+		author, ok := authors[comment.AuthorID]
+		if !ok {
+			// The author's account is gone; the comment still renders under a placeholder
+			author = missingCommentAuthor(comment)
+		}
+		var userReaction string
+		if reaction, ok := reactions[comment.ID]; ok {
+			userReaction = string(reaction.Type)
+		}
+		blogAuthorID, ok := blogAuthors[comment.BlogID]
+		responses[i] = newCommentResponse(comment, author, userReaction, ok && blogAuthorID == comment.AuthorID)
+	}
+	return responses, nil
+}
+
+// missingCommentAuthor stands in for the author of a comment whose account no longer exists,
+// keeping the name stored with the comment when there is one.
+func missingCommentAuthor(comment *entity.Comment) *entity.User {
+	name := comment.AuthorName
+	if name == "" {
+		name = "[deleted]"
+	}
+	return &entity.User{ID: comment.AuthorID, Username: name}
+}
 
+// newCommentResponse builds the response for a comment from its already loaded author, the
+// viewer's reaction and whether the author wrote the blog.
+func newCommentResponse(comment *entity.Comment, author *entity.User, userReaction string, isAuthor bool) *dto.CommentResponse {
 	return &dto.CommentResponse{
-		ID:             comment.ID,
-		BlogID:         comment.BlogID,
-		Type:           comment.Type,
-		ParentID:       comment.ParentID,
-		TargetID:       comment.TargetID,
-		AuthorID:       comment.AuthorID,
-		AuthorName:     author.Username,
-		TargetUserName: comment.TargetUserName,
-		Content:        comment.Content,
-		Status:         comment.Status,
-		LikeCount:      comment.LikeCount,
-		IsLiked:        isLiked,
-		CreatedAt:      comment.CreatedAt,
-		UpdatedAt:      comment.UpdatedAt,
-		ReplyCount:     replyCount,
-	}, nil
+		ID:              comment.ID,
+		BlogID:          comment.BlogID,
+		Type:            comment.Type,
+		ParentID:        comment.ParentID,
+		TargetID:        comment.TargetID,
+		AuthorID:        comment.AuthorID,
+		AuthorName:      author.Username,
+		TargetUserName:  comment.TargetUserName,
+		Content:         comment.Content,
+		Status:          comment.Status,
+		LikeCount:       comment.LikeCount,
+		IsLiked:         userReaction == string(entity.LIKE_TYPE_LIKE),
+		DislikeCount:    comment.DislikeCount,
+		ReactionCounts:  comment.ReactionCounts,
+		UserReaction:    userReaction,
+		CreatedAt:       comment.CreatedAt,
+		UpdatedAt:       comment.UpdatedAt,
+		ReplyCount:      comment.ReplyCount,
+		AutoHiddenAt:    comment.AutoHiddenAt,
+		IsEdited:        comment.EditCount > 0,
+		EditCount:       comment.EditCount,
+		EditedAt:        comment.EditedAt,
+		Mentions:        toMentionResponses(comment.Mentions),
+		RenderedContent: utils.RenderMentions(comment.Content, comment.Mentions),
+		IsAuthor:        isAuthor,
+		IsPinned:        comment.PinnedAt != nil,
+		PinnedAt:        comment.PinnedAt,
+	}
 }
 
 func (uc *commentUseCase) toCommentThreadResponse(ctx context.Context, thread *entity.CommentThread, userID *string) (*dto.CommentThreadResponse, error) {
-	commentResponse, err := uc.toCommentResponse(ctx, thread.Comment, userID)
-	if err != nil {
-		return nil, err
+	var comments []*entity.Comment
+	var collect func(t *entity.CommentThread)
+	collect = func(t *entity.CommentThread) {
+		comments = append(comments, t.Comment)
+		for _, reply := range t.Replies {
+			collect(reply)
+		}
 	}
+	collect(thread)
 
-	response := &dto.CommentThreadResponse{
-		Comment: commentResponse,
-		Depth:   thread.Depth,
-		Replies: make([]*dto.CommentThreadResponse, len(thread.Replies)),
+	commentResponses, err := uc.toCommentResponses(ctx, comments, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	for i, reply := range thread.Replies {
-		response.Replies[i], err = uc.toCommentThreadResponse(ctx, reply, userID)
-		if err != nil {
-			return nil, err
+	// Rebuild the tree in the same depth-first order the comments were collected in
+	next := 0
+	var build func(t *entity.CommentThread) *dto.CommentThreadResponse
+	build = func(t *entity.CommentThread) *dto.CommentThreadResponse {
+		response := &dto.CommentThreadResponse{
+			Comment: commentResponses[next],
+			Depth:   t.Depth,
+			Replies: make([]*dto.CommentThreadResponse, len(t.Replies)),
+		}
+		next++
+		for i, reply := range t.Replies {
+			response.Replies[i] = build(reply)
 		}
+		return response
 	}
-
-	return response, nil
+	return build(thread), nil
 }
 
 func (uc *commentUseCase) GetBlogCommentsCount(ctx context.Context, blogID string) (int64, error) {
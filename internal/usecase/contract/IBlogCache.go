@@ -22,6 +22,10 @@ type IBlogCache interface {
 	SetBlogsPage(ctx context.Context, key string, page *CachedBlogsPage) error
 	InvalidateBlogLists(ctx context.Context) error
 
+	// Related posts (by blog ID)
+	GetRelatedPosts(ctx context.Context, blogID string) ([]entity.RelatedPost, bool, error)
+	SetRelatedPosts(ctx context.Context, blogID string, related []entity.RelatedPost) error
+
 	// Fraud detection cache helpers
 	AddRecentViewByIP(ctx context.Context, ip, blogID string, ttlSeconds int64) error
 	GetRecentViewCountByIP(ctx context.Context, ip string) (int64, error)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
 )
 
 // SortOrder defines sorting direction for list queries
@@ -21,21 +23,29 @@ type IBlogUseCase interface {
 	GetBlogDetail(ctx context.Context, slug string) (entity.Blog, error)
 	UpdateBlog(ctx context.Context, blogID, authorID string, title *string, content *string, status *entity.BlogStatus, featuredImageID *string) (*entity.Blog, error)
 	DeleteBlog(ctx context.Context, blogID, userID string, isAdmin bool) (bool, error)
-	TrackBlogView(ctx context.Context, blogID, userID, ipAddress, userAgent string) error
+	TrackBlogView(ctx context.Context, blogID string, req dto.TrackViewRequest) error
 	GetPopularBlogs(ctx context.Context, page, pageSize int) ([]entity.Blog, int, int, int, error)
-	SearchAndFilterBlogs(
-		ctx context.Context,
-		query string,
-		tags []string,
-		dateFrom *time.Time,
-		dateTo *time.Time,
-		minViews *int,
-		maxViews *int,
-		minLikes *int,
-		maxLikes *int,
-		authorID *string,
-		page int,
-		pageSize int,
-	) ([]entity.Blog, int, int, int, error)
+	SearchAndFilterBlogs(ctx context.Context, req dto.SearchBlogsRequest, viewerID string) (*BlogSearchResult, error)
 	UpdateBlogPopularity(ctx context.Context, blogID string) error
+	RebuildSearchIndex(ctx context.Context) error
+}
+
+// BlogSearchHit is a blog found by a search with the reasons it matched: its score and the
+// title and a content snippet with the matched terms marked.
+type BlogSearchHit struct {
+	Blog             entity.Blog
+	Score            float64 // text score in keyword mode, blended similarity otherwise; 0 without a query
+	TitleHighlight   string
+	ContentHighlight string
+}
+
+// BlogSearchResult is a page of search hits with facet counts over all of the matches.
+type BlogSearchResult struct {
+	Hits           []BlogSearchHit
+	TotalCount     int
+	CurrentPage    int
+	TotalPages     int
+	Mode           string
+	CorrectedQuery string // the query with misspelled terms corrected, when any were
+	Facets         contract.BlogFacets
 }
@@ -13,13 +13,29 @@ type ICommentUseCase interface {
 	DeleteComment(ctx context.Context, commentID, userID string) error
 
 	// Listing operations
-	GetBlogComments(ctx context.Context, blogID string, page, pageSize int, userID *string) (*dto.CommentsResponse, error)
+	GetBlogComments(ctx context.Context, blogID string, req dto.ListCommentsRequest, userID *string) (*dto.CommentsResponse, error)
 	GetCommentThread(ctx context.Context, commentID string, userID *string) (*dto.CommentThreadResponse, error)
-	GetUserComments(ctx context.Context, userID string, page, pageSize int) (*dto.CommentsResponse, error)
+	GetUserComments(ctx context.Context, userID string, req dto.ListCommentsRequest) (*dto.CommentsResponse, error)
+	GetCommentReplies(ctx context.Context, commentID string, req dto.ListCommentsRequest, userID *string) (*dto.CommentsResponse, error)
 	GetBlogCommentsCount(ctx context.Context, blogID string) (int64, error)
 
+	// Search
+	SearchComments(ctx context.Context, req dto.SearchCommentsRequest, userID *string, isModerator bool) (*dto.CommentSearchResponse, error)
+
 	// Moderation
 	UpdateCommentStatus(ctx context.Context, commentID, moderatorID string, req dto.UpdateCommentStatusRequest) error
+	GetModerationQueue(ctx context.Context, status string, page, pageSize int) (*dto.CommentsResponse, error)
+	GetReportedComments(ctx context.Context, page, pageSize int) (*dto.ReportedCommentsResponse, error)
+	BulkModerateComments(ctx context.Context, moderatorID string, req dto.BulkModerationRequest) (*dto.BulkModerationResponse, error)
+	GetModerationLog(ctx context.Context, moderatorID, targetID string, page, pageSize int) (*dto.ModerationLogResponse, error)
+	GetCommentEditHistory(ctx context.Context, commentID string) (*dto.CommentEditHistoryResponse, error)
+	GetBlogCommentBlocklist(ctx context.Context, blogID, userID string) (*dto.CommentBlocklistResponse, error)
+	UpdateBlogCommentBlocklist(ctx context.Context, blogID, userID string, req dto.UpdateCommentBlocklistRequest) (*dto.CommentBlocklistResponse, error)
+
+	// Blog author tools
+	PinComment(ctx context.Context, commentID, userID string) (*dto.CommentResponse, error)
+	UnpinComment(ctx context.Context, commentID, userID string) error
+	SetBlogCommentsLocked(ctx context.Context, blogID, userID string, locked bool) error
 	// Engagement
 	LikeComment(ctx context.Context, commentID, userID string) error
 	UnlikeComment(ctx context.Context, commentID, userID string) error
@@ -27,5 +43,5 @@ type ICommentUseCase interface {
 	// Reporting
 	ReportComment(ctx context.Context, commentID, userID string, req dto.ReportCommentRequest) error
 	GetCommentReports(ctx context.Context, page, pageSize int) (*dto.ReportsResponse, error)
-	UpdateReportStatus(ctx context.Context, reportID, reviewerID string, status string) error
+	ResolveReport(ctx context.Context, reportID, reviewerID string, req dto.ResolveReportRequest) error
 }
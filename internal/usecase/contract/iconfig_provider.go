@@ -1,6 +1,10 @@
 package usecasecontract
 
-import "time"
+import (
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
 
 type IConfigProvider interface {
 	GetSendActivationEmail() bool
@@ -9,4 +13,31 @@ type IConfigProvider interface {
 	GetPasswordResetTokenExpiry() time.Duration
 	GetEmailVerificationTokenExpiry() time.Duration
 	GetAIServiceAPIKey() string
+	GetCommentRejectWords() []string
+	GetCommentHoldWords() []string
+	GetCommentMaxLinks() int
+	GetCommentMaxRepeatedChars() int
+	GetCommentNewAccountHoldPeriod() time.Duration
+	GetCommentReportHideThreshold() float64
+	GetCommentEditWindowMinutes() int
+	GetBlogReactionTypes() []string
+	GetCommentReactionTypes() []string
+	GetCounterReconcileInterval() time.Duration
+	GetTrendingGravity() float64
+	GetTrendingRecalcInterval() time.Duration
+	GetAnalyticsRollupInterval() time.Duration
+	GetRelatedPostsInterval() time.Duration
+	GetEmbeddingProvider() string
+	GetEmbeddingAPIURL() string
+	GetEmbeddingAPIKey() string
+	GetEmbeddingModel() string
+	GetEmbeddingDimensions() int
+	GetEmbeddingIndexInterval() time.Duration
+	GetSearchKeywordWeight() float64
+	GetSearchMinSimilarity() float64
+	GetSearchVocabularyInterval() time.Duration
+	GetSearchBackend() string
+	GetViewFlushInterval() time.Duration
+	GetViewFraudRules() []entity.ViewFraudRule
+	GetViewIPHashSecret() string
 }
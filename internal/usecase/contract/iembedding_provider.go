@@ -0,0 +1,12 @@
+package usecasecontract
+
+import "context"
+
+// IEmbeddingProvider turns text into dense vectors whose cosine similarity reflects
+// closeness in meaning.
+type IEmbeddingProvider interface {
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Model names the embedding model; vectors from different models are not comparable.
+	Model() string
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// reconcileBatchSize is how many blogs or comments are recounted per round trip.
+	reconcileBatchSize = 200
+	// maxReportedDiscrepancies caps the discrepancies listed in a report; all of them are still fixed.
+	maxReportedDiscrepancies = 500
+)
+
+// ErrReconciliationRunning is returned when a reconciliation is requested while one is in progress.
+var ErrReconciliationRunning = errors.New("counter reconciliation already running")
+
+// CounterReconciler recomputes the denormalized blog and comment counters from the
+// reaction and comment records, reports where they drifted and repairs them.
+type CounterReconciler struct {
+	counterRepo contract.ICounterRepository
+	logger      usecasecontract.IAppLogger
+	running     sync.Mutex
+}
+
+// NewCounterReconciler creates a new CounterReconciler.
+func NewCounterReconciler(counterRepo contract.ICounterRepository, logger usecasecontract.IAppLogger) *CounterReconciler {
+	return &CounterReconciler{counterRepo: counterRepo, logger: logger}
+}
+
+// Start runs a reconciliation now and then every interval until ctx is cancelled. A
+// non-positive interval disables the schedule.
+func (r *CounterReconciler) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	runPeriodically(ctx, interval, r.logger, "counter reconciliation", func(ctx context.Context) error {
+		report, err := r.Reconcile(ctx, false)
+		if err != nil {
+			return err
+		}
+		if report.BlogsFixed > 0 || report.CommentsFixed > 0 {
+			r.logger.Warnf("counter reconciliation fixed %d blogs and %d comments", report.BlogsFixed, report.CommentsFixed)
+		}
+		return nil
+	})
+}
+
+// Reconcile checks every blog and comment. With dryRun set, discrepancies are only reported.
+func (r *CounterReconciler) Reconcile(ctx context.Context, dryRun bool) (*dto.ReconciliationReport, error) {
+	if !r.running.TryLock() {
+		return nil, ErrReconciliationRunning
+	}
+	defer r.running.Unlock()
+
+	report := &dto.ReconciliationReport{
+		StartedAt:     time.Now(),
+		DryRun:        dryRun,
+		Discrepancies: []*dto.CounterDiscrepancy{},
+	}
+	if err := r.reconcileBlogs(ctx, report); err != nil {
+		return nil, err
+	}
+	if err := r.reconcileComments(ctx, report); err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (r *CounterReconciler) reconcileBlogs(ctx context.Context, report *dto.ReconciliationReport) error {
+	afterID := ""
+	for {
+		stored, err := r.counterRepo.ListBlogCounters(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(stored) == 0 {
+			return nil
+		}
+		ids := make([]string, len(stored))
+		for i, s := range stored {
+			ids[i] = s.BlogID
+		}
+		actual, err := r.counterRepo.ComputeBlogCounters(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range stored {
+			a := actual[s.BlogID]
+			a.BlogID = s.BlogID
+			a.ViewCount = s.ViewCount
+			a.Popularity = utils.CalculatePopularity(a.ViewCount, a.LikeCount, a.DislikeCount, a.CommentCount)
+
+			before := len(report.Discrepancies)
+			found := recordDiscrepancy(report, "blog", s.BlogID, "like_count", float64(s.LikeCount), float64(a.LikeCount))
+			found = recordDiscrepancy(report, "blog", s.BlogID, "dislike_count", float64(s.DislikeCount), float64(a.DislikeCount)) || found
+			found = recordDiscrepancy(report, "blog", s.BlogID, "comment_count", float64(s.CommentCount), float64(a.CommentCount)) || found
+			found = recordCountsDiscrepancy(report, "blog", s.BlogID, s.ReactionCounts, a.ReactionCounts) || found
+			// Popularity follows from the other counters; list it only when it is the sole drift
+			if math.Abs(s.Popularity-a.Popularity) > 1e-9 {
+				if len(report.Discrepancies) == before {
+					recordDiscrepancy(report, "blog", s.BlogID, "popularity", s.Popularity, a.Popularity)
+				}
+				found = true
+			}
+
+			report.BlogsChecked++
+			if !found {
+				continue
+			}
+			if !report.DryRun {
+				set, err := r.counterRepo.SetBlogCounters(ctx, s, a)
+				if err != nil {
+					return err
+				}
+				if !set {
+					// Changed since it was read; the next run checks it again
+					report.BlogsSkipped++
+					continue
+				}
+			}
+			report.BlogsFixed++
+		}
+		afterID = stored[len(stored)-1].BlogID
+	}
+}
+
+func (r *CounterReconciler) reconcileComments(ctx context.Context, report *dto.ReconciliationReport) error {
+	afterID := ""
+	for {
+		stored, err := r.counterRepo.ListCommentCounters(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(stored) == 0 {
+			return nil
+		}
+		ids := make([]string, len(stored))
+		for i, s := range stored {
+			ids[i] = s.CommentID
+		}
+		actual, err := r.counterRepo.ComputeCommentCounters(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range stored {
+			a := actual[s.CommentID]
+			a.CommentID = s.CommentID
+
+			found := recordDiscrepancy(report, "comment", s.CommentID, "like_count", float64(s.LikeCount), float64(a.LikeCount))
+			found = recordDiscrepancy(report, "comment", s.CommentID, "dislike_count", float64(s.DislikeCount), float64(a.DislikeCount)) || found
+			found = recordDiscrepancy(report, "comment", s.CommentID, "reply_count", float64(s.ReplyCount), float64(a.ReplyCount)) || found
+			found = recordCountsDiscrepancy(report, "comment", s.CommentID, s.ReactionCounts, a.ReactionCounts) || found
+
+			report.CommentsChecked++
+			if !found {
+				continue
+			}
+			if !report.DryRun {
+				set, err := r.counterRepo.SetCommentCounters(ctx, s, a)
+				if err != nil {
+					return err
+				}
+				if !set {
+					report.CommentsSkipped++
+					continue
+				}
+			}
+			report.CommentsFixed++
+		}
+		afterID = stored[len(stored)-1].CommentID
+	}
+}
+
+// recordDiscrepancy adds a discrepancy to the report when stored and actual differ and
+// reports whether they did.
+func recordDiscrepancy(report *dto.ReconciliationReport, targetType, targetID, field string, stored, actual float64) bool {
+	if stored == actual {
+		return false
+	}
+	if len(report.Discrepancies) >= maxReportedDiscrepancies {
+		report.Truncated = true
+		return true
+	}
+	report.Discrepancies = append(report.Discrepancies, &dto.CounterDiscrepancy{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Field:      field,
+		Stored:     stored,
+		Actual:     actual,
+	})
+	return true
+}
+
+// recordCountsDiscrepancy compares per-type reaction counters, treating a missing type as zero.
+func recordCountsDiscrepancy(report *dto.ReconciliationReport, targetType, targetID string, stored, actual map[string]int64) bool {
+	found := false
+	for reactionType, n := range actual {
+		found = recordDiscrepancy(report, targetType, targetID, "reaction_counts."+reactionType, float64(stored[reactionType]), float64(n)) || found
+	}
+	for reactionType, n := range stored {
+		if _, ok := actual[reactionType]; !ok {
+			found = recordDiscrepancy(report, targetType, targetID, "reaction_counts."+reactionType, float64(n), 0) || found
+		}
+	}
+	return found
+}
@@ -0,0 +1,48 @@
+package usecase_test
+
+import (
+	"context"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// nopLogger discards every message.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{})   {}
+func (nopLogger) Infof(format string, args ...interface{})    {}
+func (nopLogger) Warnf(format string, args ...interface{})    {}
+func (nopLogger) Warningf(format string, args ...interface{}) {}
+func (nopLogger) Errorf(format string, args ...interface{})   {}
+func (nopLogger) Fatalf(format string, args ...interface{})   {}
+
+// fakeBlogRepo serves ListSearchableBlogs from a slice. Any other method panics through the
+// nil embedded interface, so a test notices when a usecase starts relying on it.
+type fakeBlogRepo struct {
+	contract.IBlogRepository
+	blogs []*entity.Blog
+}
+
+func (r *fakeBlogRepo) ListSearchableBlogs(ctx context.Context, statuses ...entity.BlogStatus) ([]*entity.Blog, error) {
+	var blogs []*entity.Blog
+	for _, b := range r.blogs {
+		if len(statuses) == 0 || containsStatus(statuses, b.Status) {
+			blogs = append(blogs, b)
+		}
+	}
+	return blogs, nil
+}
+
+func containsStatus(statuses []entity.BlogStatus, status entity.BlogStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func publishedBlog(id, title, content string) *entity.Blog {
+	return &entity.Blog{ID: id, Title: title, Content: content, Status: entity.BlogStatusPublished}
+}
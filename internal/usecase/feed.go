@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// feedWindow is how far back the feed looks for posts
+	feedWindow = 30 * 24 * time.Hour
+	// feedGravity controls how quickly older posts sink; lower than trending so that a
+	// quiet follow's new post is not buried by yesterday's popular one
+	feedGravity = 1.2
+)
+
+// feedCursorPayload is the JSON inside an opaque feed cursor. It keeps the time the first
+// page was loaded, which fixes the feed window and the age scores are computed at, and the
+// last post of the page in publication order, where the next page starts.
+type feedCursorPayload struct {
+	AsOf        int64  `json:"t"` // unix nanoseconds
+	PublishedAt int64  `json:"p"` // unix nanoseconds
+	ID          string `json:"id"`
+}
+
+// GetFeed returns the user's personalized feed: recent published blogs by the authors and
+// with the tags they follow. Pages follow publication order, so paging never skips or
+// repeats a post, and each page is ranked by recency boosted by engagement. Pass the
+// previous response's next_cursor to continue.
+func (u *FollowUsecase) GetFeed(ctx context.Context, userID, cursor string, limit int) (*dto.FeedResponse, error) {
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	asOf := time.Now()
+	var after *contract.FeedPosition
+	if cursor != "" {
+		payload, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		asOf = time.Unix(0, payload.AsOf)
+		after = &contract.FeedPosition{PublishedAt: time.Unix(0, payload.PublishedAt), ID: payload.ID}
+	}
+
+	authorIDs, err := u.followRepo.FollowedTargets(ctx, userID, entity.FollowTargetAuthor)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := u.followRepo.FollowedTargets(ctx, userID, entity.FollowTargetTag)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.FeedResponse{Items: []*dto.FeedItemResponse{}}
+	if len(authorIDs) == 0 && len(tags) == 0 {
+		return response, nil
+	}
+
+	// Posts reach the feed through a followed tag too, but readers don't need their own
+	blogs, err := u.blogRepo.GetFeedBlogs(ctx, contract.FeedBlogsQuery{
+		AuthorIDs:       authorIDs,
+		Tags:            tags,
+		ExcludeAuthorID: userID,
+		From:            asOf.Add(-feedWindow),
+		To:              asOf,
+		After:           after,
+		Limit:           limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	if len(blogs) > limit {
+		blogs = blogs[:limit]
+		last := blogs[limit-1]
+		response.NextCursor = encodeFeedCursor(feedCursorPayload{
+			AsOf:        asOf.UnixNano(),
+			PublishedAt: feedPublishedAt(last).UnixNano(),
+			ID:          last.ID,
+		})
+	}
+
+	followedAuthors := make(map[string]bool, len(authorIDs))
+	for _, id := range authorIDs {
+		followedAuthors[id] = true
+	}
+	followedTags := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		followedTags[tag] = true
+	}
+
+	items := make([]*dto.FeedItemResponse, 0, len(blogs))
+	for _, blog := range blogs {
+		item := &dto.FeedItemResponse{
+			Blog:           toBlogSummary(blog),
+			FollowedAuthor: followedAuthors[blog.AuthorID],
+			Score:          feedScore(blog, asOf),
+		}
+		for _, tag := range blog.Tags {
+			if followedTags[tag] {
+				item.FollowedTags = append(item.FollowedTags, tag)
+			}
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].Blog.ID < items[j].Blog.ID
+	})
+	response.Items = items
+	return response, nil
+}
+
+// feedPublishedAt is the time a feed post was published. Feed posts are always published,
+// so the creation time only stands in for records that predate published_at.
+func feedPublishedAt(blog *entity.Blog) time.Time {
+	if blog.PublishedAt != nil {
+		return *blog.PublishedAt
+	}
+	return blog.CreatedAt
+}
+
+// feedScore decays a post by its age at asOf, with engagement giving a logarithmic boost so
+// that recency stays the main signal.
+func feedScore(blog *entity.Blog, asOf time.Time) float64 {
+	age := asOf.Sub(feedPublishedAt(blog))
+	if age < 0 {
+		age = 0
+	}
+
+	// Likes and dislikes are already counted by CalculatePopularity
+	var reactions int64
+	for reaction, n := range blog.ReactionCounts {
+		if reaction != string(entity.LIKE_TYPE_LIKE) && reaction != string(entity.LIKE_TYPE_DISLIKE) {
+			reactions += n
+		}
+	}
+	points := utils.CalculatePopularity(blog.ViewCount, blog.LikeCount, blog.DislikeCount, blog.CommentCount) + float64(reactions)
+	if points < 0 {
+		points = 0
+	}
+	return (1 + math.Log1p(points)) / math.Pow(age.Hours()+2, feedGravity)
+}
+
+func encodeFeedCursor(payload feedCursorPayload) string {
+	raw, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeFeedCursor(cursor string) (*feedCursorPayload, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var payload feedCursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID == "" || payload.AsOf == 0 || payload.PublishedAt == 0 {
+		return nil, errInvalidCursor
+	}
+	return &payload, nil
+}
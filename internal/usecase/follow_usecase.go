@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+)
+
+const (
+	maxFollowsPerUser  = 1000
+	maxFollowTagLength = 50
+)
+
+// FollowUsecase lets readers follow authors and tags and builds their personalized feed
+// from what they follow.
+type FollowUsecase struct {
+	followRepo contract.IFollowRepository
+	blogRepo   contract.IBlogRepository
+	userRepo   contract.IUserRepository
+}
+
+// NewFollowUsecase creates and returns a new FollowUsecase instance.
+func NewFollowUsecase(followRepo contract.IFollowRepository, blogRepo contract.IBlogRepository, userRepo contract.IUserRepository) *FollowUsecase {
+	return &FollowUsecase{followRepo: followRepo, blogRepo: blogRepo, userRepo: userRepo}
+}
+
+// FollowAuthor makes the user follow an author. It reports whether the follow is new;
+// following someone twice is not an error.
+func (u *FollowUsecase) FollowAuthor(ctx context.Context, userID, authorID string) (bool, error) {
+	if authorID == userID {
+		return false, errors.New("invalid follow: you cannot follow yourself")
+	}
+	if _, err := u.userRepo.GetUserByID(ctx, authorID); err != nil {
+		return false, errors.New("user not found")
+	}
+	return u.follow(ctx, userID, entity.FollowTargetAuthor, authorID)
+}
+
+// UnfollowAuthor stops the user following an author.
+func (u *FollowUsecase) UnfollowAuthor(ctx context.Context, userID, authorID string) error {
+	return u.unfollow(ctx, userID, entity.FollowTargetAuthor, authorID)
+}
+
+// FollowTag makes the user follow a tag, matched exactly against blog tags.
+func (u *FollowUsecase) FollowTag(ctx context.Context, userID, tag string) (bool, error) {
+	tag, err := normalizeFollowTag(tag)
+	if err != nil {
+		return false, err
+	}
+	return u.follow(ctx, userID, entity.FollowTargetTag, tag)
+}
+
+// UnfollowTag stops the user following a tag.
+func (u *FollowUsecase) UnfollowTag(ctx context.Context, userID, tag string) error {
+	tag, err := normalizeFollowTag(tag)
+	if err != nil {
+		return err
+	}
+	return u.unfollow(ctx, userID, entity.FollowTargetTag, tag)
+}
+
+// ListFollowing returns the authors or tags the user follows, newest first.
+func (u *FollowUsecase) ListFollowing(ctx context.Context, userID, targetType string, page, pageSize int) (*dto.FollowsResponse, error) {
+	t := entity.FollowTargetType(targetType)
+	if targetType == "" {
+		t = entity.FollowTargetAuthor
+	}
+	if t != entity.FollowTargetAuthor && t != entity.FollowTargetTag {
+		return nil, fmt.Errorf("invalid type %q: use author or tag", targetType)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	follows, total, err := u.followRepo.ListFollowing(ctx, userID, t, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	return toFollowsResponse(follows, page, pageSize, total), nil
+}
+
+// ListFollowers returns the users following an author, newest first.
+func (u *FollowUsecase) ListFollowers(ctx context.Context, authorID string, page, pageSize int) (*dto.FollowsResponse, error) {
+	if _, err := u.userRepo.GetUserByID(ctx, authorID); err != nil {
+		return nil, errors.New("user not found")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	follows, total, err := u.followRepo.ListFollowers(ctx, entity.FollowTargetAuthor, authorID, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list followers: %w", err)
+	}
+	return toFollowsResponse(follows, page, pageSize, total), nil
+}
+
+// GetFollowStats returns a user's follower and following counts for their profile, and
+// whether viewerID follows them when viewerID is another signed-in user.
+func (u *FollowUsecase) GetFollowStats(ctx context.Context, userID, viewerID string) (*dto.FollowStatsResponse, error) {
+	followers, err := u.followRepo.CountFollowers(ctx, entity.FollowTargetAuthor, userID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := u.followRepo.CountFollowing(ctx, userID, entity.FollowTargetAuthor)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := u.followRepo.CountFollowing(ctx, userID, entity.FollowTargetTag)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &dto.FollowStatsResponse{FollowerCount: followers, FollowingCount: following, FollowedTagCount: tags}
+	if viewerID != "" && viewerID != userID {
+		isFollowing, err := u.followRepo.IsFollowing(ctx, viewerID, entity.FollowTargetAuthor, userID)
+		if err != nil {
+			return nil, err
+		}
+		stats.IsFollowing = &isFollowing
+	}
+	return stats, nil
+}
+
+func (u *FollowUsecase) follow(ctx context.Context, userID string, targetType entity.FollowTargetType, targetID string) (bool, error) {
+	following, err := u.followRepo.IsFollowing(ctx, userID, targetType, targetID)
+	if err != nil {
+		return false, err
+	}
+	if following {
+		return false, nil
+	}
+
+	authors, err := u.followRepo.CountFollowing(ctx, userID, entity.FollowTargetAuthor)
+	if err != nil {
+		return false, err
+	}
+	tags, err := u.followRepo.CountFollowing(ctx, userID, entity.FollowTargetTag)
+	if err != nil {
+		return false, err
+	}
+	if authors+tags >= maxFollowsPerUser {
+		return false, fmt.Errorf("invalid follow: you can follow at most %d authors and tags", maxFollowsPerUser)
+	}
+
+	return u.followRepo.Follow(ctx, &entity.Follow{FollowerID: userID, TargetType: targetType, TargetID: targetID})
+}
+
+func (u *FollowUsecase) unfollow(ctx context.Context, userID string, targetType entity.FollowTargetType, targetID string) error {
+	removed, err := u.followRepo.Unfollow(ctx, userID, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("follow not found")
+	}
+	return nil
+}
+
+func normalizeFollowTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || len(tag) > maxFollowTagLength {
+		return "", fmt.Errorf("invalid tag: must be 1 to %d characters", maxFollowTagLength)
+	}
+	return tag, nil
+}
+
+func toFollowsResponse(follows []*entity.Follow, page, pageSize int, total int64) *dto.FollowsResponse {
+	responses := make([]*dto.FollowResponse, len(follows))
+	for i, f := range follows {
+		responses[i] = &dto.FollowResponse{
+			FollowerID: f.FollowerID,
+			TargetType: string(f.TargetType),
+			TargetID:   f.TargetID,
+			CreatedAt:  f.CreatedAt,
+		}
+	}
+	return &dto.FollowsResponse{Follows: responses, Pagination: buildPaginationMeta(page, pageSize, total)}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
 	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
 )
 
@@ -16,25 +18,227 @@ func (u *LikeUsecase) ExistsBlog(ctx context.Context, blogID string) bool {
 	return err == nil && blog != nil
 }
 
+// ExistsComment checks if a comment exists by its ID
+func (u *LikeUsecase) ExistsComment(ctx context.Context, commentID string) bool {
+	if u.commentRepo == nil {
+		return false
+	}
+	comment, err := u.commentRepo.GetByID(ctx, commentID)
+	return err == nil && comment != nil
+}
+
 // ErrReactionNotFound is returned when a reaction is not found in the database.
 var ErrReactionNotFound = errors.New("reaction not found")
 
 // LikeUsecase handles the business logic for managing likes and dislikes.
 type LikeUsecase struct {
-	likeRepo contract.ILikeRepository
-	blogRepo contract.IBlogRepository // Add blogRepo for updating popularity
+	likeRepo    contract.ILikeRepository
+	blogRepo    contract.IBlogRepository    // Add blogRepo for updating popularity
+	commentRepo contract.ICommentRepository // keeps comment like/dislike counters in sync
+	// allowedReactions is the configured reaction set per target type; unset types use the default set
+	allowedReactions map[entity.TargetType][]entity.LikeType
+	// uow makes a reaction write and its counter update commit together
+	uow contract.IUnitOfWork
 }
 
 // NewLikeUsecase creates and returns a new LikeUsecase instance.
-func NewLikeUsecase(likeRepo contract.ILikeRepository, blogRepo contract.IBlogRepository) *LikeUsecase {
+func NewLikeUsecase(likeRepo contract.ILikeRepository, blogRepo contract.IBlogRepository, commentRepo contract.ICommentRepository) *LikeUsecase {
 	return &LikeUsecase{
-		likeRepo: likeRepo,
-		blogRepo: blogRepo,
+		likeRepo:    likeRepo,
+		blogRepo:    blogRepo,
+		commentRepo: commentRepo,
+		uow:         directUnitOfWork{},
+	}
+}
+
+// SetUnitOfWork makes reaction writes and counter updates run as one unit of work.
+func (u *LikeUsecase) SetUnitOfWork(uow contract.IUnitOfWork) {
+	if uow == nil {
+		uow = directUnitOfWork{}
+	}
+	u.uow = uow
+}
+
+// SetAllowedReactions configures the reaction set offered on a target type. An empty list
+// falls back to entity.DefaultReactionTypes.
+func (u *LikeUsecase) SetAllowedReactions(targetType entity.TargetType, reactionTypes []string) {
+	if u.allowedReactions == nil {
+		u.allowedReactions = make(map[entity.TargetType][]entity.LikeType)
+	}
+	types := make([]entity.LikeType, 0, len(reactionTypes))
+	for _, t := range reactionTypes {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, entity.LikeType(t))
+		}
+	}
+	u.allowedReactions[targetType] = types
+}
+
+// AllowedReactions returns the reaction types users may leave on a target type.
+func (u *LikeUsecase) AllowedReactions(targetType entity.TargetType) []entity.LikeType {
+	if types := u.allowedReactions[targetType]; len(types) > 0 {
+		return types
+	}
+	return entity.DefaultReactionTypes
+}
+
+// React sets the user's reaction on a target. Users have one reaction per target: reacting
+// with a different type switches to it, reacting again with the same type removes it.
+// The returned reaction is nil when the reaction was removed.
+func (u *LikeUsecase) React(ctx context.Context, userID, targetID string, targetType entity.TargetType, reactionType entity.LikeType) (*entity.Like, error) {
+	if !u.isAllowedReaction(targetType, reactionType) {
+		return nil, fmt.Errorf("invalid reaction type %q for %s", reactionType, targetType)
+	}
+
+	var reaction *entity.Like
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		reaction = nil
+		existing, err := u.GetUserReaction(ctx, userID, targetID)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil && existing.Type == reactionType {
+			if err := u.likeRepo.DeleteReaction(ctx, existing.ID); err != nil {
+				return fmt.Errorf("failed to remove reaction: %w", err)
+			}
+		} else {
+			reaction = existing
+			if reaction == nil {
+				reaction = &entity.Like{UserID: userID, TargetID: targetID, TargetType: targetType}
+			}
+			reaction.Type = reactionType
+			if err := u.likeRepo.CreateReaction(ctx, reaction); err != nil {
+				return fmt.Errorf("failed to save reaction: %w", err)
+			}
+		}
+
+		return u.syncReactionCounts(ctx, targetID, targetType)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return reaction, nil
+}
+
+// GetReactionSummary returns the per-type breakdown of reactions on a target together with
+// the reaction set on offer and, when userID is set, that user's own reaction.
+func (u *LikeUsecase) GetReactionSummary(ctx context.Context, targetID string, targetType entity.TargetType, userID string) (*dto.ReactionSummaryResponse, error) {
+	byType, err := u.likeRepo.CountReactionsByTargetID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions for target %s: %w", targetID, err)
+	}
+
+	summary := &dto.ReactionSummaryResponse{
+		TargetID:   targetID,
+		TargetType: string(targetType),
+		Counts:     make(map[string]int64, len(byType)),
+	}
+	for reactionType, n := range byType {
+		summary.Counts[string(reactionType)] = n
+		summary.Total += n
+	}
+	for _, t := range u.AllowedReactions(targetType) {
+		summary.Available = append(summary.Available, string(t))
+	}
+
+	if userID != "" {
+		reaction, err := u.GetUserReaction(ctx, userID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if reaction != nil {
+			summary.UserReaction = string(reaction.Type)
+		}
+	}
+	return summary, nil
+}
+
+// ListReactors pages through the users who reacted to a target, optionally narrowed to one
+// reaction type. Users who hide their reactions are left out.
+func (u *LikeUsecase) ListReactors(ctx context.Context, targetID string, targetType entity.TargetType, reactionType entity.LikeType, page, pageSize int) (*dto.ReactorsResponse, error) {
+	if reactionType != "" && !u.isAllowedReaction(targetType, reactionType) {
+		return nil, fmt.Errorf("invalid reaction type %q for %s", reactionType, targetType)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	reactors, total, err := u.likeRepo.ListReactors(ctx, targetID, reactionType, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactors for target %s: %w", targetID, err)
+	}
+
+	resp := &dto.ReactorsResponse{
+		TargetID:   targetID,
+		TargetType: string(targetType),
+		Reactors:   make([]*dto.ReactorResponse, 0, len(reactors)),
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}
+	for _, r := range reactors {
+		resp.Reactors = append(resp.Reactors, &dto.ReactorResponse{
+			UserID:    r.UserID,
+			Username:  r.Username,
+			FirstName: r.FirstName,
+			LastName:  r.LastName,
+			AvatarURL: r.AvatarURL,
+			Type:      string(r.Type),
+			ReactedAt: r.ReactedAt,
+		})
+	}
+	return resp, nil
+}
+
+// ListUserReactions pages through a user's own reactions, most recent first. Hidden
+// reactions are still listed here since only the user themselves can see this history.
+func (u *LikeUsecase) ListUserReactions(ctx context.Context, userID string, page, pageSize int) (*dto.UserReactionsResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	likes, total, err := u.likeRepo.ListReactionsByUser(ctx, userID, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions for user %s: %w", userID, err)
+	}
+
+	resp := &dto.UserReactionsResponse{
+		Reactions:  make([]*dto.UserReactionResponse, 0, len(likes)),
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}
+	for _, like := range likes {
+		resp.Reactions = append(resp.Reactions, &dto.UserReactionResponse{
+			TargetID:   like.TargetID,
+			TargetType: string(like.TargetType),
+			Type:       string(like.Type),
+			ReactedAt:  like.UpdatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func (u *LikeUsecase) isAllowedReaction(targetType entity.TargetType, reactionType entity.LikeType) bool {
+	for _, t := range u.AllowedReactions(targetType) {
+		if t == reactionType {
+			return true
+		}
+	}
+	return false
 }
 
 // ToggleLike handles the logic for liking and unliking a target.
 func (u *LikeUsecase) ToggleLike(ctx context.Context, userID, targetID string, targetType entity.TargetType) error {
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		return u.toggleLike(ctx, userID, targetID, targetType)
+	})
+}
+
+func (u *LikeUsecase) toggleLike(ctx context.Context, userID, targetID string, targetType entity.TargetType) error {
 	existingReaction, err := u.likeRepo.GetReactionByUserIDAndTargetID(ctx, userID, targetID)
 	if err != nil {
 		if errors.Is(err, ErrReactionNotFound) || err.Error() == "reaction not found" {
@@ -64,33 +268,21 @@ func (u *LikeUsecase) ToggleLike(ctx context.Context, userID, targetID string, t
 		}
 		resultErr = u.likeRepo.CreateReaction(ctx, newLike)
 	}
-
-	// Update blog like_count and popularity if this is a blog like/dislike
-	if targetType == entity.TargetTypeBlog && u.blogRepo != nil {
-		likes, err1 := u.likeRepo.CountLikesByTargetID(ctx, targetID)
-		dislikes, err2 := u.likeRepo.CountDislikesByTargetID(ctx, targetID)
-		if err1 == nil && err2 == nil {
-			blog, err := u.blogRepo.GetBlogByID(ctx, targetID)
-			views := 0
-			comments := 0
-			if err == nil && blog != nil {
-				views = blog.ViewCount
-				comments = blog.CommentCount
-			}
-			popularity := utils.CalculatePopularity(views, int(likes), int(dislikes), comments)
-			updates := map[string]interface{}{
-				"like_count":    likes,
-				"dislike_count": dislikes,
-				"popularity":    popularity,
-			}
-			_ = u.blogRepo.UpdateBlog(ctx, targetID, updates)
-		}
+	if resultErr != nil {
+		return resultErr
 	}
-	return resultErr
+
+	return u.syncReactionCounts(ctx, targetID, targetType)
 }
 
 // ToggleDislike handles the logic for disliking and undisliking a target.
 func (u *LikeUsecase) ToggleDislike(ctx context.Context, userID, targetID string, targetType entity.TargetType) error {
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		return u.toggleDislike(ctx, userID, targetID, targetType)
+	})
+}
+
+func (u *LikeUsecase) toggleDislike(ctx context.Context, userID, targetID string, targetType entity.TargetType) error {
 	existingReaction, err := u.likeRepo.GetReactionByUserIDAndTargetID(ctx, userID, targetID)
 	if err != nil {
 		if errors.Is(err, ErrReactionNotFound) || (err.Error() == "reaction not found") {
@@ -127,35 +319,14 @@ func (u *LikeUsecase) ToggleDislike(ctx context.Context, userID, targetID string
 		}
 	}
 
-	// Update blog dislike_count and popularity if this is a blog like/dislike
-	if targetType == entity.TargetTypeBlog && u.blogRepo != nil {
-		likes, err1 := u.likeRepo.CountLikesByTargetID(ctx, targetID)
-		dislikes, err2 := u.likeRepo.CountDislikesByTargetID(ctx, targetID)
-		if err1 == nil && err2 == nil {
-			blog, err := u.blogRepo.GetBlogByID(ctx, targetID)
-			views := 0
-			comments := 0
-			if err == nil && blog != nil {
-				views = blog.ViewCount
-				comments = blog.CommentCount
-			}
-			popularity := utils.CalculatePopularity(views, int(likes), int(dislikes), comments)
-			updates := map[string]interface{}{
-				"like_count":    likes,
-				"dislike_count": dislikes,
-				"popularity":    popularity,
-			}
-			_ = u.blogRepo.UpdateBlog(ctx, targetID, updates)
-		}
-	}
-	return nil
+	return u.syncReactionCounts(ctx, targetID, targetType)
 }
 
 // GetUserReaction retrieves the active reaction (if any) a user has on a specific target.
 func (u *LikeUsecase) GetUserReaction(ctx context.Context, userID, targetID string) (*entity.Like, error) {
 	like, err := u.likeRepo.GetReactionByUserIDAndTargetID(ctx, userID, targetID)
 	if err != nil {
-		if errors.Is(err, ErrReactionNotFound) {
+		if errors.Is(err, ErrReactionNotFound) || err.Error() == "reaction not found" {
 			// The use case should handle this specific error and return nil, nil
 			return nil, nil
 		}
@@ -164,6 +335,19 @@ func (u *LikeUsecase) GetUserReaction(ctx context.Context, userID, targetID stri
 	return like, nil
 }
 
+// GetUserReactions returns the user's active reactions on any of the targets, by target ID.
+func (u *LikeUsecase) GetUserReactions(ctx context.Context, userID string, targetIDs []string) (map[string]*entity.Like, error) {
+	likes, err := u.likeRepo.GetReactionsByUserIDAndTargetIDs(ctx, userID, targetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user's reactions: %w", err)
+	}
+	reactions := make(map[string]*entity.Like, len(likes))
+	for _, like := range likes {
+		reactions[like.TargetID] = like
+	}
+	return reactions, nil
+}
+
 // GetReactionCounts retrieves the total number of likes and dislikes for a specific target.
 func (u *LikeUsecase) GetReactionCounts(ctx context.Context, targetID string) (likes, dislikes int64, err error) {
 	likes, err = u.likeRepo.CountLikesByTargetID(ctx, targetID)
@@ -178,3 +362,48 @@ func (u *LikeUsecase) GetReactionCounts(ctx context.Context, targetID string) (l
 
 	return likes, dislikes, nil
 }
+
+// syncReactionCounts recounts a target's active reactions and stores them on the target,
+// so the denormalized counters always agree with the reaction records. It runs in the same
+// unit of work as the reaction write, so a failure here rolls that write back too.
+func (u *LikeUsecase) syncReactionCounts(ctx context.Context, targetID string, targetType entity.TargetType) error {
+	byType, err := u.likeRepo.CountReactionsByTargetID(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to count reactions for target %s: %w", targetID, err)
+	}
+	counts := make(map[string]int64, len(byType))
+	for reactionType, n := range byType {
+		counts[string(reactionType)] = n
+	}
+	likes, dislikes := counts[string(entity.LIKE_TYPE_LIKE)], counts[string(entity.LIKE_TYPE_DISLIKE)]
+
+	switch targetType {
+	case entity.TargetTypeBlog:
+		// Update blog like_count, dislike_count and popularity
+		if u.blogRepo == nil {
+			return nil
+		}
+		blog, err := u.blogRepo.GetBlogByID(ctx, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to load blog %s: %w", targetID, err)
+		}
+		popularity := utils.CalculatePopularity(blog.ViewCount, int(likes), int(dislikes), blog.CommentCount)
+		updates := map[string]interface{}{
+			"like_count":      likes,
+			"dislike_count":   dislikes,
+			"reaction_counts": counts,
+			"popularity":      popularity,
+		}
+		if err := u.blogRepo.UpdateBlog(ctx, targetID, updates); err != nil {
+			return fmt.Errorf("failed to update blog counters: %w", err)
+		}
+	case entity.TargetTypeComment:
+		if u.commentRepo == nil {
+			return nil
+		}
+		if err := u.commentRepo.SetReactionCounts(ctx, targetID, counts); err != nil {
+			return fmt.Errorf("failed to update comment counters: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	defaultUsernameSuggestions = 10
+	maxUsernameSuggestions     = 20
+)
+
+// NotificationUsecase resolves @mentions, notifies the users they point at and serves
+// the in-app notification inbox.
+type NotificationUsecase struct {
+	notificationRepo contract.INotificationRepository
+	userRepo         contract.IUserRepository
+}
+
+// NewNotificationUsecase creates and returns a new NotificationUsecase instance.
+func NewNotificationUsecase(notificationRepo contract.INotificationRepository, userRepo contract.IUserRepository) *NotificationUsecase {
+	return &NotificationUsecase{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// ResolveMentions parses @username mentions in content and keeps those that match an
+// existing user. Unknown usernames are ignored and stay plain text.
+func (u *NotificationUsecase) ResolveMentions(ctx context.Context, content string) []entity.Mention {
+	var mentions []entity.Mention
+	for _, username := range utils.ExtractMentions(content) {
+		user, err := u.userRepo.GetUserByUsername(ctx, username)
+		if err != nil || user == nil {
+			continue
+		}
+		mentions = append(mentions, entity.Mention{UserID: user.ID, Username: user.Username})
+	}
+	return mentions
+}
+
+// NotifyMentions notifies every mentioned user that was not already notified for the same
+// content. Mentioning yourself does not create a notification.
+func (u *NotificationUsecase) NotifyMentions(ctx context.Context, notificationType entity.NotificationType, relatedID, senderID string, mentions, alreadyNotified []entity.Mention) error {
+	skip := map[string]struct{}{senderID: {}}
+	for _, m := range alreadyNotified {
+		skip[m.UserID] = struct{}{}
+	}
+
+	senderName := "Someone"
+	if sender, err := u.userRepo.GetUserByID(ctx, senderID); err == nil && sender != nil {
+		senderName = sender.Username
+	}
+	where := "a comment"
+	if notificationType == entity.NotificationTypeBlogMention {
+		where = "a blog post"
+	}
+
+	var notifications []*entity.Notification
+	for _, m := range mentions {
+		if _, ok := skip[m.UserID]; ok {
+			continue
+		}
+		skip[m.UserID] = struct{}{}
+		sender, related := senderID, relatedID
+		notifications = append(notifications, &entity.Notification{
+			RecipientUserID: m.UserID,
+			SenderUserID:    &sender,
+			Type:            notificationType,
+			Message:         fmt.Sprintf("%s mentioned you in %s", senderName, where),
+			RelatedEntityID: &related,
+		})
+	}
+	return u.notificationRepo.Create(ctx, notifications...)
+}
+
+// SuggestUsernames returns users whose username starts with prefix, for mention autocomplete.
+func (u *NotificationUsecase) SuggestUsernames(ctx context.Context, prefix string, limit int) ([]*dto.UserSuggestionResponse, error) {
+	prefix = strings.TrimPrefix(strings.TrimSpace(prefix), "@")
+	if prefix == "" {
+		return nil, errors.New("invalid query: username prefix is required")
+	}
+	if limit < 1 {
+		limit = defaultUsernameSuggestions
+	}
+	if limit > maxUsernameSuggestions {
+		limit = maxUsernameSuggestions
+	}
+
+	users, err := u.userRepo.SearchUsersByUsernamePrefix(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]*dto.UserSuggestionResponse, len(users))
+	for i, user := range users {
+		suggestions[i] = &dto.UserSuggestionResponse{
+			UserID:    user.ID,
+			Username:  user.Username,
+			AvatarURL: user.AvatarURL,
+		}
+	}
+	return suggestions, nil
+}
+
+// ListNotifications returns a page of the user's notifications and their unread count.
+func (u *NotificationUsecase) ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) (*dto.NotificationsResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	notifications, total, err := u.notificationRepo.ListByRecipient(ctx, userID, unreadOnly, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, err
+	}
+	unread, err := u.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		responses[i] = &dto.NotificationResponse{
+			ID:              n.ID,
+			Type:            string(n.Type),
+			SenderUserID:    n.SenderUserID,
+			Message:         n.Message,
+			RelatedEntityID: n.RelatedEntityID,
+			IsRead:          n.IsRead,
+			CreatedAt:       n.CreatedAt,
+		}
+	}
+
+	return &dto.NotificationsResponse{
+		Notifications: responses,
+		UnreadCount:   unread,
+		Pagination:    buildPaginationMeta(page, pageSize, total),
+	}, nil
+}
+
+// MarkNotificationsRead marks the given notifications as read, or all of them when ids is empty.
+func (u *NotificationUsecase) MarkNotificationsRead(ctx context.Context, userID string, ids []string) (int64, error) {
+	return u.notificationRepo.MarkRead(ctx, userID, ids)
+}
@@ -0,0 +1,366 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	// maxRelatedCorpus bounds how many of the newest published posts are matched per run
+	maxRelatedCorpus = 5000
+	// maxRelatedPostings bounds how many posts one tag or term links a post to
+	maxRelatedPostings = 500
+	// maxRelatedStored is how many related posts are kept per blog
+	maxRelatedStored = 10
+	// relatedPostsShown is how many related posts blog detail returns
+	relatedPostsShown = 5
+	// minRelatedScore drops weak matches rather than padding the list
+	minRelatedScore = 0.05
+	// relatedTermsPerPost caps each post's TF-IDF vector to its most distinctive terms
+	relatedTermsPerPost = 50
+	// minCoReaders ignores pairs read together by too few readers to mean anything
+	minCoReaders = 2
+	// coReadSaturation is the co-reader count at which co-readership reaches full weight
+	coReadSaturation = 25
+	// maxCoReadPostsPerReader skips readers who viewed more posts than anyone reads, like crawlers
+	maxCoReadPostsPerReader = 50
+	// rawViewRetention matches the blog_views TTL; older views are gone
+	rawViewRetention = 24 * time.Hour
+
+	relatedTagWeight    = 0.35
+	relatedCoReadWeight = 0.35
+	relatedTextWeight   = 0.30
+)
+
+// RelatedPostsUsecase precomputes, for every published blog, the posts most related to it by
+// shared tags, co-readership and text similarity, and serves them on blog detail.
+type RelatedPostsUsecase struct {
+	relatedRepo contract.IRelatedPostsRepository
+	blogRepo    contract.IBlogRepository
+	cache       contract.IBlogCache
+	logger      usecasecontract.IAppLogger
+	running     sync.Mutex
+}
+
+// NewRelatedPostsUsecase creates and returns a new RelatedPostsUsecase instance.
+func NewRelatedPostsUsecase(relatedRepo contract.IRelatedPostsRepository, blogRepo contract.IBlogRepository, logger usecasecontract.IAppLogger) *RelatedPostsUsecase {
+	return &RelatedPostsUsecase{relatedRepo: relatedRepo, blogRepo: blogRepo, logger: logger}
+}
+
+// SetBlogCache makes related posts be served from, and refreshed into, the cache.
+func (u *RelatedPostsUsecase) SetBlogCache(cache contract.IBlogCache) {
+	u.cache = cache
+}
+
+// Start recomputes related posts now and then every interval until ctx is cancelled. A
+// non-positive interval disables the schedule.
+func (u *RelatedPostsUsecase) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	runPeriodically(ctx, interval, u.logger, "related posts recomputation", u.Recompute)
+}
+
+// Recompute folds the views since the last run into co-readership and then rebuilds the
+// related posts of every post in the corpus.
+func (u *RelatedPostsUsecase) Recompute(ctx context.Context) error {
+	if !u.running.TryLock() {
+		return nil
+	}
+	defer u.running.Unlock()
+
+	if err := u.countCoReads(ctx); err != nil {
+		return err
+	}
+
+	blogs, err := u.relatedRepo.ListCorpus(ctx, maxRelatedCorpus)
+	if err != nil {
+		return err
+	}
+	pairs, err := u.relatedRepo.ListCoReads(ctx, minCoReaders)
+	if err != nil {
+		return err
+	}
+
+	computedAt := time.Now()
+	results := computeRelatedPosts(blogs, pairs)
+	related := make([]*entity.RelatedPosts, 0, len(blogs))
+	for _, blog := range blogs {
+		related = append(related, &entity.RelatedPosts{BlogID: blog.ID, Related: results[blog.ID], ComputedAt: computedAt})
+	}
+	if err := u.relatedRepo.SaveRelatedPosts(ctx, related); err != nil {
+		return err
+	}
+
+	if u.cache != nil {
+		for _, rp := range related {
+			if err := u.cache.SetRelatedPosts(ctx, rp.BlogID, rp.Related); err != nil {
+				u.logger.Warningf("failed to cache related posts for blog %s: %v", rp.BlogID, err)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// GetRelatedPosts returns the posts related to a blog that are still published, best first.
+func (u *RelatedPostsUsecase) GetRelatedPosts(ctx context.Context, blogID string) ([]*dto.RelatedPostResponse, error) {
+	related, err := u.storedRelatedPosts(ctx, blogID)
+	if err != nil || len(related) == 0 {
+		return []*dto.RelatedPostResponse{}, err
+	}
+
+	ids := make([]string, len(related))
+	for i, rp := range related {
+		ids[i] = rp.BlogID
+	}
+	blogs, err := u.blogRepo.GetBlogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Blog, len(blogs))
+	for _, b := range blogs {
+		byID[b.ID] = b
+	}
+
+	responses := make([]*dto.RelatedPostResponse, 0, relatedPostsShown)
+	for _, rp := range related {
+		blog, ok := byID[rp.BlogID]
+		if !ok || blog.Status != entity.BlogStatusPublished {
+			continue
+		}
+		responses = append(responses, &dto.RelatedPostResponse{
+			Blog:           toBlogSummary(blog),
+			Score:          rp.Score,
+			SharedTags:     rp.SharedTags,
+			CoReaders:      rp.CoReaders,
+			TextSimilarity: rp.TextSimilarity,
+		})
+		if len(responses) == relatedPostsShown {
+			break
+		}
+	}
+	return responses, nil
+}
+
+func (u *RelatedPostsUsecase) storedRelatedPosts(ctx context.Context, blogID string) ([]entity.RelatedPost, error) {
+	if u.cache != nil {
+		if related, found, err := u.cache.GetRelatedPosts(ctx, blogID); err == nil && found {
+			return related, nil
+		}
+	}
+
+	stored, err := u.relatedRepo.GetRelatedPosts(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	var related []entity.RelatedPost
+	if stored != nil {
+		related = stored.Related
+	}
+	if u.cache != nil && stored != nil {
+		_ = u.cache.SetRelatedPosts(ctx, blogID, related)
+	}
+	return related, nil
+}
+
+// countCoReads adds the reader pairs that completed since the last run to the stored
+// co-readership. A reader is a user, or for anonymous readers a daily IP hash. A pair counts
+// once its later view is new, so pairs spanning two runs are neither missed nor counted twice.
+func (u *RelatedPostsUsecase) countCoReads(ctx context.Context) error {
+	// Leave a margin for views stamped just before now but not yet written
+	until := time.Now().Add(-time.Minute)
+	from := until.Add(-rawViewRetention)
+	watermark, err := u.relatedRepo.GetCoReadWatermark(ctx)
+	if err != nil {
+		return err
+	}
+	if watermark.Before(from) {
+		watermark = from
+	}
+
+	counts := make(map[[2]string]int64)
+	err = u.relatedRepo.EachReaderPosts(ctx, from, until, maxCoReadPostsPerReader, func(posts map[string]time.Time) error {
+		ids := make([]string, 0, len(posts))
+		for id := range posts {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				later := posts[ids[i]]
+				if posts[ids[j]].After(later) {
+					later = posts[ids[j]]
+				}
+				if later.After(watermark) {
+					counts[[2]string{ids[i], ids[j]}]++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	pairs := make([]entity.CoReadPair, 0, len(counts))
+	for pair, count := range counts {
+		pairs = append(pairs, entity.CoReadPair{BlogA: pair[0], BlogB: pair[1], Count: count})
+	}
+	if err := u.relatedRepo.AddCoReads(ctx, pairs); err != nil {
+		return err
+	}
+	return u.relatedRepo.SetCoReadWatermark(ctx, until)
+}
+
+// relatedCandidate accumulates the signals linking one post to another.
+type relatedCandidate struct {
+	sharedTags []string
+	coReaders  int64
+	text       float64
+}
+
+type termPosting struct {
+	doc    int
+	weight float64
+}
+
+// computeRelatedPosts scores, for each post, every other post linked to it by a shared tag,
+// co-readership or a shared distinctive term, and keeps its best matches. Posts are
+// compared one at a time so memory stays linear in the corpus size.
+func computeRelatedPosts(blogs []*entity.Blog, pairs []entity.CoReadPair) map[string][]entity.RelatedPost {
+	index := make(map[string]int, len(blogs))
+	for i, b := range blogs {
+		index[b.ID] = i
+	}
+
+	// Tags are compared case-insensitively; blogs arrive newest first, so each tag's
+	// posting list keeps its most recent posts when it is capped
+	tagSets := make([]map[string]string, len(blogs))
+	byTag := make(map[string][]int)
+	for i, b := range blogs {
+		tagSets[i] = make(map[string]string, len(b.Tags))
+		for _, tag := range b.Tags {
+			key := strings.ToLower(strings.TrimSpace(tag))
+			if key == "" {
+				continue
+			}
+			if _, dup := tagSets[i][key]; !dup {
+				tagSets[i][key] = tag
+				if len(byTag[key]) < maxRelatedPostings {
+					byTag[key] = append(byTag[key], i)
+				}
+			}
+		}
+	}
+
+	coReads := make(map[int]map[int]int64)
+	addCoRead := func(i, j int, count int64) {
+		if coReads[i] == nil {
+			coReads[i] = make(map[int]int64)
+		}
+		coReads[i][j] = count
+	}
+	for _, p := range pairs {
+		i, okA := index[p.BlogA]
+		j, okB := index[p.BlogB]
+		if okA && okB && i != j {
+			addCoRead(i, j, p.Count)
+			addCoRead(j, i, p.Count)
+		}
+	}
+
+	// Text: TF-IDF over the plain text with titles counted twice. Terms used by more posts
+	// than maxRelatedPostings are too common to tell posts apart and are skipped
+	docs := make([][]string, len(blogs))
+	for i, b := range blogs {
+		title := utils.Tokenize(b.Title)
+		docs[i] = append(append(title, title...), utils.Tokenize(utils.PlainText(b.Content))...)
+	}
+	vectors := utils.TFIDFVectors(docs, relatedTermsPerPost)
+	postings := make(map[string][]termPosting)
+	for i, v := range vectors {
+		for term, w := range v {
+			postings[term] = append(postings[term], termPosting{i, w})
+		}
+	}
+
+	results := make(map[string][]entity.RelatedPost, len(blogs))
+	for i, b := range blogs {
+		candidates := make(map[int]*relatedCandidate)
+		candidate := func(j int) *relatedCandidate {
+			c, ok := candidates[j]
+			if !ok {
+				c = &relatedCandidate{}
+				candidates[j] = c
+			}
+			return c
+		}
+		for key, tag := range tagSets[i] {
+			for _, j := range byTag[key] {
+				if j != i {
+					candidate(j).sharedTags = append(candidate(j).sharedTags, tag)
+				}
+			}
+		}
+		for j, count := range coReads[i] {
+			candidate(j).coReaders = count
+		}
+		for term, w := range vectors[i] {
+			list := postings[term]
+			if len(list) > maxRelatedPostings {
+				continue
+			}
+			for _, p := range list {
+				if p.doc != i {
+					candidate(p.doc).text += w * p.weight
+				}
+			}
+		}
+
+		related := make([]entity.RelatedPost, 0, len(candidates))
+		for j, c := range candidates {
+			tagScore := 0.0
+			if union := len(tagSets[i]) + len(tagSets[j]) - len(c.sharedTags); union > 0 {
+				tagScore = float64(len(c.sharedTags)) / float64(union)
+			}
+			coRead := 0.0
+			if c.coReaders > 0 {
+				coRead = math.Min(1, math.Log1p(float64(c.coReaders))/math.Log1p(coReadSaturation))
+			}
+			score := relatedTagWeight*tagScore + relatedCoReadWeight*coRead + relatedTextWeight*c.text
+			if score < minRelatedScore {
+				continue
+			}
+			sort.Strings(c.sharedTags)
+			related = append(related, entity.RelatedPost{
+				BlogID:         blogs[j].ID,
+				Score:          math.Round(score*1e4) / 1e4,
+				SharedTags:     c.sharedTags,
+				CoReaders:      c.coReaders,
+				TextSimilarity: math.Round(c.text*1e4) / 1e4,
+			})
+		}
+		sort.Slice(related, func(x, y int) bool {
+			if related[x].Score != related[y].Score {
+				return related[x].Score > related[y].Score
+			}
+			return related[x].BlogID < related[y].BlogID
+		})
+		if len(related) > maxRelatedStored {
+			related = related[:maxRelatedStored]
+		}
+		results[b.ID] = related
+	}
+	return results
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// minCorrectableLength is the shortest query term that is checked for typos; shorter words
+// have too many close neighbours to guess from.
+const minCorrectableLength = 4
+
+// SearchVocabulary holds every word used in published blogs, with the number of blogs using
+// it, so that misspelled query terms can be corrected to the closest known word.
+type SearchVocabulary struct {
+	blogRepo contract.IBlogRepository
+	logger   usecasecontract.IAppLogger
+	running  sync.Mutex
+
+	mu    sync.RWMutex
+	freq  map[string]int
+	byLen map[int][]string // words by rune length
+}
+
+// NewSearchVocabulary creates an empty SearchVocabulary; it corrects nothing until loaded.
+func NewSearchVocabulary(blogRepo contract.IBlogRepository, logger usecasecontract.IAppLogger) *SearchVocabulary {
+	return &SearchVocabulary{
+		blogRepo: blogRepo,
+		logger:   logger,
+		freq:     make(map[string]int),
+		byLen:    make(map[int][]string),
+	}
+}
+
+// Start loads the vocabulary now and then every interval until ctx is cancelled. A
+// non-positive interval only loads it once.
+func (v *SearchVocabulary) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		go runJob(ctx, v.logger, "search vocabulary refresh", v.Refresh)
+		return
+	}
+	runPeriodically(ctx, interval, v.logger, "search vocabulary refresh", v.Refresh)
+}
+
+// Refresh rebuilds the vocabulary from the published blogs.
+func (v *SearchVocabulary) Refresh(ctx context.Context) error {
+	if !v.running.TryLock() {
+		return nil
+	}
+	defer v.running.Unlock()
+
+	blogs, err := v.blogRepo.ListSearchableBlogs(ctx, entity.BlogStatusPublished)
+	if err != nil {
+		return err
+	}
+	freq := make(map[string]int)
+	for _, blog := range blogs {
+		seen := make(map[string]struct{})
+		for _, token := range utils.Tokenize(blog.Title + " " + utils.PlainText(blog.Content)) {
+			if _, ok := seen[token]; !ok {
+				seen[token] = struct{}{}
+				freq[token]++
+			}
+		}
+	}
+	byLen := make(map[int][]string)
+	for word := range freq {
+		n := len([]rune(word))
+		byLen[n] = append(byLen[n], word)
+	}
+
+	v.mu.Lock()
+	v.freq, v.byLen = freq, byLen
+	v.mu.Unlock()
+	return nil
+}
+
+// Correct returns the known word closest to term when term itself is unknown: one edit away
+// for words of up to seven letters and two edits for longer ones. Ties go to the word used in
+// more blogs. It reports false when term is known, too short, a stop word or a number, or
+// has no close word.
+func (v *SearchVocabulary) Correct(term string) (string, bool) {
+	n := len([]rune(term))
+	if n < minCorrectableLength || len(utils.Tokenize(term)) != 1 {
+		return "", false
+	}
+	limit := 1
+	if n > 7 {
+		limit = 2
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if len(v.freq) == 0 {
+		return "", false
+	}
+	if _, known := v.freq[term]; known {
+		return "", false
+	}
+	best, bestDist, bestFreq := "", limit+1, 0
+	for l := n - limit; l <= n+limit; l++ {
+		for _, word := range v.byLen[l] {
+			d := utils.EditDistance(term, word, limit)
+			if d > limit {
+				continue
+			}
+			f := v.freq[word]
+			if d < bestDist || (d == bestDist && (f > bestFreq || (f == bestFreq && word < best))) {
+				best, bestDist, bestFreq = word, d, f
+			}
+		}
+	}
+	return best, best != ""
+}
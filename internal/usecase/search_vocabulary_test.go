@@ -0,0 +1,59 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchVocabulary_Correct(t *testing.T) {
+	draft := publishedBlog("draft", "Unpublished", "Typescript")
+	draft.Status = entity.BlogStatusDraft
+	repo := &fakeBlogRepo{blogs: []*entity.Blog{
+		publishedBlog("1", "Golang gophers", "<p>Bake the mist.</p>"),
+		publishedBlog("2", "Kubernetes", "Bake a list."),
+		publishedBlog("3", "Cakes", "cake"),
+		draft,
+	}}
+	v := usecase.NewSearchVocabulary(repo, nopLogger{})
+
+	_, ok := v.Correct("golnag")
+	assert.False(t, ok, "an empty vocabulary corrects nothing")
+	require.NoError(t, v.Refresh(context.Background()))
+
+	tests := []struct {
+		name   string
+		term   string
+		want   string
+		wantOK bool
+	}{
+		{name: "known word", term: "golang", wantOK: false},
+		{name: "adjacent swap", term: "golnag", want: "golang", wantOK: true},
+		{name: "missing letter", term: "glang", want: "golang", wantOK: true},
+		{name: "two edits in a short word", term: "gxlxng", wantOK: false},
+		{name: "one edit in a long word", term: "kubernetis", want: "kubernetes", wantOK: true},
+		{name: "two edits in a long word", term: "kubarnetis", want: "kubernetes", wantOK: true},
+		{name: "two edits at eight letters", term: "goolangg", want: "golang", wantOK: true},
+		{name: "three edits in a long word", term: "kbarnetis", wantOK: false},
+		{name: "seven letters allow one edit", term: "gophars", want: "gophers", wantOK: true},
+		{name: "seven letters reject two edits", term: "gaphars", wantOK: false},
+		{name: "tie goes to the more used word", term: "fake", want: "bake", wantOK: true},
+		{name: "equal use goes to the first word", term: "gist", want: "list", wantOK: true},
+		{name: "too short", term: "bak", wantOK: false},
+		{name: "stop word", term: "their", wantOK: false},
+		{name: "number", term: "12345", wantOK: false},
+		{name: "several words", term: "golnag gophers", wantOK: false},
+		{name: "drafts are not in the vocabulary", term: "typescrypt", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := v.Correct(tt.term)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// Search modes accepted by SearchAndFilterBlogs.
+const (
+	SearchModeKeyword  = "keyword"
+	SearchModeSemantic = "semantic"
+	SearchModeHybrid   = "hybrid"
+)
+
+const (
+	// embeddingBatchSize is how many blogs are sent to the provider per request
+	embeddingBatchSize = 32
+	// maxEmbeddedTextLength caps the text embedded per blog, in runes; models truncate anyway
+	maxEmbeddedTextLength = 8000
+	// searchCandidates is how many keyword and semantic matches each are blended per query
+	searchCandidates = 200
+)
+
+// SemanticSearchConfig tunes semantic and hybrid search.
+type SemanticSearchConfig struct {
+	// MinSimilarity is the cosine similarity below which a blog is not a semantic match.
+	MinSimilarity float64
+	// KeywordWeight is the share of the keyword score in hybrid ranking; the rest is semantic.
+	KeywordWeight float64
+}
+
+// SemanticMatch is a blog whose embedding is close to the query's.
+type SemanticMatch struct {
+	BlogID     string
+	Similarity float64
+}
+
+// SemanticSearchUsecase keeps an embedding of every published blog up to date and finds
+// the blogs closest in meaning to a query. Vectors are held in memory and compared by brute
+// force, which is fast enough for tens of thousands of posts.
+type SemanticSearchUsecase struct {
+	blogRepo      contract.IBlogRepository
+	embeddingRepo contract.IEmbeddingRepository
+	provider      usecasecontract.IEmbeddingProvider
+	logger        usecasecontract.IAppLogger
+	config        SemanticSearchConfig
+	running       sync.Mutex
+
+	mu      sync.RWMutex
+	vectors map[string][]float32
+}
+
+// NewSemanticSearchUsecase creates a SemanticSearchUsecase. A KeywordWeight outside [0, 1]
+// falls back to an even blend.
+func NewSemanticSearchUsecase(blogRepo contract.IBlogRepository, embeddingRepo contract.IEmbeddingRepository, provider usecasecontract.IEmbeddingProvider, logger usecasecontract.IAppLogger, config SemanticSearchConfig) *SemanticSearchUsecase {
+	if config.KeywordWeight < 0 || config.KeywordWeight > 1 {
+		config.KeywordWeight = 0.5
+	}
+	return &SemanticSearchUsecase{
+		blogRepo:      blogRepo,
+		embeddingRepo: embeddingRepo,
+		provider:      provider,
+		logger:        logger,
+		config:        config,
+		vectors:       make(map[string][]float32),
+	}
+}
+
+// Start reindexes now and then every interval until ctx is cancelled. A non-positive
+// interval only loads the stored vectors once.
+func (u *SemanticSearchUsecase) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		go runJob(ctx, u.logger, "semantic search reindex", u.Reindex)
+		return
+	}
+	runPeriodically(ctx, interval, u.logger, "semantic search reindex", u.Reindex)
+}
+
+// Reindex embeds published blogs that are new or were edited since they were embedded,
+// drops the vectors of blogs that are no longer published and refreshes the in-memory index.
+func (u *SemanticSearchUsecase) Reindex(ctx context.Context) error {
+	if !u.running.TryLock() {
+		return nil
+	}
+	defer u.running.Unlock()
+
+	blogs, err := u.blogRepo.ListSearchableBlogs(ctx, entity.BlogStatusPublished)
+	if err != nil {
+		return err
+	}
+	model := u.provider.Model()
+	stored, err := u.embeddingRepo.ListEmbeddings(ctx, model)
+	if err != nil {
+		return err
+	}
+	storedByID := make(map[string]*entity.BlogEmbedding, len(stored))
+	for _, e := range stored {
+		storedByID[e.BlogID] = e
+	}
+
+	vectors := make(map[string][]float32, len(blogs))
+	published := make(map[string]bool, len(blogs))
+	var pending []*entity.BlogEmbedding
+	var texts []string
+	for _, blog := range blogs {
+		published[blog.ID] = true
+		text := embeddingText(blog)
+		hash := contentHash(text)
+		if e, ok := storedByID[blog.ID]; ok {
+			// An edited blog keeps its old vector until the new one is stored
+			vectors[blog.ID] = normalizeVector(e.Vector)
+			if e.ContentHash == hash {
+				continue
+			}
+		}
+		pending = append(pending, &entity.BlogEmbedding{BlogID: blog.ID, Model: model, ContentHash: hash})
+		texts = append(texts, text)
+	}
+
+	// Embed in batches, keeping whatever succeeded if the provider fails part way
+	var embedErr error
+	for start := 0; start < len(pending); start += embeddingBatchSize {
+		end := min(start+embeddingBatchSize, len(pending))
+		embedded, err := u.provider.Embed(ctx, texts[start:end])
+		if err != nil {
+			embedErr = fmt.Errorf("failed to embed blogs: %w", err)
+			break
+		}
+		batch := pending[start:end]
+		now := time.Now()
+		for i, e := range batch {
+			e.Vector = embedded[i]
+			e.UpdatedAt = now
+			vectors[e.BlogID] = normalizeVector(e.Vector)
+		}
+		if err := u.embeddingRepo.UpsertEmbeddings(ctx, batch); err != nil {
+			embedErr = err
+			break
+		}
+	}
+
+	var orphaned []string
+	for id := range storedByID {
+		if !published[id] {
+			orphaned = append(orphaned, id)
+		}
+	}
+	if err := u.embeddingRepo.DeleteEmbeddings(ctx, orphaned); err != nil && embedErr == nil {
+		embedErr = err
+	}
+
+	u.mu.Lock()
+	u.vectors = vectors
+	u.mu.Unlock()
+	return embedErr
+}
+
+// Search returns up to limit blogs at least MinSimilarity close to the query, closest first.
+func (u *SemanticSearchUsecase) Search(ctx context.Context, query string, limit int) ([]SemanticMatch, error) {
+	embedded, err := u.provider.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(embedded) == 0 {
+		return nil, nil
+	}
+	q := normalizeVector(embedded[0])
+
+	u.mu.RLock()
+	matches := make([]SemanticMatch, 0, limit)
+	for id, v := range u.vectors {
+		if len(v) != len(q) {
+			continue
+		}
+		var dot float64
+		for i := range q {
+			dot += float64(q[i]) * float64(v[i])
+		}
+		if dot >= u.config.MinSimilarity {
+			matches = append(matches, SemanticMatch{BlogID: id, Similarity: dot})
+		}
+	}
+	u.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Similarity != matches[j].Similarity {
+			return matches[i].Similarity > matches[j].Similarity
+		}
+		return matches[i].BlogID < matches[j].BlogID
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// KeywordWeight is the share of the keyword score in hybrid ranking.
+func (u *SemanticSearchUsecase) KeywordWeight() float64 {
+	return u.config.KeywordWeight
+}
+
+// embeddingText is the text embedded for a blog: its title followed by its plain text.
+func embeddingText(blog *entity.Blog) string {
+	text := []rune(blog.Title + "\n\n" + utils.PlainText(blog.Content))
+	if len(text) > maxEmbeddedTextLength {
+		text = text[:maxEmbeddedTextLength]
+	}
+	return string(text)
+}
+
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:16])
+}
+
+// normalizeVector scales v to unit length so that dot products are cosine similarities.
+func normalizeVector(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package usecase_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbeddingRepo keeps embeddings in a map and records what was written.
+type fakeEmbeddingRepo struct {
+	stored   map[string]*entity.BlogEmbedding
+	upserted []string
+	deleted  []string
+}
+
+func newFakeEmbeddingRepo() *fakeEmbeddingRepo {
+	return &fakeEmbeddingRepo{stored: make(map[string]*entity.BlogEmbedding)}
+}
+
+func (r *fakeEmbeddingRepo) ListEmbeddings(ctx context.Context, model string) ([]*entity.BlogEmbedding, error) {
+	var embeddings []*entity.BlogEmbedding
+	for _, e := range r.stored {
+		if e.Model == model {
+			embeddings = append(embeddings, e)
+		}
+	}
+	return embeddings, nil
+}
+
+func (r *fakeEmbeddingRepo) UpsertEmbeddings(ctx context.Context, embeddings []*entity.BlogEmbedding) error {
+	for _, e := range embeddings {
+		r.stored[e.BlogID] = e
+		r.upserted = append(r.upserted, e.BlogID)
+	}
+	return nil
+}
+
+func (r *fakeEmbeddingRepo) DeleteEmbeddings(ctx context.Context, blogIDs []string) error {
+	for _, id := range blogIDs {
+		delete(r.stored, id)
+	}
+	r.deleted = append(r.deleted, blogIDs...)
+	return nil
+}
+
+// fakeEmbeddingProvider returns the vector registered for the first line of each text, which
+// for a blog is its title, and records every text it embeds.
+type fakeEmbeddingProvider struct {
+	vectors  map[string][]float32
+	embedded []string
+}
+
+func (p *fakeEmbeddingProvider) Model() string {
+	return "fake"
+}
+
+func (p *fakeEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		p.embedded = append(p.embedded, text)
+		key, _, _ := strings.Cut(text, "\n")
+		v, ok := p.vectors[key]
+		if !ok {
+			v = []float32{1, 1}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+func TestSemanticSearchUsecase_ReindexSkipsUnchangedContent(t *testing.T) {
+	ctx := context.Background()
+	blogs := &fakeBlogRepo{blogs: []*entity.Blog{
+		publishedBlog("a", "Alpha", "First post."),
+		publishedBlog("b", "Beta", "Second post."),
+	}}
+	embeddings := newFakeEmbeddingRepo()
+	provider := &fakeEmbeddingProvider{}
+	u := usecase.NewSemanticSearchUsecase(blogs, embeddings, provider, nopLogger{}, usecase.SemanticSearchConfig{})
+
+	require.NoError(t, u.Reindex(ctx))
+	assert.Len(t, provider.embedded, 2)
+	assert.ElementsMatch(t, []string{"a", "b"}, embeddings.upserted)
+
+	steps := []struct {
+		name         string
+		change       func()
+		wantEmbedded []string // titles embedded by this reindex
+		wantUpserted []string
+		wantDeleted  []string
+	}{
+		{
+			name:   "nothing changed",
+			change: func() {},
+		},
+		{
+			name:         "edited content",
+			change:       func() { blogs.blogs[1] = publishedBlog("b", "Beta", "Second post, edited.") },
+			wantEmbedded: []string{"Beta"},
+			wantUpserted: []string{"b"},
+		},
+		{
+			name: "counters and metadata changed but not the text",
+			change: func() {
+				blogs.blogs[0] = publishedBlog("a", "Alpha", "First post.")
+				blogs.blogs[0].ViewCount = 42
+			},
+		},
+		{
+			name:         "new blog",
+			change:       func() { blogs.blogs = append(blogs.blogs, publishedBlog("c", "Gamma", "Third post.")) },
+			wantEmbedded: []string{"Gamma"},
+			wantUpserted: []string{"c"},
+		},
+		{
+			name: "unpublished blog",
+			change: func() {
+				blogs.blogs[0] = publishedBlog("a", "Alpha", "First post.")
+				blogs.blogs[0].Status = entity.BlogStatusDraft
+			},
+			wantDeleted: []string{"a"},
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			provider.embedded, embeddings.upserted, embeddings.deleted = nil, nil, nil
+			step.change()
+			require.NoError(t, u.Reindex(ctx))
+
+			var titles []string
+			for _, text := range provider.embedded {
+				title, _, _ := strings.Cut(text, "\n")
+				titles = append(titles, title)
+			}
+			assert.Equal(t, step.wantEmbedded, titles)
+			assert.Equal(t, step.wantUpserted, embeddings.upserted)
+			assert.Equal(t, step.wantDeleted, embeddings.deleted)
+		})
+	}
+}
+
+func TestSemanticSearchUsecase_Search(t *testing.T) {
+	provider := &fakeEmbeddingProvider{vectors: map[string][]float32{
+		"query":     {1, 0},
+		"exact":     {3, 0}, // not unit length; similarity 1 once normalized
+		"close":     {0.8, 0.6},
+		"close-2":   {0.8, 0.6},
+		"related":   {0.6, 0.8},
+		"unrelated": {0, 1},
+		"opposite":  {-1, 0},
+		"other":     {1, 0, 0}, // another model's size is never compared
+	}}
+	var blogs []*entity.Blog
+	for title := range provider.vectors {
+		if title != "query" {
+			blogs = append(blogs, publishedBlog(title, title, ""))
+		}
+	}
+	sort.Slice(blogs, func(i, j int) bool { return blogs[i].ID < blogs[j].ID })
+
+	tests := []struct {
+		name          string
+		minSimilarity float64
+		limit         int
+		want          []string
+	}{
+		{name: "closest first, ties by ID", minSimilarity: 0.5, limit: 10, want: []string{"exact", "close", "close-2", "related"}},
+		{name: "cutoff drops weaker matches", minSimilarity: 0.7, limit: 10, want: []string{"exact", "close", "close-2"}},
+		{name: "cutoff is inclusive", minSimilarity: 0, limit: 10, want: []string{"exact", "close", "close-2", "related", "unrelated"}},
+		{name: "negative cutoff keeps opposites", minSimilarity: -1, limit: 10, want: []string{"exact", "close", "close-2", "related", "unrelated", "opposite"}},
+		{name: "limit keeps the best", minSimilarity: 0, limit: 2, want: []string{"exact", "close"}},
+		{name: "nothing close enough", minSimilarity: 1.5, limit: 10, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := usecase.NewSemanticSearchUsecase(&fakeBlogRepo{blogs: blogs}, newFakeEmbeddingRepo(), provider,
+				nopLogger{}, usecase.SemanticSearchConfig{MinSimilarity: tt.minSimilarity})
+			require.NoError(t, u.Reindex(context.Background()))
+
+			matches, err := u.Search(context.Background(), "query", tt.limit)
+			require.NoError(t, err)
+			ids := make([]string, len(matches))
+			for i, m := range matches {
+				ids[i] = m.BlogID
+				if i > 0 {
+					assert.GreaterOrEqual(t, matches[i-1].Similarity, m.Similarity)
+				}
+			}
+			assert.Equal(t, tt.want, ids)
+			if len(matches) > 0 {
+				assert.InDelta(t, 1, matches[0].Similarity, 1e-6)
+			}
+		})
+	}
+}
+
+func TestSemanticSearchUsecase_KeywordWeight(t *testing.T) {
+	tests := []struct {
+		weight float64
+		want   float64
+	}{
+		{weight: 0, want: 0},
+		{weight: 0.3, want: 0.3},
+		{weight: 1, want: 1},
+		{weight: -0.1, want: 0.5},
+		{weight: 1.5, want: 0.5},
+	}
+	for _, tt := range tests {
+		u := usecase.NewSemanticSearchUsecase(&fakeBlogRepo{}, newFakeEmbeddingRepo(), &fakeEmbeddingProvider{},
+			nopLogger{}, usecase.SemanticSearchConfig{KeywordWeight: tt.weight})
+		assert.Equal(t, tt.want, u.KeywordWeight())
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+// defaultTrendingGravity is used when no positive gravity is configured.
+const defaultTrendingGravity = 1.8
+
+// TrendingUsecase ranks blogs by recent activity with a time decay, recalculating the
+// scores of every trending window periodically.
+type TrendingUsecase struct {
+	trendingRepo contract.ITrendingRepository
+	logger       usecasecontract.IAppLogger
+	gravity      float64
+}
+
+// NewTrendingUsecase creates a new TrendingUsecase. A higher gravity makes scores fall off faster with age.
+func NewTrendingUsecase(trendingRepo contract.ITrendingRepository, logger usecasecontract.IAppLogger, gravity float64) *TrendingUsecase {
+	if gravity <= 0 {
+		gravity = defaultTrendingGravity
+	}
+	return &TrendingUsecase{trendingRepo: trendingRepo, logger: logger, gravity: gravity}
+}
+
+// Start recalculates the trending scores now and then every interval until ctx is
+// cancelled. A non-positive interval disables the schedule.
+func (u *TrendingUsecase) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	runPeriodically(ctx, interval, u.logger, "trending recalculation", u.Recalculate)
+}
+
+// Recalculate recomputes the trending scores of every window.
+func (u *TrendingUsecase) Recalculate(ctx context.Context) error {
+	now := time.Now()
+	for _, window := range entity.TrendingWindows {
+		signals, err := u.trendingRepo.CollectSignals(ctx, now.Add(-window.Duration()))
+		if err != nil {
+			return err
+		}
+
+		scores := make(map[string]float64, len(signals))
+		for _, s := range signals {
+			if score := u.score(s, window, now); score > 0 {
+				scores[s.BlogID] = score
+			}
+		}
+		if err := u.trendingRepo.SetTrendingScores(ctx, window, scores); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTrendingBlogs returns the blogs trending in the window, paginated like GetPopularBlogs.
+func (u *TrendingUsecase) GetTrendingBlogs(ctx context.Context, window string, page, pageSize int) ([]entity.Blog, int, int, int, error) {
+	w := entity.TrendingWindow(window)
+	if window == "" {
+		w = entity.TrendingWindowDay
+	}
+	if w.Duration() == 0 {
+		return nil, 0, 0, 0, fmt.Errorf("invalid window %q: use 24h, 7d or 30d", window)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	blogs, total, err := u.trendingRepo.GetTrendingBlogs(ctx, w, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, 0, 0, 0, fmt.Errorf("failed to get trending blogs: %w", err)
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize != 0 {
+		totalPages++
+	}
+	result := make([]entity.Blog, 0, len(blogs))
+	for _, b := range blogs {
+		result = append(result, *b)
+	}
+	return result, int(total), page, totalPages, nil
+}
+
+// score decays a blog's activity in the window by its age. Age is capped at the window
+// length, so an older post with a burst of fresh activity can still trend while new posts
+// keep their edge.
+func (u *TrendingUsecase) score(s contract.TrendingSignals, window entity.TrendingWindow, now time.Time) float64 {
+	age := now.Sub(s.PublishedAt)
+	if age > window.Duration() {
+		age = window.Duration()
+	}
+	return utils.CalculateTrendingScore(int(s.Views), int(s.Likes), int(s.Dislikes), int(s.Reactions), int(s.Comments), age, u.gravity)
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import "context"
+
+// directUnitOfWork runs work as-is, without a transaction. Usecases fall back to it when
+// no unit of work is configured.
+type directUnitOfWork struct{}
+
+func (directUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -539,6 +539,10 @@ func (uc *UserUsecase) UpdateProfile(ctx context.Context, userID string, updates
 			if isActive, ok := v.(bool); ok {
 				user.IsActive = isActive
 			}
+		case "hide_reactions":
+			if hideReactions, ok := v.(bool); ok {
+				user.HideReactions = hideReactions
+			}
 		}
 	}
 	user.UpdatedAt = time.Now()
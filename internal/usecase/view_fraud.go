@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	usecasecontract "github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+)
+
+const (
+	defaultOffenderPeriod = 24 * time.Hour
+	maxOffenderPeriod     = 30 * 24 * time.Hour
+	maxOffendersListed    = 100
+)
+
+// ViewFraudMatch is one rule that a view tripped.
+type ViewFraudMatch struct {
+	Rule   string
+	Action entity.ViewFraudAction
+	Reason string
+}
+
+// ViewFraudDecision is the outcome of running a view through the fraud rules. Action is
+// that of the strictest matching rule, empty when none matched.
+type ViewFraudDecision struct {
+	Action      entity.ViewFraudAction
+	Matches     []ViewFraudMatch
+	Fingerprint string
+}
+
+// Err returns the error a blocked view is rejected with. The velocity rules keep the
+// messages clients already know.
+func (d ViewFraudDecision) Err() error {
+	for _, m := range d.Matches {
+		if m.Action != d.Action {
+			continue
+		}
+		switch m.Rule {
+		case entity.ViewFraudRuleIPVelocity:
+			return errors.New("exceeded view velocity limit: too many views from this IP recently")
+		case entity.ViewFraudRuleUserIPRotation:
+			return errors.New("exceeded IP rotation limit: too many IPs used by this user recently")
+		default:
+			return fmt.Errorf("view blocked: %s", m.Reason)
+		}
+	}
+	return nil
+}
+
+// ViewFraudUsecase decides whether views are genuine using configurable rules, and keeps a
+// record of the views it flagged for admins.
+type ViewFraudUsecase struct {
+	rules       []entity.ViewFraudRule
+	datacenters []netip.Prefix
+	fraudRepo   contract.IViewFraudRepository
+	blogRepo    contract.IBlogRepository
+	cache       contract.IBlogCache
+	logger      usecasecontract.IAppLogger
+}
+
+// NewViewFraudUsecase creates a ViewFraudUsecase for the given rules. Rules switched off
+// are left out; unparseable datacenter ranges are logged and skipped.
+func NewViewFraudUsecase(rules []entity.ViewFraudRule, fraudRepo contract.IViewFraudRepository, blogRepo contract.IBlogRepository, logger usecasecontract.IAppLogger) *ViewFraudUsecase {
+	uc := &ViewFraudUsecase{fraudRepo: fraudRepo, blogRepo: blogRepo, logger: logger}
+	for _, rule := range rules {
+		if rule.Action.Severity() == 0 {
+			continue
+		}
+		if rule.Name == entity.ViewFraudRuleDatacenterIP {
+			ranges, invalid := utils.ParseIPRanges(rule.Values)
+			if len(invalid) > 0 {
+				logger.Warningf("ignoring %d invalid datacenter IP ranges, e.g. %q", len(invalid), invalid[0])
+			}
+			uc.datacenters = ranges
+		}
+		uc.rules = append(uc.rules, rule)
+	}
+	return uc
+}
+
+// SetBlogCache makes the velocity rules count views in the cache instead of the database.
+func (uc *ViewFraudUsecase) SetBlogCache(cache contract.IBlogCache) {
+	uc.cache = cache
+}
+
+// Rules returns the active rules.
+func (uc *ViewFraudUsecase) Rules() []entity.ViewFraudRule {
+	return uc.rules
+}
+
+// Evaluate runs a view through every active rule.
+// The raw IP is only used in memory, for the datacenter check; velocity is tracked by hash.
+func (uc *ViewFraudUsecase) Evaluate(ctx context.Context, blogID string, req dto.TrackViewRequest, viewer ViewerIdentity) ViewFraudDecision {
+	decision := ViewFraudDecision{Fingerprint: utils.HeaderFingerprint(req.UserAgent, req.Headers)}
+	for _, rule := range uc.rules {
+		reason, matched := uc.check(ctx, rule, blogID, req, viewer)
+		if !matched {
+			continue
+		}
+		decision.Matches = append(decision.Matches, ViewFraudMatch{Rule: rule.Name, Action: rule.Action, Reason: reason})
+		if rule.Action.Severity() > decision.Action.Severity() {
+			decision.Action = rule.Action
+		}
+	}
+	return decision
+}
+
+func (uc *ViewFraudUsecase) check(ctx context.Context, rule entity.ViewFraudRule, blogID string, req dto.TrackViewRequest, viewer ViewerIdentity) (string, bool) {
+	switch rule.Name {
+	case entity.ViewFraudRuleBotUserAgent:
+		ua := strings.ToLower(req.UserAgent)
+		for _, sig := range rule.Values {
+			if sig != "" && strings.Contains(ua, strings.ToLower(sig)) {
+				return fmt.Sprintf("user agent contains bot signature %q", sig), true
+			}
+		}
+	case entity.ViewFraudRuleMissingUserAgent:
+		if strings.TrimSpace(req.UserAgent) == "" {
+			return "no user agent", true
+		}
+	case entity.ViewFraudRuleUnknownUserAgent:
+		if req.UserAgent != "" && utils.UserAgentFamily(req.UserAgent) == "other" {
+			return "user agent of no known browser", true
+		}
+	case entity.ViewFraudRuleHeaderFingerprint:
+		var missing []string
+		for _, name := range rule.Values {
+			if req.Headers[strings.ToLower(name)] == "" {
+				missing = append(missing, strings.ToLower(name))
+			}
+		}
+		if len(missing) > 0 {
+			return "missing headers " + strings.Join(missing, ", "), true
+		}
+	case entity.ViewFraudRuleDatacenterIP:
+		if utils.IPInRanges(req.IPAddress, uc.datacenters) {
+			return "IP address in a datacenter range", true
+		}
+	case entity.ViewFraudRuleIPVelocity:
+		if viewer.IPHash == "" || rule.Limit <= 0 {
+			break
+		}
+		if n := uc.recentViewsByIP(ctx, viewer.IPHash, blogID, rule.Window); n > int64(rule.Limit) {
+			return fmt.Sprintf("%d views from this IP within %s (max %d)", n, rule.Window, rule.Limit), true
+		}
+	case entity.ViewFraudRuleUserIPRotation:
+		if req.UserID == "" || rule.Limit <= 0 {
+			break
+		}
+		if n := uc.recentIPsByUser(ctx, req.UserID, viewer.IPHash, rule.Window); n > int64(rule.Limit) {
+			return fmt.Sprintf("%d IPs used by this user within %s (max %d)", n, rule.Window, rule.Limit), true
+		}
+	}
+	return "", false
+}
+
+// recentViewsByIP adds this view to the IP's recent views and returns how many there are.
+// The IP is identified by its hash. The database is consulted when there is no cache or it fails.
+func (uc *ViewFraudUsecase) recentViewsByIP(ctx context.Context, ipHash, blogID string, window time.Duration) int64 {
+	if uc.cache != nil {
+		_ = uc.cache.AddRecentViewByIP(ctx, ipHash, blogID, int64(window.Seconds()))
+		if n, err := uc.cache.GetRecentViewCountByIP(ctx, ipHash); err == nil {
+			return n
+		}
+	}
+	views, err := uc.blogRepo.GetRecentViewsByIPHash(ctx, ipHash, time.Now().Add(-window))
+	if err != nil {
+		return 0
+	}
+	return int64(len(views))
+}
+
+// recentIPsByUser adds the IP hash to the user's recent IPs and returns how many distinct ones there are.
+func (uc *ViewFraudUsecase) recentIPsByUser(ctx context.Context, userID, ipHash string, window time.Duration) int64 {
+	if uc.cache != nil {
+		_ = uc.cache.AddRecentViewByUser(ctx, userID, ipHash, int64(window.Seconds()))
+		if n, err := uc.cache.GetRecentIPCountByUser(ctx, userID); err == nil {
+			return n
+		}
+	}
+	views, err := uc.blogRepo.GetRecentViewsByUser(ctx, userID, time.Now().Add(-window))
+	if err != nil {
+		return 0
+	}
+	ipSet := map[string]struct{}{ipHash: {}}
+	for _, view := range views {
+		ipSet[view.IPHash] = struct{}{}
+	}
+	return int64(len(ipSet))
+}
+
+// Record stores a view that matched at least one rule. Failures are logged, not returned,
+// so that review bookkeeping never decides the fate of a view.
+func (uc *ViewFraudUsecase) Record(ctx context.Context, blogID string, req dto.TrackViewRequest, viewer ViewerIdentity, decision ViewFraudDecision) {
+	if len(decision.Matches) == 0 {
+		return
+	}
+	rules := make([]string, len(decision.Matches))
+	reasons := make([]string, len(decision.Matches))
+	for i, m := range decision.Matches {
+		rules[i], reasons[i] = m.Rule, m.Reason
+	}
+	// Like stored views, a flagged view of a reader who opted out of tracking keeps only
+	// what identifies the request for the day: the IP hash and the header fingerprint.
+	flagged := &entity.FlaggedView{
+		BlogID:      blogID,
+		IPHash:      viewer.IPHash,
+		Fingerprint: decision.Fingerprint,
+		Action:      decision.Action,
+		Rules:       rules,
+		Reasons:     reasons,
+		CreatedAt:   time.Now(),
+	}
+	if !viewer.NoTracking {
+		flagged.UserID = req.UserID
+		flagged.IPPrefix = viewer.IPPrefix
+	}
+	if req.UserAgent != "" {
+		flagged.UserAgentFamily = utils.UserAgentFamily(req.UserAgent)
+	}
+	uc.logger.Warningf("view of blog %s %s by fraud rules %s (ip hash %s, user %q)", blogID, decision.Action, strings.Join(rules, ","), viewer.IPHash, flagged.UserID)
+
+	if uc.fraudRepo == nil {
+		return
+	}
+	if err := uc.fraudRepo.RecordFlaggedView(ctx, flagged); err != nil {
+		uc.logger.Errorf("failed to record flagged view: %v", err)
+	}
+}
+
+// ListFlaggedViews returns flagged views for admin review, newest first.
+func (uc *ViewFraudUsecase) ListFlaggedViews(ctx context.Context, filter contract.FlaggedViewFilter, page, pageSize int) (*dto.FlaggedViewsResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	switch filter.Action {
+	case "", entity.ViewFraudActionFlag, entity.ViewFraudActionDrop, entity.ViewFraudActionBlock:
+	default:
+		return nil, fmt.Errorf("invalid action %q: use flag, drop or block", filter.Action)
+	}
+
+	views, total, err := uc.fraudRepo.ListFlaggedViews(ctx, filter, contract.Pagination{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flagged views: %w", err)
+	}
+
+	resp := &dto.FlaggedViewsResponse{
+		Views:      make([]*dto.FlaggedViewResponse, len(views)),
+		Pagination: buildPaginationMeta(page, pageSize, total),
+	}
+	for i, v := range views {
+		resp.Views[i] = &dto.FlaggedViewResponse{
+			ID:              v.ID,
+			BlogID:          v.BlogID,
+			UserID:          v.UserID,
+			IPHash:          v.IPHash,
+			IPPrefix:        v.IPPrefix,
+			UserAgentFamily: v.UserAgentFamily,
+			Fingerprint:     v.Fingerprint,
+			Action:          string(v.Action),
+			Rules:           v.Rules,
+			Reasons:         v.Reasons,
+			CreatedAt:       v.CreatedAt,
+		}
+	}
+	return resp, nil
+}
+
+// TopOffenders returns the IP hashes, users or header fingerprints ("ip", "user", "fingerprint")
+// behind the most flagged views within the period.
+func (uc *ViewFraudUsecase) TopOffenders(ctx context.Context, by string, period time.Duration, limit int) ([]contract.ViewOffender, error) {
+	fields := map[string]string{"ip": "ip_hash", "user": "user_id", "fingerprint": "fingerprint"}
+	field, ok := fields[by]
+	if !ok {
+		return nil, fmt.Errorf("invalid offender type %q: use ip, user or fingerprint", by)
+	}
+	if period <= 0 {
+		period = defaultOffenderPeriod
+	}
+	if period > maxOffenderPeriod {
+		return nil, errors.New("invalid period: at most 30 days")
+	}
+	if limit < 1 || limit > maxOffendersListed {
+		limit = 20
+	}
+
+	offenders, err := uc.fraudRepo.TopOffenders(ctx, field, time.Now().Add(-period), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list view offenders: %w", err)
+	}
+	return offenders, nil
+}
@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/dto"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/usecase"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeViewHistory serves the recent views the velocity rules look up, keyed by IP hash and
+// by user.
+type fakeViewHistory struct {
+	contract.IBlogRepository
+	byIP   map[string][]entity.BlogView
+	byUser map[string][]entity.BlogView
+}
+
+func (r *fakeViewHistory) GetRecentViewsByIPHash(ctx context.Context, ipHash string, since time.Time) ([]entity.BlogView, error) {
+	return r.byIP[ipHash], nil
+}
+
+func (r *fakeViewHistory) GetRecentViewsByUser(ctx context.Context, userID string, since time.Time) ([]entity.BlogView, error) {
+	return r.byUser[userID], nil
+}
+
+func TestViewFraudUsecase_Evaluate(t *testing.T) {
+	const chrome = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+	browserHeaders := map[string]string{"accept": "text/html", "accept-language": "en"}
+
+	history := &fakeViewHistory{
+		byIP: map[string][]entity.BlogView{
+			"busy-ip":   {{BlogID: "a"}, {BlogID: "b"}, {BlogID: "c"}},
+			"steady-ip": {{BlogID: "a"}, {BlogID: "b"}},
+		},
+		byUser: map[string][]entity.BlogView{
+			"rotating-user": {{IPHash: "ip-1"}, {IPHash: "ip-2"}},
+			"steady-user":   {{IPHash: "ip-1"}, {IPHash: "ip-1"}},
+		},
+	}
+	fraud := usecase.NewViewFraudUsecase([]entity.ViewFraudRule{
+		{Name: entity.ViewFraudRuleBotUserAgent, Action: entity.ViewFraudActionDrop, Values: []string{"bot", "curl"}},
+		{Name: entity.ViewFraudRuleMissingUserAgent, Action: entity.ViewFraudActionFlag},
+		{Name: entity.ViewFraudRuleUnknownUserAgent, Action: entity.ViewFraudActionFlag},
+		{Name: entity.ViewFraudRuleHeaderFingerprint, Action: entity.ViewFraudActionFlag, Values: []string{"Accept", "Accept-Language"}},
+		{Name: entity.ViewFraudRuleDatacenterIP, Action: entity.ViewFraudActionBlock, Values: []string{"203.0.113.0/24", "not-a-range"}},
+		{Name: entity.ViewFraudRuleIPVelocity, Action: entity.ViewFraudActionBlock, Limit: 2, Window: time.Hour},
+		{Name: entity.ViewFraudRuleUserIPRotation, Action: entity.ViewFraudActionFlag, Limit: 2, Window: time.Hour},
+		{Name: entity.ViewFraudRuleBotUserAgent, Action: entity.ViewFraudActionOff, Values: []string{"mozilla"}},
+	}, nil, history, nopLogger{})
+
+	tests := []struct {
+		name        string
+		req         dto.TrackViewRequest
+		ipHash      string
+		wantAction  entity.ViewFraudAction
+		wantReasons map[string]string // rule name to reason
+		wantErr     string
+	}{
+		{
+			name:       "genuine browser",
+			req:        dto.TrackViewRequest{IPAddress: "198.51.100.7", UserAgent: chrome, Headers: browserHeaders},
+			ipHash:     "steady-ip",
+			wantAction: "",
+		},
+		{
+			name:       "bot signature",
+			req:        dto.TrackViewRequest{UserAgent: "Googlebot/2.1 (+http://www.google.com/bot.html)", Headers: browserHeaders},
+			wantAction: entity.ViewFraudActionDrop,
+			wantReasons: map[string]string{
+				entity.ViewFraudRuleBotUserAgent:     `user agent contains bot signature "bot"`,
+				entity.ViewFraudRuleUnknownUserAgent: "user agent of no known browser",
+			},
+		},
+		{
+			name:        "missing user agent",
+			req:         dto.TrackViewRequest{Headers: browserHeaders},
+			wantAction:  entity.ViewFraudActionFlag,
+			wantReasons: map[string]string{entity.ViewFraudRuleMissingUserAgent: "no user agent"},
+		},
+		{
+			name:        "missing browser header",
+			req:         dto.TrackViewRequest{UserAgent: chrome, Headers: map[string]string{"accept": "text/html"}},
+			wantAction:  entity.ViewFraudActionFlag,
+			wantReasons: map[string]string{entity.ViewFraudRuleHeaderFingerprint: "missing headers accept-language"},
+		},
+		{
+			name:        "datacenter address",
+			req:         dto.TrackViewRequest{IPAddress: "203.0.113.9", UserAgent: chrome, Headers: browserHeaders},
+			wantAction:  entity.ViewFraudActionBlock,
+			wantReasons: map[string]string{entity.ViewFraudRuleDatacenterIP: "IP address in a datacenter range"},
+			wantErr:     "view blocked: IP address in a datacenter range",
+		},
+		{
+			name:        "too many views from one IP",
+			req:         dto.TrackViewRequest{UserAgent: chrome, Headers: browserHeaders},
+			ipHash:      "busy-ip",
+			wantAction:  entity.ViewFraudActionBlock,
+			wantReasons: map[string]string{entity.ViewFraudRuleIPVelocity: "3 views from this IP within 1h0m0s (max 2)"},
+			wantErr:     "exceeded view velocity limit: too many views from this IP recently",
+		},
+		{
+			name:        "too many IPs for one user",
+			req:         dto.TrackViewRequest{UserID: "rotating-user", UserAgent: chrome, Headers: browserHeaders},
+			ipHash:      "ip-3",
+			wantAction:  entity.ViewFraudActionFlag,
+			wantReasons: map[string]string{entity.ViewFraudRuleUserIPRotation: "3 IPs used by this user within 1h0m0s (max 2)"},
+		},
+		{
+			name:       "user on a known IP",
+			req:        dto.TrackViewRequest{UserID: "steady-user", UserAgent: chrome, Headers: browserHeaders},
+			ipHash:     "ip-2",
+			wantAction: "",
+		},
+		{
+			name:       "strictest action wins",
+			req:        dto.TrackViewRequest{IPAddress: "203.0.113.9", UserAgent: "curl/8.4.0", Headers: browserHeaders},
+			wantAction: entity.ViewFraudActionBlock,
+			wantReasons: map[string]string{
+				entity.ViewFraudRuleBotUserAgent:     `user agent contains bot signature "curl"`,
+				entity.ViewFraudRuleUnknownUserAgent: "user agent of no known browser",
+				entity.ViewFraudRuleDatacenterIP:     "IP address in a datacenter range",
+			},
+			wantErr: "view blocked: IP address in a datacenter range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := fraud.Evaluate(context.Background(), "blog", tt.req, usecase.ViewerIdentity{IPHash: tt.ipHash})
+			assert.Equal(t, tt.wantAction, decision.Action)
+			assert.Equal(t, utils.HeaderFingerprint(tt.req.UserAgent, tt.req.Headers), decision.Fingerprint)
+
+			reasons := make(map[string]string, len(decision.Matches))
+			for _, m := range decision.Matches {
+				reasons[m.Rule] = m.Reason
+			}
+			if tt.wantReasons == nil {
+				tt.wantReasons = map[string]string{}
+			}
+			assert.Equal(t, tt.wantReasons, reasons)
+			if tt.wantErr != "" {
+				assert.EqualError(t, decision.Err(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// ReferrerDirect is the referrer key for views that arrived without a usable Referer header.
+const ReferrerDirect = "direct"
+
+// ReferrerHost reduces a Referer header to its host name, without a leading "www.".
+func ReferrerHost(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil || u.Hostname() == "" {
+		return ReferrerDirect
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// UserAgentFamily returns the browser family of a User-Agent string. The order of the
+// checks matters: Edge and Opera also claim to be Chrome, and Chrome claims to be Safari.
+func UserAgentFamily(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return "unknown"
+	case strings.Contains(ua, "edg/") || strings.Contains(ua, "edge/"):
+		return "edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		return "opera"
+	case strings.Contains(ua, "samsungbrowser"):
+		return "samsung"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		return "firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		return "chrome"
+	case strings.Contains(ua, "safari/"):
+		return "safari"
+	default:
+		return "other"
+	}
+}
+
+// ViewerKey returns a stable pseudonymous key for a viewer: the user ID when signed in,
+// the IP address otherwise, hashed so that rollups do not store either.
+func ViewerKey(userID, ipAddress string) string {
+	raw := "ip:" + ipAddress
+	if userID != "" {
+		raw = "user:" + userID
+	}
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:16])
+}
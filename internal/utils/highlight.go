@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Markers wrapped around matched terms in highlighted snippets.
+const (
+	HighlightOpenTag  = "<mark>"
+	HighlightCloseTag = "</mark>"
+)
+
+// SearchTerms splits a free-text query into unique lowercase terms suitable for highlighting.
+func SearchTerms(query string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !isWordRune(r)
+	})
+	seen := make(map[string]struct{}, len(fields))
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, ok := seen[f]; ok {
+			continue
+		}
+		seen[f] = struct{}{}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// HighlightSnippet returns a window of at most maxLen runes of text centred on the first
+// term match, with every match wrapped in HighlightOpenTag/HighlightCloseTag. Terms match
+// case-insensitively at the start of a word, so "run" also marks "running". The text itself
+// is HTML-escaped so the snippet is safe to render. A maxLen <= 0 keeps the whole text.
+func HighlightSnippet(text string, terms []string, maxLen int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	termRunes := make([][]rune, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			termRunes = append(termRunes, []rune(t))
+		}
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for i := 0; i < len(lower); {
+		if i > 0 && isWordRune(lower[i-1]) {
+			i++
+			continue
+		}
+		matched := 0
+		for _, tr := range termRunes {
+			if len(tr) > matched && hasRunePrefix(lower[i:], tr) {
+				matched = len(tr)
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		spans = append(spans, span{start: i, end: i + matched})
+		i += matched
+	}
+
+	start, end := 0, len(runes)
+	if maxLen > 0 && len(runes) > maxLen {
+		if len(spans) > 0 {
+			start = spans[0].start - maxLen/4
+			if start < 0 {
+				start = 0
+			}
+		}
+		end = start + maxLen
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxLen
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, s := range spans {
+		if s.end <= start || s.start >= end {
+			continue
+		}
+		if s.start < pos {
+			s.start = pos
+		}
+		if s.end > end {
+			s.end = end
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s.start])))
+		b.WriteString(HighlightOpenTag)
+		b.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		b.WriteString(HighlightCloseTag)
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func hasRunePrefix(s, prefix []rune) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i := range prefix {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Excerpt returns the start of text with whitespace collapsed, cut at a word boundary to at
+// most maxLen runes and ending in "…" when shortened.
+func Excerpt(text string, maxLen int) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	runes := []rune(collapsed)
+	if maxLen <= 0 || len(runes) <= maxLen {
+		return collapsed
+	}
+	cut := maxLen
+	for i := maxLen; i > maxLen/2; i-- {
+		if runes[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRight(string(runes[:cut]), " ,.;:") + "…"
+}
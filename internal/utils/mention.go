@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+const (
+	// MaxMentionsPerText caps how many distinct users a single comment or post can mention.
+	MaxMentionsPerText = 10
+	// MentionProfilePath is where rendered mentions link to; the user ID is appended.
+	MentionProfilePath = "/api/v1/users/profile/"
+)
+
+// mentionPattern matches @username where the @ is not glued to a preceding word
+// (so e-mail addresses are not picked up). Usernames are 3-32 characters.
+var mentionPattern = regexp.MustCompile(`(^|[^\w@])@([A-Za-z0-9_.\-]{3,32})`)
+
+// ExtractMentions returns the distinct usernames mentioned in text, in order of first
+// appearance. A trailing dot is treated as punctuation rather than part of the name.
+func ExtractMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]struct{})
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(m[2], ".")
+		if len(username) < 3 {
+			continue
+		}
+		key := strings.ToLower(username)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		usernames = append(usernames, username)
+		if len(usernames) == MaxMentionsPerText {
+			break
+		}
+	}
+	return usernames
+}
+
+// RenderMentions HTML-escapes text and turns every resolved @username into a link to
+// that user's profile. Mentions that did not resolve to a user are left as plain text.
+func RenderMentions(text string, mentions []entity.Mention) string {
+	if len(mentions) == 0 {
+		return html.EscapeString(text)
+	}
+	links := make(map[string]string, len(mentions))
+	for _, m := range mentions {
+		links[strings.ToLower(m.Username)] = MentionProfilePath + m.UserID
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, idx := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := idx[4], idx[5] // the username group
+		username := strings.TrimRight(text[start:end], ".")
+		href, ok := links[strings.ToLower(username)]
+		if !ok {
+			continue
+		}
+		end = start + len(username)
+		b.WriteString(html.EscapeString(text[last : start-1])) // up to, not including, the @
+		b.WriteString(`<a href="` + html.EscapeString(href) + `">@` + html.EscapeString(username) + `</a>`)
+		last = end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTagPattern      = regexp.MustCompile(`<[^>]*>`)
+	mdImagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdLinePrefixPattern = regexp.MustCompile(`(?m)^\s*(#{1,6}|>+|[-*+]|\d+\.)\s+`)
+	mdFencePattern      = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	mdEmphasisPattern   = regexp.MustCompile("[*_~`]+")
+)
+
+// PlainText derives the readable text of blog content written in Markdown or light HTML:
+// tags and formatting marks are dropped, links and images keep their text, entities are
+// decoded and whitespace is collapsed. Code blocks keep their contents.
+func PlainText(content string) string {
+	text := mdFencePattern.ReplaceAllString(content, " ")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = mdImagePattern.ReplaceAllString(text, "$1")
+	text = mdLinkPattern.ReplaceAllString(text, "$1")
+	text = mdLinePrefixPattern.ReplaceAllString(text, "")
+	text = mdEmphasisPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.Join(strings.Fields(text), " ")
+}
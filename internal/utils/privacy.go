@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// HashIP returns a keyed hash of an IP address that changes every UTC day. Without the
+// secret the address cannot be recovered by hashing candidate addresses, and hashes from
+// different days cannot be linked to each other.
+func HashIP(secret []byte, ip string, at time.Time) string {
+	if ip == "" {
+		return ""
+	}
+	dayKey := hmac.New(sha256.New, secret)
+	dayKey.Write([]byte(at.UTC().Format("2006-01-02")))
+
+	mac := hmac.New(sha256.New, dayKey.Sum(nil))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// IPNetworkPrefix truncates an address to its network: /24 for IPv4 and /48 for IPv6.
+// That is enough for geography-level analytics without identifying a household.
+func IPNetworkPrefix(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
+
+// PrefersNoTracking reports whether a request carries a Do-Not-Track or Global Privacy
+// Control signal. headers must have lowercase names.
+func PrefersNoTracking(headers map[string]string) bool {
+	return strings.TrimSpace(headers["dnt"]) == "1" || strings.TrimSpace(headers["sec-gpc"]) == "1"
+}
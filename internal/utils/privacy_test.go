@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashIP(t *testing.T) {
+	secret := []byte("secret")
+	morning := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	base := utils.HashIP(secret, "198.51.100.7", morning)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), base)
+
+	tests := []struct {
+		name     string
+		secret   []byte
+		ip       string
+		at       time.Time
+		wantSame bool
+	}{
+		{name: "same day", secret: secret, ip: "198.51.100.7", at: morning.Add(23*time.Hour + 59*time.Minute), wantSame: true},
+		{name: "same UTC day in another zone", secret: secret, ip: "198.51.100.7", at: morning.Add(12 * time.Hour).In(time.FixedZone("UTC+13", 13*3600)), wantSame: true},
+		{name: "next day", secret: secret, ip: "198.51.100.7", at: morning.Add(24 * time.Hour)},
+		{name: "other secret", secret: []byte("other"), ip: "198.51.100.7", at: morning},
+		{name: "other address", secret: secret, ip: "198.51.100.8", at: morning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.HashIP(tt.secret, tt.ip, tt.at)
+			if tt.wantSame {
+				assert.Equal(t, base, got)
+			} else {
+				assert.NotEqual(t, base, got)
+			}
+		})
+	}
+
+	t.Run("no address", func(t *testing.T) {
+		assert.Empty(t, utils.HashIP(secret, "", morning))
+	})
+}
+
+func TestIPNetworkPrefix(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "203.0.113.77", want: "203.0.113.0/24"},
+		{ip: "2001:db8:abcd:12:34::1", want: "2001:db8:abcd::/48"},
+		{ip: "::ffff:198.51.100.9", want: "198.51.100.0/24"},
+		{ip: "10.0.0.1:8080", want: ""},
+		{ip: "not-an-ip", want: ""},
+		{ip: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.IPNetworkPrefix(tt.ip))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a search box query split into its parts: plain terms, "quoted phrases"
+// that must appear as written and -excluded terms that must not appear at all.
+type SearchQuery struct {
+	Terms    []string
+	Phrases  []string
+	Excluded []string
+}
+
+// ParseSearchQuery parses a query such as `golang "error handling" -java`. Everything is
+// lowercased and split into words the way the text is. An unterminated quote runs to the end
+// of the query, and a minus before a quoted phrase excludes each of its words.
+func ParseSearchQuery(query string) SearchQuery {
+	var q SearchQuery
+	seen := make(map[string]struct{})
+	add := func(list *[]string, kind byte, value string) {
+		key := string(kind) + value
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		*list = append(*list, value)
+	}
+
+	rest := query
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		negated := false
+		if rest[0] == '-' {
+			negated = true
+			rest = rest[1:]
+		}
+
+		var chunk string
+		quoted := rest != "" && rest[0] == '"'
+		if quoted {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				chunk, rest = rest[1:], ""
+			} else {
+				chunk, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			chunk, rest = rest[:end], rest[end:]
+		}
+
+		words := queryWords(chunk)
+		switch {
+		case len(words) == 0:
+		case negated:
+			for _, w := range words {
+				add(&q.Excluded, '-', w)
+			}
+		case quoted && len(words) > 1:
+			add(&q.Phrases, '"', strings.Join(words, " "))
+		default:
+			for _, w := range words {
+				add(&q.Terms, '+', w)
+			}
+		}
+	}
+	return q
+}
+
+// IsEmpty reports whether the query has nothing that a document could match.
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0
+}
+
+// OnlyStopWords reports whether the query has terms but no phrases and every term is a stop
+// word, which search indexes leave out, so nothing could match it.
+func (q SearchQuery) OnlyStopWords() bool {
+	if len(q.Terms) == 0 || len(q.Phrases) > 0 {
+		return false
+	}
+	for _, t := range q.Terms {
+		if !IsStopWord(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the query back into search box syntax.
+func (q SearchQuery) String() string {
+	parts := make([]string, 0, len(q.Terms)+len(q.Phrases)+len(q.Excluded))
+	parts = append(parts, q.Terms...)
+	for _, p := range q.Phrases {
+		parts = append(parts, `"`+p+`"`)
+	}
+	for _, e := range q.Excluded {
+		parts = append(parts, "-"+e)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Text returns the positive part of the query as plain words, for matching by meaning.
+func (q SearchQuery) Text() string {
+	return strings.Join(append(append([]string{}, q.Terms...), q.Phrases...), " ")
+}
+
+// HighlightTerms returns the terms and the words of the phrases, for HighlightSnippet.
+func (q SearchQuery) HighlightTerms() []string {
+	terms := append([]string{}, q.Terms...)
+	for _, p := range q.Phrases {
+		terms = append(terms, strings.Fields(p)...)
+	}
+	return SearchTerms(strings.Join(terms, " "))
+}
+
+// Matches reports whether text contains every phrase and none of the excluded terms. Plain
+// terms are not checked; they only rank.
+func (q SearchQuery) Matches(text string) bool {
+	words := queryWords(text)
+	if len(q.Excluded) > 0 {
+		present := make(map[string]struct{}, len(words))
+		for _, w := range words {
+			present[w] = struct{}{}
+		}
+		for _, e := range q.Excluded {
+			if _, ok := present[e]; ok {
+				return false
+			}
+		}
+	}
+	if len(q.Phrases) > 0 {
+		joined := " " + strings.Join(words, " ") + " "
+		for _, p := range q.Phrases {
+			if !strings.Contains(joined, " "+p+" ") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func queryWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
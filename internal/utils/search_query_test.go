@@ -0,0 +1,169 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchQuery_OnlyStopWords(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "the", want: true},
+		{query: "to be or not", want: true},
+		{query: "to be -question", want: true},
+		{query: `"to be"`, want: false},
+		{query: `to "not to be"`, want: false},
+		{query: "the golang", want: false},
+		{query: "", want: false},
+		{query: "-the", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.ParseSearchQuery(tt.query).OnlyStopWords())
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  utils.SearchQuery
+	}{
+		{
+			name:  "terms, phrase and exclusion",
+			query: `golang "error handling" -java`,
+			want:  utils.SearchQuery{Terms: []string{"golang"}, Phrases: []string{"error handling"}, Excluded: []string{"java"}},
+		},
+		{
+			name:  "excluded phrase excludes each word",
+			query: `java -"spring boot"`,
+			want:  utils.SearchQuery{Terms: []string{"java"}, Excluded: []string{"spring", "boot"}},
+		},
+		{
+			name:  "quoted single word is a term",
+			query: `"golang"`,
+			want:  utils.SearchQuery{Terms: []string{"golang"}},
+		},
+		{
+			name:  "unterminated quote runs to the end",
+			query: `rust "memory safety`,
+			want:  utils.SearchQuery{Terms: []string{"rust"}, Phrases: []string{"memory safety"}},
+		},
+		{
+			name:  "lowercased and deduplicated",
+			query: `Go GO go "Error  Handling" "error handling" -Java -java`,
+			want:  utils.SearchQuery{Terms: []string{"go"}, Phrases: []string{"error handling"}, Excluded: []string{"java"}},
+		},
+		{
+			name:  "punctuation splits words",
+			query: `node.js -c++ "ci/cd pipelines"`,
+			want:  utils.SearchQuery{Terms: []string{"node", "js"}, Phrases: []string{"ci cd pipelines"}, Excluded: []string{"c"}},
+		},
+		{
+			name:  "hyphen inside a word does not exclude",
+			query: `error-handling`,
+			want:  utils.SearchQuery{Terms: []string{"error", "handling"}},
+		},
+		{
+			name:  "exclusions only",
+			query: `-java -"spring boot"`,
+			want:  utils.SearchQuery{Excluded: []string{"java", "spring", "boot"}},
+		},
+		{
+			name:  "empty parts are ignored",
+			query: `  - "" -"" ... `,
+			want:  utils.SearchQuery{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.ParseSearchQuery(tt.query))
+		})
+	}
+}
+
+func TestSearchQuery_IsEmpty(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: true},
+		{query: "-java", want: true},
+		{query: `-java -"spring boot"`, want: true},
+		{query: "golang -java", want: false},
+		{query: `"error handling" -java`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.ParseSearchQuery(tt.query).IsEmpty())
+		})
+	}
+}
+
+func TestSearchQuery_String(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: `golang "error handling" -java`, want: `golang "error handling" -java`},
+		{query: `-java "Error Handling" GOLANG`, want: `golang "error handling" -java`},
+		{query: `-"spring boot"`, want: `-spring -boot`},
+		{query: ``, want: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q := utils.ParseSearchQuery(tt.query)
+			assert.Equal(t, tt.want, q.String())
+			assert.Equal(t, q, utils.ParseSearchQuery(q.String()), "the rendered query parses back the same")
+		})
+	}
+}
+
+func TestSearchQuery_Matches(t *testing.T) {
+	const text = "Error handling in Go: wrap errors, then handle them once."
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "terms are not required", query: "python", want: true},
+		{name: "phrase present", query: `"error handling"`, want: true},
+		{name: "phrase across punctuation", query: `"go wrap errors"`, want: true},
+		{name: "phrase words out of order", query: `"handling error"`, want: false},
+		{name: "phrase must match whole words", query: `"rror handling"`, want: false},
+		{name: "every phrase must appear", query: `"error handling" "panic recovery"`, want: false},
+		{name: "excluded word present", query: "golang -wrap", want: false},
+		{name: "excluded word absent", query: "golang -java", want: true},
+		{name: "exclusion matches whole words only", query: "-err", want: true},
+		{name: "excluded phrase word present", query: `-"java once"`, want: false},
+		{name: "phrase and exclusion", query: `"error handling" -panic`, want: true},
+		{name: "case-insensitive", query: `"ERROR Handling" -WRAP`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.ParseSearchQuery(tt.query).Matches(text))
+		})
+	}
+}
+
+func TestSearchQuery_HighlightTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: `golang "error handling" -java`, want: []string{"golang", "error", "handling"}},
+		{query: `error "error handling"`, want: []string{"error", "handling"}},
+		{query: `-java`, want: []string{}},
+		{query: `"the go way"`, want: []string{"the", "go", "way"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.ParseSearchQuery(tt.query).HighlightTerms())
+		})
+	}
+}
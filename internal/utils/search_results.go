@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"sort"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/contract"
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/domain/entity"
+)
+
+// Facet sizes for blog search
+const (
+	MaxFacetValues = 20
+	MaxFacetMonths = 24
+)
+
+// SortScoredBlogs orders search matches by score, best first, or by created_at, view_count,
+// like_count or popularity, highest first unless sortOrder is "asc". Ties go in ID order.
+func SortScoredBlogs(blogs []contract.ScoredBlog, sortBy, sortOrder string) {
+	key := func(b contract.ScoredBlog) float64 {
+		switch sortBy {
+		case "created_at":
+			return float64(b.Blog.CreatedAt.UnixNano())
+		case "view_count":
+			return float64(b.Blog.ViewCount)
+		case "like_count":
+			return float64(b.Blog.LikeCount)
+		case "popularity":
+			return b.Blog.Popularity
+		default:
+			return b.Score
+		}
+	}
+	asc := sortOrder == "asc" && sortBy != "" && sortBy != "relevance"
+	sort.SliceStable(blogs, func(i, j int) bool {
+		ki, kj := key(blogs[i]), key(blogs[j])
+		if ki != kj {
+			return (ki < kj) == asc
+		}
+		return blogs[i].Blog.ID < blogs[j].Blog.ID
+	})
+}
+
+// CountBlogFacets counts the blogs per tag, author, publication month and, optionally,
+// status, keeping the most common values and the newest months.
+func CountBlogFacets(blogs []*entity.Blog, includeStatus bool) *contract.BlogFacets {
+	tags := make(map[string]int64)
+	authors := make(map[string]int64)
+	months := make(map[string]int64)
+	statuses := make(map[string]int64)
+	for _, b := range blogs {
+		for _, t := range b.Tags {
+			tags[t]++
+		}
+		authors[b.AuthorID]++
+		if b.PublishedAt != nil {
+			months[b.PublishedAt.UTC().Format("2006-01")]++
+		}
+		statuses[string(b.Status)]++
+	}
+
+	facets := &contract.BlogFacets{
+		Tags:    topFacetCounts(tags),
+		Authors: topFacetCounts(authors),
+		Months:  make([]contract.FacetCount, 0, len(months)),
+	}
+	for month, count := range months {
+		facets.Months = append(facets.Months, contract.FacetCount{Value: month, Count: count})
+	}
+	sort.Slice(facets.Months, func(i, j int) bool { return facets.Months[i].Value > facets.Months[j].Value })
+	if len(facets.Months) > MaxFacetMonths {
+		facets.Months = facets.Months[:MaxFacetMonths]
+	}
+	if includeStatus {
+		facets.Statuses = topFacetCounts(statuses)
+	}
+	return facets
+}
+
+// topFacetCounts returns the most common values, ties in value order.
+func topFacetCounts(counts map[string]int64) []contract.FacetCount {
+	facets := make([]contract.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, contract.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	if len(facets) > MaxFacetValues {
+		facets = facets[:MaxFacetValues]
+	}
+	return facets
+}
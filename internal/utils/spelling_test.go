@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		limit int
+		want  int
+	}{
+		{name: "identical", a: "golang", b: "golang", limit: 1, want: 0},
+		{name: "both empty", a: "", b: "", limit: 1, want: 0},
+		{name: "insertion", a: "glang", b: "golang", limit: 1, want: 1},
+		{name: "deletion", a: "gollang", b: "golang", limit: 1, want: 1},
+		{name: "substitution", a: "gelang", b: "golang", limit: 1, want: 1},
+		{name: "adjacent swap is one edit", a: "golnag", b: "golang", limit: 1, want: 1},
+		{name: "two edits", a: "kubarnetis", b: "kubernetes", limit: 2, want: 2},
+		{name: "kitten to sitting", a: "kitten", b: "sitting", limit: 3, want: 3},
+		{name: "over the limit stops at limit+1", a: "kitten", b: "sitting", limit: 2, want: 3},
+		{name: "length gap over the limit", a: "go", b: "golang", limit: 2, want: 3},
+		{name: "empty against a word", a: "", b: "go", limit: 2, want: 2},
+		{name: "runes, not bytes", a: "café", b: "cafe", limit: 1, want: 1},
+		{name: "runes swapped", a: "naïve", b: "nïave", limit: 1, want: 1},
+		{name: "zero limit", a: "golang", b: "golanf", limit: 0, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.EditDistance(tt.a, tt.b, tt.limit))
+			assert.Equal(t, tt.want, utils.EditDistance(tt.b, tt.a, tt.limit), "distance is symmetric")
+		})
+	}
+}
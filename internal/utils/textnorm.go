@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps look-alike characters from other scripts and common leet-speak
+// substitutions onto the Latin letter they are usually meant to imitate.
+var confusables = map[rune]rune{
+	// Leet-speak
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '|': 'l', '€': 'e',
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// NormalizeForMatching folds text into lowercase Latin tokens so that obfuscated
+// words ("B4dW0rd", "bаdword" with a Cyrillic а, "b a d w o r d") compare equal
+// to their plain spelling. Runs of single-character tokens are joined together.
+func NormalizeForMatching(text string) []string {
+	// NFKD splits accented letters into base letter + combining mark and
+	// maps compatibility forms (fullwidth, ligatures) to their plain equivalents.
+	decomposed := norm.NFKD.String(text)
+
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range decomposed {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if mapped, ok := confusables[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) {
+			current.WriteRune(r)
+			continue
+		}
+		flush()
+	}
+	flush()
+
+	return joinSingleLetterRuns(tokens)
+}
+
+// SqueezeRepeats cuts every run of three or more identical characters down to keep
+// characters, so elongated words ("baaaad") can be matched against word lists.
+// Shorter runs are left alone so that ordinary double letters survive.
+func SqueezeRepeats(s string, keep int) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		n := j - i
+		if n >= 3 {
+			n = keep
+		}
+		b.WriteString(strings.Repeat(string(runes[i]), n))
+		i = j
+	}
+	return b.String()
+}
+
+// LongestRepeatedRun returns the length of the longest run of the same non-space character.
+func LongestRepeatedRun(s string) int {
+	longest, run := 0, 0
+	var prev rune
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			run = 0
+			prev = 0
+			continue
+		}
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// CountLinks returns the number of http(s) or www-style links in the text.
+func CountLinks(text string) int {
+	count := 0
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		if strings.Contains(field, "http://") || strings.Contains(field, "https://") || strings.HasPrefix(field, "www.") {
+			count++
+		}
+	}
+	return count
+}
+
+func joinSingleLetterRuns(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+	var run strings.Builder
+	for _, t := range tokens {
+		if len([]rune(t)) == 1 {
+			run.WriteString(t)
+			continue
+		}
+		if run.Len() > 0 {
+			out = append(out, run.String())
+			run.Reset()
+		}
+		out = append(out, t)
+	}
+	if run.Len() > 0 {
+		out = append(out, run.String())
+	}
+	return out
+}
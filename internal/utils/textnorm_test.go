@@ -0,0 +1,55 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeForMatching(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "plain words", text: "Hello, World!", want: []string{"hello", "world"}},
+		{name: "leet-speak", text: "B4dW0rd", want: []string{"badword"}},
+		{name: "symbol substitutions", text: "$ucce$$", want: []string{"success"}},
+		{name: "cyrillic look-alike", text: "bаdword", want: []string{"badword"}},
+		{name: "greek look-alikes", text: "κοτ", want: []string{"kot"}},
+		{name: "accents are dropped", text: "Café crème", want: []string{"cafe", "creme"}},
+		{name: "fullwidth letters", text: "ｆｕｌｌｗｉｄｔｈ", want: []string{"fullwidth"}},
+		{name: "spaced-out letters are joined", text: "b a d w o r d", want: []string{"badword"}},
+		{name: "single letters join only while in a run", text: "hello w-o-r-l-d again", want: []string{"hello", "world", "again"}},
+		{name: "other digits split words", text: "version 2 release", want: []string{"version", "release"}},
+		{name: "empty", text: "", want: []string{}},
+		{name: "punctuation only", text: "?! ...", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.NormalizeForMatching(tt.text))
+		})
+	}
+}
+
+func TestSqueezeRepeats(t *testing.T) {
+	tests := []struct {
+		s    string
+		keep int
+		want string
+	}{
+		{s: "baaaad", keep: 1, want: "bad"},
+		{s: "baaaad", keep: 2, want: "baad"},
+		{s: "good", keep: 1, want: "good"},
+		{s: "soooo cooool", keep: 1, want: "so col"},
+		{s: "zzz!!!", keep: 1, want: "z!"},
+		{s: "cööööl", keep: 1, want: "cöl"},
+		{s: "", keep: 1, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.SqueezeRepeats(tt.s, tt.keep))
+		})
+	}
+}
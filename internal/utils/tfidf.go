@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// stopWords are common English words that carry no topic and are left out of term vectors.
+var stopWords = map[string]struct{}{}
+
+func init() {
+	for _, w := range strings.Fields(`a about above after again against all also am an and any are as at be
+		because been before being below between both but by can could did do does doing down during each
+		few for from further had has have having he her here hers herself him himself his how i if in into
+		is it its itself just me more most my myself no nor not now of off on once only or other our ours
+		ourselves out over own same she should so some such than that the their theirs them themselves
+		then there these they this those through to too under until up very was we were what when where
+		which while who whom why will with would you your yours yourself yourselves`) {
+		stopWords[w] = struct{}{}
+	}
+}
+
+// IsStopWord reports whether a lowercase word is too common to carry any topic.
+func IsStopWord(word string) bool {
+	_, ok := stopWords[word]
+	return ok
+}
+
+// Tokenize splits text into lowercase word tokens, dropping stop words, single characters
+// and bare numbers.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || isNumeric(f) {
+			continue
+		}
+		if _, stop := stopWords[f]; stop {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
+
+// TFIDFVectors weighs the terms of each tokenized document by sublinear term frequency
+// times smoothed inverse document frequency across docs. Each vector keeps at most maxTerms
+// of its heaviest terms (all when maxTerms <= 0) and is L2-normalized, so the dot product
+// of two vectors is their cosine similarity.
+func TFIDFVectors(docs [][]string, maxTerms int) []map[string]float64 {
+	df := make(map[string]int)
+	counts := make([]map[string]int, len(docs))
+	for i, doc := range docs {
+		counts[i] = make(map[string]int)
+		for _, term := range doc {
+			counts[i][term]++
+		}
+		for term := range counts[i] {
+			df[term]++
+		}
+	}
+
+	n := float64(len(docs))
+	vectors := make([]map[string]float64, len(docs))
+	for i, tf := range counts {
+		type weighted struct {
+			term   string
+			weight float64
+		}
+		terms := make([]weighted, 0, len(tf))
+		for term, count := range tf {
+			idf := math.Log((1+n)/(1+float64(df[term]))) + 1
+			terms = append(terms, weighted{term, (1 + math.Log(float64(count))) * idf})
+		}
+		if maxTerms > 0 && len(terms) > maxTerms {
+			sort.Slice(terms, func(a, b int) bool {
+				if terms[a].weight != terms[b].weight {
+					return terms[a].weight > terms[b].weight
+				}
+				return terms[a].term < terms[b].term
+			})
+			terms = terms[:maxTerms]
+		}
+
+		var norm float64
+		for _, t := range terms {
+			norm += t.weight * t.weight
+		}
+		norm = math.Sqrt(norm)
+		vector := make(map[string]float64, len(terms))
+		for _, t := range terms {
+			vector[t.term] = t.weight / norm
+		}
+		vectors[i] = vector
+	}
+	return vectors
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "stop words are dropped", text: "The Go programming language", want: []string{"go", "programming", "language"}},
+		{name: "numbers and single characters are dropped", text: "Go 1.22 and C", want: []string{"go"}},
+		{name: "punctuation splits words", text: "Don't panic!", want: []string{"don", "panic"}},
+		{name: "letters with digits are kept", text: "v2 release", want: []string{"v2", "release"}},
+		{name: "non-ASCII letters", text: "Ünïcode wörds", want: []string{"ünïcode", "wörds"}},
+		{name: "empty", text: "", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.Tokenize(tt.text))
+		})
+	}
+}
+
+func TestTFIDFVectors(t *testing.T) {
+	corpus := [][]string{{"go", "go", "channels"}, {"go", "rust"}, {"python"}}
+
+	tests := []struct {
+		name     string
+		docs     [][]string
+		maxTerms int
+		want     []map[string]float64
+	}{
+		{
+			name: "repeated and rarer terms weigh more",
+			docs: corpus,
+			want: []map[string]float64{
+				{"go": 0.7898069, "channels": 0.6133555},
+				{"go": 0.6053485, "rust": 0.7959605},
+				{"python": 1},
+			},
+		},
+		{
+			name:     "only the heaviest terms are kept",
+			docs:     corpus,
+			maxTerms: 1,
+			want:     []map[string]float64{{"go": 1}, {"rust": 1}, {"python": 1}},
+		},
+		{
+			name:     "ties are broken by term",
+			docs:     [][]string{{"beta", "alpha"}},
+			maxTerms: 1,
+			want:     []map[string]float64{{"alpha": 1}},
+		},
+		{
+			name: "empty document",
+			docs: [][]string{{}, {"go"}},
+			want: []map[string]float64{{}, {"go": 1}},
+		},
+		{
+			name: "no documents",
+			docs: nil,
+			want: []map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.TFIDFVectors(tt.docs, tt.maxTerms)
+			if !assert.Len(t, got, len(tt.want)) {
+				return
+			}
+			for i, want := range tt.want {
+				assert.Len(t, got[i], len(want), "doc %d", i)
+				for term, weight := range want {
+					assert.InDelta(t, weight, got[i][term], 1e-6, "doc %d term %q", i, term)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"math"
+	"time"
+)
+
+// otherReactionWeight is how much a reaction other than a like or dislike adds to a trending score.
+const otherReactionWeight = 2.0
+
+// CalculateTrendingScore applies Hacker News style gravity to recent activity: the activity
+// points are divided by (ageHours + 2) ^ gravity, so newer posts rank above older posts with
+// the same activity. Activity is weighted as in CalculatePopularity. Scores never go below zero.
+func CalculateTrendingScore(views, likes, dislikes, reactions, comments int, age time.Duration, gravity float64) float64 {
+	points := CalculatePopularity(views, likes, dislikes, comments) + float64(reactions)*otherReactionWeight
+	if points <= 0 {
+		return 0
+	}
+	if age < 0 {
+		age = 0
+	}
+	return points / math.Pow(age.Hours()+2, gravity)
+}
@@ -0,0 +1,39 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikiasgoitom/A2SV-Backend-Blog-Starter-Project/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateTrendingScore(t *testing.T) {
+	tests := []struct {
+		name                                        string
+		views, likes, dislikes, reactions, comments int
+		age                                         time.Duration
+		gravity                                     float64
+		want                                        float64
+	}{
+		{name: "new post", views: 10, gravity: 1, want: 5},
+		{name: "weighted activity", likes: 2, reactions: 1, comments: 1, age: 2 * time.Hour, gravity: 1, want: 2.5},
+		{name: "higher gravity decays faster", likes: 2, reactions: 1, comments: 1, age: 2 * time.Hour, gravity: 2, want: 0.625},
+		{name: "no gravity ignores age", views: 10, age: 48 * time.Hour, gravity: 0, want: 10},
+		{name: "negative age counts as new", views: 10, age: -time.Hour, gravity: 1, want: 5},
+		{name: "dislikes never go below zero", views: 1, dislikes: 5, gravity: 1, want: 0},
+		{name: "no activity", age: time.Hour, gravity: 1.8, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.CalculateTrendingScore(tt.views, tt.likes, tt.dislikes, tt.reactions, tt.comments, tt.age, tt.gravity)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	t.Run("older posts rank below newer ones", func(t *testing.T) {
+		newer := utils.CalculateTrendingScore(100, 10, 0, 0, 5, time.Hour, 1.8)
+		older := utils.CalculateTrendingScore(100, 10, 0, 0, 5, 24*time.Hour, 1.8)
+		assert.Greater(t, newer, older)
+	})
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// fingerprintHeaders are the headers whose values go into a header fingerprint. Their
+// combination varies between browsers and versions but stays stable for one client.
+var fingerprintHeaders = []string{"accept", "accept-language", "accept-encoding", "sec-ch-ua", "sec-ch-ua-platform"}
+
+// HeaderFingerprint returns a short hash identifying a client by its User-Agent, the values
+// of a few telling headers and the set of header names it sent. headers must have lowercase
+// names.
+func HeaderFingerprint(userAgent string, headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(userAgent)
+	for _, name := range fingerprintHeaders {
+		b.WriteString("\n" + headers[name])
+	}
+	b.WriteString("\n" + strings.Join(names, ","))
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParseIPRanges parses CIDR ranges and bare addresses, skipping blank entries and
+// #-comments. Entries that do not parse are returned separately.
+func ParseIPRanges(entries []string) (ranges []netip.Prefix, invalid []string) {
+	for _, entry := range entries {
+		if i := strings.IndexByte(entry, '#'); i >= 0 {
+			entry = entry[:i]
+		}
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			ranges = append(ranges, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			ranges = append(ranges, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		invalid = append(invalid, entry)
+	}
+	return ranges, invalid
+}
+
+// IPInRanges reports whether ip falls inside any of the ranges.
+func IPInRanges(ip string, ranges []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, r := range ranges {
+		if r.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}